@@ -1,6 +1,8 @@
 package bdb
 
 import (
+	"fmt"
+	"regexp"
 	"strings"
 
 	"github.com/volatiletech/sqlboiler/strmangle"
@@ -16,6 +18,79 @@ type Column struct {
 	Nullable  bool
 	Unique    bool
 	Validated bool
+	Comment   string
+	// DBName is the name of the column in the database, it's the same as
+	// Name unless the names were normalized.
+	DBName string
+	// GoName is the Go identifier for Name, made by Options.NameMapper.
+	GoName string
+	// DefaultKind says what Default is, one of the Default* constants.
+	DefaultKind string
+	// TypeImport is the import path of the package of Type when it was
+	// given by a @gotype: hint, eg: github.com/acme/geo for geo.Address.
+	TypeImport string
+
+	// IsAutoIncrement is true when the database generates the value of
+	// this column from a sequence or identity on insert.
+	IsAutoIncrement bool
+	// Identity is how a postgres identity column is generated, ALWAYS or
+	// BY DEFAULT, and empty for other columns. ALWAYS columns can't be
+	// written so they're also AutoGenerated.
+	Identity string
+	// SequenceName is the sequence the default value of the column is taken
+	// from, it may be owned by another table when a sequence is shared.
+	SequenceName string
+	// SequenceLastValue is the last value taken from the sequence, nil if it
+	// hasn't been used or wasn't read. It's only read when
+	// Options.SequenceValues is set.
+	SequenceLastValue *int64
+	// Sequence describes the sequence of SequenceName, it's only read when
+	// Options.SequenceValues is set and is nil if the driver can't see it.
+	Sequence *Sequence
+	// GenerationExpression is the expression a generated column is computed
+	// from, eg: (qty * price). GeneratedFrom are the other columns of the
+	// table it uses, found by a best effort scan of the expression that may
+	// miss some, eg: in a function that takes the whole row.
+	GenerationExpression string
+	GeneratedFrom        []string
+	// OptionalOnInsert is true when the column may be omitted from an
+	// INSERT even if it is NOT NULL, because it has a default value or the
+	// value is generated by the database.
+	OptionalOnInsert bool
+	// Checks are the expressions of the check constraints that only use
+	// this column.
+	Checks []string
+	// ForeignKey is the entry of the table's FKeys for this column, or nil
+	// if it isn't a foreign key. Each column of a composite foreign key has
+	// its own entry, with the same constraint Name. A column in more than
+	// one foreign key gets the first by name.
+	ForeignKey *ForeignKey
+
+	// TextRepresentation is true for types the driver knows but reads as
+	// their text, eg: postgres' pg_lsn or point. Types the driver doesn't
+	// know at all become strings without it.
+	TextRepresentation bool
+
+	// UnknownType is true when the driver doesn't know the database type
+	// and fell back to its default Go type, usually a string.
+	UnknownType bool
+
+	// IsSystem is true for the system columns of a postgres table, eg: xmin
+	// or ctid, they're only read when asked for and can't be written.
+	IsSystem bool
+
+	// SpatialSRID and SpatialSubtype are the spatial reference system and
+	// geometry type (eg: POINT) of a PostGIS geometry or geography column,
+	// when they're constrained.
+	SpatialSRID    int
+	SpatialSubtype string
+
+	// Storage is how postgres stores the column's values: plain, main,
+	// external or extended. Compression is the method used to compress
+	// them (pglz or lz4, Postgres 14+), empty for the server's default.
+	// Both are only read when extended metadata is enabled.
+	Storage     string
+	Compression string
 
 	// Postgres only extension bits
 	// ArrType is the underlying data type of the Postgres
@@ -23,12 +98,26 @@ type Column struct {
 	// https://www.postgresql.org/docs/9.1/static/infoschema-element-types.html
 	ArrType *string
 	UDTName string
+	// NumericPrecision and NumericScale are the digits of a numeric column,
+	// eg: 10 and 2 for numeric(10,2). They're 0 for a numeric without a
+	// precision, which is unlimited.
+	NumericPrecision int
+	NumericScale     int
 
 	// MySQL only bits
 	// Used to get full type, ex:
 	// tinyint(1) instead of tinyint
 	// Used for "tinyint-as-bool" flag
 	FullDBType string
+	// DisplayWidth is the display width of an integer column, eg: 11 for
+	// int(11), and Zerofill is true for a ZEROFILL numeric column. MySQL 8
+	// only reports the width of zerofill columns, they are 0 and false when
+	// there is none.
+	DisplayWidth int
+	Zerofill     bool
+	// EnumValues are the values allowed in an ENUM or SET column, or an
+	// array of a postgres enum, in the order they were declared.
+	EnumValues []string
 
 	// MS SQL only bits
 	// Used to indicate that the value
@@ -36,6 +125,174 @@ type Column struct {
 	AutoGenerated bool
 }
 
+// Kinds of column defaults for Column.DefaultKind
+const (
+	DefaultNone     = ""
+	DefaultLiteral  = "literal"
+	DefaultSequence = "sequence"
+	DefaultFunction = "function"
+)
+
+// defaultKeywordFunctions are the sql functions that are called without
+// parentheses.
+var defaultKeywordFunctions = map[string]struct{}{
+	"current_timestamp": {}, "current_date": {}, "current_time": {}, "localtime": {},
+	"localtimestamp": {}, "current_user": {}, "session_user": {}, "system_user": {}, "user": {},
+}
+
+// rgxDefaultCall matches a default that starts with a function call,
+// eg: now(), gen_random_uuid(), pg_catalog.now() or [dbo].[fn]()
+var rgxDefaultCall = regexp.MustCompile(`^[\w\.\[\]"]+\s*\(`)
+
+// rgxDefaultCast matches a trailing postgres cast, eg: 'a'::text
+var rgxDefaultCast = regexp.MustCompile(`::[\w\s\."\[\]]+(\(\d+(,\s*\d+)?\))?$`)
+
+// classifyDefault works out the DefaultKind of a column from its Default,
+// a literal is anything that isn't a sequence or a function call.
+func classifyDefault(c Column) string {
+	if c.IsAutoIncrement || len(c.SequenceName) != 0 {
+		return DefaultSequence
+	}
+	if !c.HasDefault() {
+		return DefaultNone
+	}
+
+	def := stripDefault(c.Default)
+	lower := strings.ToLower(def)
+	switch {
+	case strings.HasPrefix(lower, "nextval("), strings.HasPrefix(lower, "next value for "):
+		return DefaultSequence
+	case strings.HasPrefix(def, "'"):
+		return DefaultLiteral
+	case rgxDefaultCall.MatchString(def):
+		return DefaultFunction
+	}
+
+	if _, ok := defaultKeywordFunctions[lower]; ok {
+		return DefaultFunction
+	}
+
+	return DefaultLiteral
+}
+
+// stripDefault removes the parentheses mssql wraps defaults in, eg: ((0)) or
+// (getdate()), and the postgres casts after them, eg: 'a'::text
+func stripDefault(def string) string {
+	def = strings.TrimSpace(def)
+	for len(def) > 1 && def[0] == '(' && def[len(def)-1] == ')' {
+		def = strings.TrimSpace(def[1 : len(def)-1])
+	}
+	for rgxDefaultCast.MatchString(def) && !strings.HasSuffix(def, "'") {
+		def = strings.TrimSpace(rgxDefaultCast.ReplaceAllString(def, ""))
+	}
+
+	return def
+}
+
+// DefaultValue returns the column's Default without its casts or the
+// parentheses around it, and a string literal without its quotes, eg:
+// 'active'::character varying is active and ((0)) is 0. Functions are
+// kept as they are, eg: nextval('jets_id_seq'::regclass). Default is still
+// the default as the database has it.
+func (c Column) DefaultValue() string {
+	def := stripDefault(c.Default)
+	// mssql's unicode strings, eg: (N'a')
+	if len(def) > 1 && def[0] == 'N' && def[1] == '\'' {
+		def = def[1:]
+	}
+	if len(def) < 2 || def[0] != '\'' || def[len(def)-1] != '\'' {
+		return def
+	}
+
+	return strings.Replace(def[1:len(def)-1], "''", "'", -1)
+}
+
+// NullType returns true if the column's Go type is a null type, for
+// nullable columns or with Options.ForceNullable.
+func (c Column) NullType() bool {
+	return strings.HasPrefix(c.Type, "null.")
+}
+
+// DefaultIsFunction returns true if the default of the column is a function
+// call, eg: now() or gen_random_uuid(), so it can't be known client side.
+func (c Column) DefaultIsFunction() bool {
+	return c.DefaultKind == DefaultFunction
+}
+
+// HasDefault returns true if the column has a default value. Auto increment
+// columns have one even when there's no Default, eg: postgres identity
+// columns, the database still gives them their value.
+func (c Column) HasDefault() bool {
+	return len(c.Default) != 0 || c.IsAutoIncrement
+}
+
+// rgxTypeHint finds a go type hint in a column comment, eg:
+// @gotype:github.com/acme/geo.Address, in parts: the slice and pointer
+// prefix, the directories of the import path, the package (or the type if
+// it isn't qualified) and the type.
+var rgxTypeHint = regexp.MustCompile(`@gotype:([\[\]\*]*)((?:[\w\.\-~]+/)*)([A-Za-z_]\w*)(?:\.([A-Za-z_]\w*))?`)
+
+// TypeHint returns the go type given in the column's comment with the
+// @gotype: marker and the import path of its package, eg: geo.Address and
+// github.com/acme/geo. The import path is empty for a type that isn't
+// qualified, eg: []string, and both are empty if there is no hint.
+func (c Column) TypeHint() (goType string, importPath string) {
+	match := rgxTypeHint.FindStringSubmatch(c.Comment)
+	if match == nil {
+		return "", ""
+	}
+
+	prefix, dirs, name, typ := match[1], match[2], match[3], match[4]
+	if len(typ) == 0 {
+		if len(dirs) != 0 {
+			// An import path without a type
+			return "", ""
+		}
+		return prefix + name, ""
+	}
+
+	return prefix + name + "." + typ, dirs + name
+}
+
+// rgxExpressionLiteral matches the string literals of an expression
+var rgxExpressionLiteral = regexp.MustCompile(`'(?:[^']|'')*'`)
+
+// rgxExpressionIdent matches the identifiers of an expression, quoted or
+// not, and whether they're called as a function.
+var rgxExpressionIdent = regexp.MustCompile("(?:\"((?:[^\"]|\"\")+)\"|`([^`]+)`|\\[([^\\]]+)\\]|\\b([A-Za-z_][A-Za-z0-9_$]*))(\\s*\\()?")
+
+// expressionColumns returns the columns of cols that expr uses, in the order
+// they first appear. Unquoted identifiers are compared case insensitively.
+func expressionColumns(expr string, cols []Column) []string {
+	expr = rgxExpressionLiteral.ReplaceAllString(expr, "''")
+
+	var names []string
+	for _, match := range rgxExpressionIdent.FindAllStringSubmatch(expr, -1) {
+		if len(match[5]) != 0 {
+			continue
+		}
+
+		for _, c := range cols {
+			var found bool
+			switch {
+			case len(match[1]) != 0:
+				found = c.Name == strings.Replace(match[1], `""`, `"`, -1)
+			case len(match[2]) != 0:
+				found = strings.EqualFold(c.Name, match[2])
+			case len(match[3]) != 0:
+				found = strings.EqualFold(c.Name, match[3])
+			default:
+				found = strings.EqualFold(c.Name, match[4])
+			}
+			if found && !strmangle.SetInclude(c.Name, names) {
+				names = append(names, c.Name)
+			}
+		}
+	}
+
+	return names
+}
+
 // ColumnNames of the columns.
 func ColumnNames(cols []Column) []string {
 	names := make([]string, len(cols))
@@ -51,7 +308,26 @@ func ColumnDBTypes(cols []Column) map[string]string {
 	types := map[string]string{}
 
 	for _, c := range cols {
-		types[strmangle.TitleCase(c.Name)] = c.DBType
+		goName := c.GoName
+		if len(goName) == 0 {
+			goName = strmangle.TitleCase(c.Name)
+		}
+		// A set's members are needed to randomize it, eg: set('a','b')
+		if c.DBType == "set" && len(c.FullDBType) != 0 {
+			types[goName] = c.FullDBType
+			continue
+		}
+		// PostGIS columns are randomized by geometry type and SRID, eg:
+		// geometry(POINT,4326)
+		if c.UDTName == "geometry" || c.UDTName == "geography" {
+			subtype := c.SpatialSubtype
+			if len(subtype) == 0 {
+				subtype = "GEOMETRY"
+			}
+			types[goName] = fmt.Sprintf("%s(%s,%d)", c.UDTName, strings.ToUpper(subtype), c.SpatialSRID)
+			continue
+		}
+		types[goName] = c.DBType
 	}
 
 	return types
@@ -75,7 +351,7 @@ func FilterColumnsByDefault(defaults bool, columns []Column) []Column {
 	var cols []Column
 
 	for _, c := range columns {
-		if (defaults && len(c.Default) != 0) || (!defaults && len(c.Default) == 0) {
+		if (defaults && c.HasDefault()) || (!defaults && !c.HasDefault()) {
 			cols = append(cols, c)
 		}
 	}
@@ -83,12 +359,23 @@ func FilterColumnsByDefault(defaults bool, columns []Column) []Column {
 	return cols
 }
 
-// FilterColumnsByEnum generates the list of columns that are enum values.
+// EnumDBType returns the enum type of the column, its DBType or the type
+// of the elements of a postgres array of an enum, eg: enum.mood('sad','ok')
+func (c Column) EnumDBType() string {
+	if c.ArrType != nil && strings.HasPrefix(*c.ArrType, "enum") {
+		return *c.ArrType
+	}
+
+	return c.DBType
+}
+
+// FilterColumnsByEnum generates the list of columns that are enum values,
+// or arrays of them.
 func FilterColumnsByEnum(columns []Column) []Column {
 	var cols []Column
 
 	for _, c := range columns {
-		if strings.HasPrefix(c.DBType, "enum") {
+		if strings.HasPrefix(c.EnumDBType(), "enum") {
 			cols = append(cols, c)
 		}
 	}
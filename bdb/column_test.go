@@ -20,10 +20,78 @@ func TestColumnNames(t *testing.T) {
 	}
 }
 
+func TestColumnHasDefault(t *testing.T) {
+	t.Parallel()
+
+	if (Column{}).HasDefault() {
+		t.Error("column without a default should not have one")
+	}
+	if !(Column{Default: "0"}).HasDefault() {
+		t.Error("column with a default should have one")
+	}
+	if !(Column{IsAutoIncrement: true}).HasDefault() {
+		t.Error("auto increment column should have a default")
+	}
+}
+
+func TestColumnDefaultValue(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		Default string
+		Want    string
+	}{
+		{"", ""},
+		{"'active'::character varying", "active"},
+		{"0::integer", "0"},
+		{"'{}'::text[]", "{}"},
+		{"'it''s'::text", "it's"},
+		{"((0))", "0"},
+		{"(N'draft')", "draft"},
+		{"now()", "now()"},
+		{"nextval('jets_id_seq'::regclass)", "nextval('jets_id_seq'::regclass)"},
+		{"true", "true"},
+	}
+
+	for i, test := range tests {
+		if got := (Column{Default: test.Default}).DefaultValue(); got != test.Want {
+			t.Errorf("%d) %s want %q, got %q", i, test.Default, test.Want, got)
+		}
+	}
+}
+
+func TestExpressionColumns(t *testing.T) {
+	t.Parallel()
+
+	cols := []Column{{Name: "qty"}, {Name: "price"}, {Name: "Tax Rate"}, {Name: "lower"}}
+	tests := []struct {
+		Expr string
+		Want string
+	}{
+		{"(qty * price)", "qty,price"},
+		{"((QTY * price) * (1 + \"Tax Rate\"))", "qty,price,Tax Rate"},
+		{"(price * price)", "price"},
+		{"lower(('qty: ' || qty))", "qty"},
+		{"`price` * 1e5", "price"},
+		{"(\"tax rate\" + 1)", ""},
+		{"now()", ""},
+	}
+
+	for i, test := range tests {
+		if got := strings.Join(expressionColumns(test.Expr, cols), ","); got != test.Want {
+			t.Errorf("%d) %s want %q, got %q", i, test.Expr, test.Want, got)
+		}
+	}
+}
+
 func TestColumnDBTypes(t *testing.T) {
 	cols := []Column{
 		{Name: "test_one", DBType: "integer"},
 		{Name: "test_two", DBType: "interval"},
+		{Name: "test_three", DBType: "set", FullDBType: "set('a','b')"},
+		{Name: "test_four", DBType: "geography", UDTName: "geography", SpatialSubtype: "Point", SpatialSRID: 4326},
+		{Name: "test_five", DBType: "geometry", UDTName: "geometry"},
+		{Name: "tbl_six", GoName: "Six", DBType: "text"},
 	}
 
 	res := ColumnDBTypes(cols)
@@ -33,6 +101,18 @@ func TestColumnDBTypes(t *testing.T) {
 	if res["TestTwo"] != "interval" {
 		t.Errorf(`Expected res["TestOne"]="interval", got: %s`, res["TestOne"])
 	}
+	if res["TestThree"] != "set('a','b')" {
+		t.Errorf(`Expected res["TestThree"]="set('a','b')", got: %s`, res["TestThree"])
+	}
+	if res["TestFour"] != "geography(POINT,4326)" {
+		t.Errorf(`Expected res["TestFour"]="geography(POINT,4326)", got: %s`, res["TestFour"])
+	}
+	if res["TestFive"] != "geometry(GEOMETRY,0)" {
+		t.Errorf(`Expected res["TestFive"]="geometry(GEOMETRY,0)", got: %s`, res["TestFive"])
+	}
+	if res["Six"] != "text" {
+		t.Errorf(`Expected res["Six"]="text", got: %s`, res["Six"])
+	}
 }
 
 func TestFilterColumnsByDefault(t *testing.T) {
@@ -61,6 +141,11 @@ func TestFilterColumnsByDefault(t *testing.T) {
 		t.Errorf("Invalid result: %#v", res)
 	}
 
+	res = FilterColumnsByDefault(true, []Column{{Name: "id", IsAutoIncrement: true}})
+	if len(res) != 1 {
+		t.Errorf("an identity column without a default should be included: %#v", res)
+	}
+
 	res = FilterColumnsByDefault(false, []Column{})
 	if res != nil {
 		t.Errorf("Invalid result: %#v", res)
@@ -85,4 +170,87 @@ func TestFilterColumnsByEnum(t *testing.T) {
 	if res[1].Name != `col2` {
 		t.Errorf("Invalid result: %#v", res)
 	}
+
+	moods := "enum.mood('sad','ok')"
+	cols = append(cols, Column{Name: "col6", DBType: "ARRAY" + moods, ArrType: &moods})
+	res = FilterColumnsByEnum(cols)
+	if len(res) != 4 || res[3].Name != "col6" || res[3].EnumDBType() != moods {
+		t.Errorf("the array of an enum should be an enum column: %#v", res)
+	}
+}
+
+func TestColumnTypeHint(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		Comment string
+		Want    string
+		Import  string
+	}{
+		{"", "", ""},
+		{"just a comment", "", ""},
+		{"@gotype:", "", ""},
+		{"@gotype:time.Time", "time.Time", "time"},
+		{"@gotype:github.com/acme/geo.Address", "geo.Address", "github.com/acme/geo"},
+		{"the address @gotype:*github.com/acme/geo.Address of a user", "*geo.Address", "github.com/acme/geo"},
+		{"@gotype:github.com/acme/geo", "", ""},
+		{"@gotype:[]string", "[]string", ""},
+	}
+
+	for i, test := range tests {
+		got, path := (Column{Comment: test.Comment}).TypeHint()
+		if got != test.Want || path != test.Import {
+			t.Errorf("%d) want %q from %q, got %q from %q", i, test.Want, test.Import, got, path)
+		}
+	}
+}
+
+func TestSetTypeHint(t *testing.T) {
+	t.Parallel()
+
+	c := Column{Name: "address", Type: "types.JSON", Comment: "@gotype:github.com/acme/geo.Address"}
+	if err := setTypeHint(&c); err != nil {
+		t.Fatal(err)
+	}
+	if c.Type != "geo.Address" || c.TypeImport != "github.com/acme/geo" {
+		t.Errorf("want the hinted type, got: %#v", c)
+	}
+
+	c = Column{Name: "address", Type: "null.JSON", Nullable: true, Comment: "@gotype:github.com/acme/geo.Address"}
+	if err := setTypeHint(&c); err == nil {
+		t.Error("want an error for a hint on a nullable column")
+	}
+}
+
+func TestClassifyDefault(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		Col  Column
+		Want string
+	}{
+		{Column{}, DefaultNone},
+		{Column{Default: "0"}, DefaultLiteral},
+		{Column{Default: "'hello'::character varying"}, DefaultLiteral},
+		{Column{Default: "'now()'::text"}, DefaultLiteral},
+		{Column{Default: "false"}, DefaultLiteral},
+		{Column{Default: "abc"}, DefaultLiteral},
+		{Column{Default: "((0))"}, DefaultLiteral},
+		{Column{Default: "now()"}, DefaultFunction},
+		{Column{Default: "gen_random_uuid()"}, DefaultFunction},
+		{Column{Default: "uuid_generate_v4()"}, DefaultFunction},
+		{Column{Default: "CURRENT_TIMESTAMP"}, DefaultFunction},
+		{Column{Default: "CURRENT_TIMESTAMP(6)"}, DefaultFunction},
+		{Column{Default: "(getdate())"}, DefaultFunction},
+		{Column{Default: "(now() + '1 day'::interval)"}, DefaultFunction},
+		{Column{Default: "nextval('users_id_seq'::regclass)", SequenceName: "users_id_seq"}, DefaultSequence},
+		{Column{Default: "(NEXT VALUE FOR [dbo].[seq])"}, DefaultSequence},
+		{Column{Default: "auto_increment", IsAutoIncrement: true}, DefaultSequence},
+	}
+
+	for i, test := range tests {
+		if got := classifyDefault(test.Col); got != test.Want {
+			t.Errorf("%d) %s: want %q, got %q", i, test.Col.Default, test.Want, got)
+		}
+	}
 }
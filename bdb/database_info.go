@@ -0,0 +1,14 @@
+package bdb
+
+// DatabaseInfo describes the database itself, which decides how its text
+// columns behave.
+type DatabaseInfo struct {
+	// Encoding is the character set text is stored in, eg: UTF8 or utf8mb4.
+	Encoding string
+	// Collation is how text is sorted and compared unless a column says
+	// otherwise, eg: en_US.UTF-8 or utf8mb4_0900_ai_ci.
+	Collation string
+	// ServerVersion is the version the server reports, eg: 14.5 or
+	// 8.0.32. It may have a suffix, eg: 14.5 (Debian 14.5-1.pgdg110+1)
+	ServerVersion string
+}
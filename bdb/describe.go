@@ -0,0 +1,72 @@
+package bdb
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+)
+
+// Describe writes a report of the tables to w as aligned text: the columns
+// of each table with their Go and database types, whether they're nullable
+// and their defaults, followed by its keys. It's to check what the driver
+// read before generating.
+func Describe(w io.Writer, tables []Table) error {
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+
+	for i, t := range tables {
+		if i != 0 {
+			fmt.Fprintln(tw)
+		}
+
+		fmt.Fprintf(tw, "%s%s\n", t.Name, describeTableKind(t))
+		fmt.Fprintln(tw, "  column\ttype\tdb type\tnull\tdefault")
+		for _, c := range t.Columns {
+			null := "no"
+			if c.Nullable {
+				null = "yes"
+			}
+			fmt.Fprintf(tw, "  %s\t%s\t%s\t%s\t%s\n", c.Name, c.Type, c.DBType, null, c.Default)
+		}
+
+		if t.PKey != nil {
+			fmt.Fprintf(tw, "  primary key %s (%s)\n", t.PKey.Name, strings.Join(t.PKey.Columns, ", "))
+		}
+		for _, u := range t.UKeys {
+			fmt.Fprintf(tw, "  unique %s (%s)", u.Name, strings.Join(u.Columns, ", "))
+			if len(u.Predicate) != 0 {
+				fmt.Fprintf(tw, " where %s", u.Predicate)
+			}
+			fmt.Fprintln(tw)
+		}
+		for _, group := range ForeignKeyGroups(t.FKeys) {
+			columns := make([]string, len(group))
+			foreign := make([]string, len(group))
+			for j, f := range group {
+				columns[j] = f.Column
+				foreign[j] = f.ForeignColumn
+			}
+			fmt.Fprintf(tw, "  foreign key %s (%s) references %s (%s)\n",
+				group[0].Name, strings.Join(columns, ", "), group[0].ForeignTable, strings.Join(foreign, ", "))
+		}
+	}
+
+	return tw.Flush()
+}
+
+// describeTableKind is what Describe says a table is when it's not a plain
+// table, eg: " (view)"
+func describeTableKind(t Table) string {
+	switch {
+	case t.IsMaterialized:
+		return " (materialized view)"
+	case t.IsRemote:
+		return " (remote view)"
+	case t.IsView:
+		return " (view)"
+	case t.IsJoinTable:
+		return " (join table)"
+	default:
+		return ""
+	}
+}
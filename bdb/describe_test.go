@@ -0,0 +1,47 @@
+package bdb
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestDescribe(t *testing.T) {
+	t.Parallel()
+
+	tables := []Table{
+		{
+			Name: "jets",
+			Columns: []Column{
+				{Name: "id", Type: "int", DBType: "integer", Default: "nextval('jets_id_seq'::regclass)"},
+				{Name: "pilot_id", Type: "null.Int", DBType: "integer", Nullable: true},
+			},
+			PKey:  &PrimaryKey{Name: "jets_pkey", Columns: []string{"id"}},
+			FKeys: []ForeignKey{{Table: "jets", Name: "jets_pilot_id_fkey", Column: "pilot_id", ForeignTable: "pilots", ForeignColumn: "id"}},
+		},
+		{
+			Name:    "pilot_names",
+			IsView:  true,
+			Columns: []Column{{Name: "name", Type: "string", DBType: "text"}},
+		},
+	}
+
+	var buf bytes.Buffer
+	if err := Describe(&buf, tables); err != nil {
+		t.Fatal(err)
+	}
+
+	want := `jets
+  column    type      db type  null  default
+  id        int       integer  no    nextval('jets_id_seq'::regclass)
+  pilot_id  null.Int  integer  yes   
+  primary key jets_pkey (id)
+  foreign key jets_pilot_id_fkey (pilot_id) references pilots (id)
+
+pilot_names (view)
+  column  type    db type  null  default
+  name    string  text     no    
+`
+	if got := buf.String(); got != want {
+		t.Errorf("want:\n%s\ngot:\n%s", want, got)
+	}
+}
@@ -0,0 +1,60 @@
+package bdb
+
+// ColumnChanges are the differences between the columns of a table at two
+// points in time. Added and Changed are the columns as they are now, Removed
+// as they were.
+type ColumnChanges struct {
+	Added   []Column
+	Changed []Column
+	Removed []Column
+}
+
+// DiffColumns compares the columns of the tables that are in both prior and
+// current, by table name, so new fields can be added to the models of the
+// tables already generated. A column changed when its database type, Go type
+// or nullability did. Tables that were added, removed or didn't change are
+// left out.
+func DiffColumns(prior, current []Table) map[string]ColumnChanges {
+	before := make(map[string]Table, len(prior))
+	for _, t := range prior {
+		before[t.Name] = t
+	}
+
+	diffs := map[string]ColumnChanges{}
+	for _, t := range current {
+		old, ok := before[t.Name]
+		if !ok {
+			continue
+		}
+
+		oldColumns := make(map[string]Column, len(old.Columns))
+		for _, c := range old.Columns {
+			oldColumns[c.Name] = c
+		}
+
+		var changes ColumnChanges
+		seen := make(map[string]struct{}, len(t.Columns))
+		for _, c := range t.Columns {
+			seen[c.Name] = struct{}{}
+
+			oldColumn, ok := oldColumns[c.Name]
+			switch {
+			case !ok:
+				changes.Added = append(changes.Added, c)
+			case columnSignature(c) != columnSignature(oldColumn) || c.Type != oldColumn.Type:
+				changes.Changed = append(changes.Changed, c)
+			}
+		}
+		for _, c := range old.Columns {
+			if _, ok := seen[c.Name]; !ok {
+				changes.Removed = append(changes.Removed, c)
+			}
+		}
+
+		if len(changes.Added) != 0 || len(changes.Changed) != 0 || len(changes.Removed) != 0 {
+			diffs[t.Name] = changes
+		}
+	}
+
+	return diffs
+}
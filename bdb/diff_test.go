@@ -0,0 +1,47 @@
+package bdb
+
+import "testing"
+
+func TestDiffColumns(t *testing.T) {
+	t.Parallel()
+
+	prior := []Table{
+		{Name: "pilots", Columns: []Column{{Name: "id", Type: "int", DBType: "integer"}}},
+		{Name: "jets", Columns: []Column{
+			{Name: "id", Type: "int", DBType: "integer"},
+			{Name: "name", Type: "string", DBType: "text"},
+			{Name: "color", Type: "string", DBType: "text"},
+			{Name: "size", Type: "int", DBType: "integer"},
+		}},
+		{Name: "hangars", Columns: []Column{{Name: "id", Type: "int", DBType: "integer"}}},
+	}
+	current := []Table{
+		{Name: "pilots", Columns: []Column{{Name: "id", Type: "int", DBType: "integer"}}},
+		{Name: "jets", Columns: []Column{
+			{Name: "id", Type: "int", DBType: "integer"},
+			{Name: "name", Type: "null.String", DBType: "text", Nullable: true},
+			{Name: "size", Type: "int64", DBType: "bigint"},
+			{Name: "wingspan", Type: "float64", DBType: "double precision"},
+		}},
+		{Name: "airports", Columns: []Column{{Name: "id", Type: "int", DBType: "integer"}}},
+	}
+
+	diffs := DiffColumns(prior, current)
+	if len(diffs) != 1 {
+		t.Fatalf("only jets changed, got: %#v", diffs)
+	}
+
+	jets := diffs["jets"]
+	if len(jets.Added) != 1 || jets.Added[0].Name != "wingspan" {
+		t.Errorf("wingspan should be added: %#v", jets.Added)
+	}
+	if len(jets.Changed) != 2 || jets.Changed[0].Name != "name" || jets.Changed[1].Name != "size" {
+		t.Errorf("name and size should be changed: %#v", jets.Changed)
+	}
+	if jets.Changed[1].DBType != "bigint" {
+		t.Errorf("changed columns should be as they are now: %#v", jets.Changed[1])
+	}
+	if len(jets.Removed) != 1 || jets.Removed[0].Name != "color" {
+		t.Errorf("color should be removed: %#v", jets.Removed)
+	}
+}
@@ -0,0 +1,375 @@
+package drivers
+
+import (
+	"database/sql"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+
+	// Side-effect import sql driver
+	_ "github.com/ClickHouse/clickhouse-go"
+	"github.com/pkg/errors"
+	"github.com/volatiletech/sqlboiler/bdb"
+)
+
+// ClickHouseDriver holds the database connection string and a handle
+// to the database connection.
+type ClickHouseDriver struct {
+	connStr string
+	dbConn  *sql.DB
+}
+
+// NewClickHouseDriver takes the database connection details as parameters and
+// returns a pointer to a ClickHouseDriver object. Note that it is required to
+// call ClickHouseDriver.Open() and ClickHouseDriver.Close() to open and close
+// the database connection once an object has been obtained.
+func NewClickHouseDriver(user, pass, dbname, host string, port int, sslmode string) *ClickHouseDriver {
+	driver := ClickHouseDriver{
+		connStr: ClickHouseBuildQueryString(user, pass, dbname, host, port, sslmode),
+	}
+
+	return &driver
+}
+
+// ClickHouseBuildQueryString builds a query string for ClickHouse.
+func ClickHouseBuildQueryString(user, pass, dbname, host string, port int, sslmode string) string {
+	query := url.Values{}
+	if len(user) != 0 {
+		query.Add("username", user)
+	}
+	if len(pass) != 0 {
+		query.Add("password", pass)
+	}
+	if len(dbname) != 0 {
+		query.Add("database", dbname)
+	}
+	if len(sslmode) != 0 {
+		query.Add("secure", sslmode)
+	}
+
+	if port == 0 {
+		port = 9000
+	}
+
+	u := &url.URL{
+		Scheme:   "tcp",
+		Host:     host + ":" + strconv.Itoa(port),
+		RawQuery: query.Encode(),
+	}
+
+	return u.String()
+}
+
+// Open opens the database connection using the connection string
+func (c *ClickHouseDriver) Open() error {
+	var err error
+	c.dbConn, err = sql.Open("clickhouse", c.connStr)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// Close closes the database connection
+func (c *ClickHouseDriver) Close() {
+	c.dbConn.Close()
+}
+
+// conn returns the database connection with the StatementTimeout.
+func (c *ClickHouseDriver) conn() queryer {
+	return withStatementTimeout(c.dbConn)
+}
+
+// UseLastInsertID returns false for clickhouse
+func (c *ClickHouseDriver) UseLastInsertID() bool {
+	return false
+}
+
+// UseTopClause returns false to indicate ClickHouse doesnt support SQL TOP clause
+func (c *ClickHouseDriver) UseTopClause() bool {
+	return false
+}
+
+// TableNames connects to the clickhouse database and
+// retrieves all table names from system.tables where the
+// database is schema. It uses a whitelist and blacklist.
+func (c *ClickHouseDriver) TableNames(schema string, whitelist, blacklist []string) ([]string, error) {
+	var names []string
+
+	query := `select name from system.tables where database = ? and is_temporary = 0 and engine not in ('View', 'MaterializedView')`
+	args := []interface{}{schema}
+	if len(whitelist) > 0 {
+		query += fmt.Sprintf(" and name in (%s)", questionMarks(len(whitelist)))
+		for _, w := range whitelist {
+			args = append(args, w)
+		}
+	} else if len(blacklist) > 0 {
+		query += fmt.Sprintf(" and name not in (%s)", questionMarks(len(blacklist)))
+		for _, b := range blacklist {
+			args = append(args, b)
+		}
+	}
+
+	query += " order by name"
+
+	rows, err := c.conn().Query(query, args...)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			return nil, err
+		}
+		names = append(names, name)
+	}
+
+	return names, nil
+}
+
+// Columns takes a table name and attempts to retrieve the table information
+// from system.columns. It retrieves the column names and column types and
+// returns those as a []Column after TranslateColumnType() converts the
+// ClickHouse types to Go types, for example: "String" to "string".
+//
+// Nullable(T) and LowCardinality(T) are unwrapped, the base type is stored
+// in DBType and the full type in FullDBType.
+func (c *ClickHouseDriver) Columns(schema, tableName string) ([]bdb.Column, error) {
+	var columns []bdb.Column
+
+	rows, err := c.conn().Query(`
+	select name, type, default_kind, default_expression
+	from system.columns
+	where database = ? and table = ?
+	order by position
+	`, schema, tableName)
+
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var colName, colFullType, defaultKind, defaultExpr string
+		if err := rows.Scan(&colName, &colFullType, &defaultKind, &defaultExpr); err != nil {
+			return nil, errors.Wrapf(err, "unable to scan for table %s", tableName)
+		}
+
+		base, nullable := clickhouseUnwrapType(colFullType)
+		column := bdb.Column{
+			Name:       colName,
+			FullDBType: colFullType,
+			DBType:     clickhouseTypeName(base),
+			Nullable:   nullable,
+		}
+
+		if column.DBType == "Array" {
+			elem, _ := clickhouseUnwrapType(base[len("Array(") : len(base)-1])
+			arrType := clickhouseTypeName(elem)
+			column.ArrType = &arrType
+		}
+
+		switch defaultKind {
+		case "DEFAULT":
+			column.Default = defaultExpr
+		case "MATERIALIZED", "ALIAS":
+			// These columns are computed by the database and can't be inserted
+			column.Default = defaultExpr
+			column.AutoGenerated = true
+		}
+
+		columns = append(columns, column)
+	}
+
+	return columns, nil
+}
+
+// clickhouseUnwrapType strips the Nullable and LowCardinality wrappers from a
+// ClickHouse type, eg: LowCardinality(Nullable(String)) is String and nullable.
+func clickhouseUnwrapType(typ string) (base string, nullable bool) {
+	base = strings.TrimSpace(typ)
+	for {
+		switch {
+		case strings.HasPrefix(base, "Nullable(") && strings.HasSuffix(base, ")"):
+			nullable = true
+			base = base[len("Nullable(") : len(base)-1]
+		case strings.HasPrefix(base, "LowCardinality(") && strings.HasSuffix(base, ")"):
+			base = base[len("LowCardinality(") : len(base)-1]
+		default:
+			return base, nullable
+		}
+	}
+}
+
+// clickhouseTypeName strips the parameters from a ClickHouse type,
+// eg: Decimal(18, 2) is Decimal and Array(UInt8) is Array.
+func clickhouseTypeName(typ string) string {
+	if i := strings.IndexByte(typ, '('); i >= 0 {
+		return typ[:i]
+	}
+
+	return typ
+}
+
+// PrimaryKeyInfo looks up the primary key for a table. ClickHouse primary
+// keys are the sorting key of a MergeTree table and are not unique.
+func (c *ClickHouseDriver) PrimaryKeyInfo(schema, tableName string) (*bdb.PrimaryKey, error) {
+	query := `
+	select name
+	from system.columns
+	where database = ? and table = ? and is_in_primary_key = 1
+	order by position`
+
+	rows, err := c.conn().Query(query, schema, tableName)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var columns []string
+	for rows.Next() {
+		var column string
+
+		err = rows.Scan(&column)
+		if err != nil {
+			return nil, err
+		}
+
+		columns = append(columns, column)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	if len(columns) == 0 {
+		return nil, nil
+	}
+
+	pkey := &bdb.PrimaryKey{
+		Name:    tableName + "_pkey",
+		Columns: columns,
+	}
+
+	return pkey, nil
+}
+
+// ForeignKeyInfo returns no foreign keys since ClickHouse doesn't have them.
+func (c *ClickHouseDriver) ForeignKeyInfo(schema, tableName string) ([]bdb.ForeignKey, error) {
+	return nil, nil
+}
+
+// TranslateColumnType converts clickhouse database types to Go types, for example
+// "String" to "string" and "UInt64" to "uint64". It returns this parsed data
+// as a Column object.
+func (c *ClickHouseDriver) TranslateColumnType(col bdb.Column) bdb.Column {
+	if col.Nullable {
+		switch col.DBType {
+		case "Int8":
+			col.Type = "null.Int8"
+		case "Int16":
+			col.Type = "null.Int16"
+		case "Int32":
+			col.Type = "null.Int32"
+		case "Int64":
+			col.Type = "null.Int64"
+		case "UInt8":
+			col.Type = "null.Uint8"
+		case "UInt16":
+			col.Type = "null.Uint16"
+		case "UInt32":
+			col.Type = "null.Uint32"
+		case "UInt64":
+			col.Type = "null.Uint64"
+		case "Float32":
+			col.Type = "null.Float32"
+		case "Float64":
+			col.Type = "null.Float64"
+		case "Bool":
+			col.Type = "null.Bool"
+		case "Date", "Date32", "DateTime", "DateTime64":
+			col.Type = "null.Time"
+		case "Array":
+			col.Type = getClickHouseArrayType(col)
+		case "String", "FixedString", "UUID", "Decimal", "Enum8", "Enum16", "IPv4", "IPv6":
+			col.Type = "null.String"
+		default:
+			col.Type = "null.String"
+			col.UnknownType = true
+		}
+	} else {
+		switch col.DBType {
+		case "Int8":
+			col.Type = "int8"
+		case "Int16":
+			col.Type = "int16"
+		case "Int32":
+			col.Type = "int32"
+		case "Int64":
+			col.Type = "int64"
+		case "UInt8":
+			col.Type = "uint8"
+		case "UInt16":
+			col.Type = "uint16"
+		case "UInt32":
+			col.Type = "uint32"
+		case "UInt64":
+			col.Type = "uint64"
+		case "Float32":
+			col.Type = "float32"
+		case "Float64":
+			col.Type = "float64"
+		case "Bool":
+			col.Type = "bool"
+		case "Date", "Date32", "DateTime", "DateTime64":
+			col.Type = "time.Time"
+		case "Array":
+			col.Type = getClickHouseArrayType(col)
+		case "String", "FixedString", "UUID", "Decimal", "Enum8", "Enum16", "IPv4", "IPv6":
+			col.Type = "string"
+		default:
+			col.Type = "string"
+			col.UnknownType = true
+		}
+	}
+
+	return col
+}
+
+// getClickHouseArrayType returns the correct types.Array type for each clickhouse type
+func getClickHouseArrayType(c bdb.Column) string {
+	if c.ArrType == nil {
+		panic("unable to get clickhouse Array underlying type")
+	}
+
+	switch *c.ArrType {
+	case "Int8", "Int16", "Int32", "Int64", "UInt8", "UInt16", "UInt32", "UInt64":
+		return "types.Int64Array"
+	case "Float32", "Float64":
+		return "types.Float64Array"
+	case "Bool":
+		return "types.BoolArray"
+	default:
+		return "types.StringArray"
+	}
+}
+
+// RightQuote is the quoting character for the right side of the identifier
+func (c *ClickHouseDriver) RightQuote() byte {
+	return '`'
+}
+
+// LeftQuote is the quoting character for the left side of the identifier
+func (c *ClickHouseDriver) LeftQuote() byte {
+	return '`'
+}
+
+// IndexPlaceholders returns false to indicate ClickHouse doesnt support indexed placeholders
+func (c *ClickHouseDriver) IndexPlaceholders() bool {
+	return false
+}
@@ -0,0 +1,166 @@
+package drivers
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/pkg/errors"
+)
+
+// The drivers build their connection string from the configuration, these
+// let an application connect with the same one instead of building it again.
+// ConnectionString contains the password, String is the one to log.
+
+// healthCheck pings db within the deadline of ctx, db is nil when the
+// driver wasn't opened or reads the schema from a file.
+func healthCheck(ctx context.Context, db *sql.DB) error {
+	if db == nil {
+		return errors.New("health check failed: there is no open database connection")
+	}
+
+	err := db.PingContext(ctx)
+	if err == nil {
+		return nil
+	}
+	if ctx.Err() == context.DeadlineExceeded {
+		return errors.Wrap(err, "health check failed: the database did not respond before the deadline")
+	}
+
+	return errors.Wrap(err, "health check failed")
+}
+
+// ConnectionString returns the connection string with its password.
+func (p *PostgresDriver) ConnectionString() string {
+	return p.connStr
+}
+
+// OpenDB opens a new connection pool with the connection string, it's
+// independent of the one used to read the schema and must be closed by the
+// caller.
+func (p *PostgresDriver) OpenDB() (*sql.DB, error) {
+	return sql.Open(p.sqlDriverName(), p.connStr)
+}
+
+// HealthCheck pings the open database connection within the deadline of ctx.
+func (p *PostgresDriver) HealthCheck(ctx context.Context) error {
+	return healthCheck(ctx, p.dbConn)
+}
+
+// ConnectionString returns the data source name with its password.
+func (m *MySQLDriver) ConnectionString() string {
+	return m.connStr
+}
+
+// OpenDB opens a new connection pool with the data source name, it's
+// independent of the one used to read the schema and must be closed by the
+// caller.
+func (m *MySQLDriver) OpenDB() (*sql.DB, error) {
+	return sql.Open("mysql", m.connStr)
+}
+
+// HealthCheck pings the open database connection within the deadline of ctx.
+func (m *MySQLDriver) HealthCheck(ctx context.Context) error {
+	return healthCheck(ctx, m.dbConn)
+}
+
+// ConnectionString returns the connection url with its password.
+func (m *MSSQLDriver) ConnectionString() string {
+	return m.connStr
+}
+
+// OpenDB opens a new connection pool with the connection url, it's
+// independent of the one used to read the schema and must be closed by the
+// caller.
+func (m *MSSQLDriver) OpenDB() (*sql.DB, error) {
+	return sql.Open("mssql", m.connStr)
+}
+
+// HealthCheck pings the open database connection within the deadline of ctx.
+func (m *MSSQLDriver) HealthCheck(ctx context.Context) error {
+	return healthCheck(ctx, m.dbConn)
+}
+
+// ConnectionString returns the database path.
+func (s *SpannerDriver) ConnectionString() string {
+	return s.connStr
+}
+
+// OpenDB opens a new connection pool with the database path, it's
+// independent of the one used to read the schema and must be closed by the
+// caller.
+func (s *SpannerDriver) OpenDB() (*sql.DB, error) {
+	return sql.Open("spanner", s.connStr)
+}
+
+// HealthCheck pings the open database connection within the deadline of ctx.
+func (s *SpannerDriver) HealthCheck(ctx context.Context) error {
+	return healthCheck(ctx, s.dbConn)
+}
+
+// ConnectionString returns the connection url with its password.
+func (c *ClickHouseDriver) ConnectionString() string {
+	return c.connStr
+}
+
+// OpenDB opens a new connection pool with the connection url, it's
+// independent of the one used to read the schema and must be closed by the
+// caller.
+func (c *ClickHouseDriver) OpenDB() (*sql.DB, error) {
+	return sql.Open("clickhouse", c.connStr)
+}
+
+// HealthCheck pings the open database connection within the deadline of ctx.
+func (c *ClickHouseDriver) HealthCheck(ctx context.Context) error {
+	return healthCheck(ctx, c.dbConn)
+}
+
+// ConnectionString returns the connection url with its password.
+func (v *VerticaDriver) ConnectionString() string {
+	return v.connStr
+}
+
+// OpenDB opens a new connection pool with the connection url, it's
+// independent of the one used to read the schema and must be closed by the
+// caller.
+func (v *VerticaDriver) OpenDB() (*sql.DB, error) {
+	return sql.Open("vertica", v.connStr)
+}
+
+// HealthCheck pings the open database connection within the deadline of ctx.
+func (v *VerticaDriver) HealthCheck(ctx context.Context) error {
+	return healthCheck(ctx, v.dbConn)
+}
+
+// ConnectionString returns the data source name with its password.
+func (s *SnowflakeDriver) ConnectionString() string {
+	return s.connStr
+}
+
+// OpenDB opens a new connection pool with the data source name, it's
+// independent of the one used to read the schema and must be closed by the
+// caller.
+func (s *SnowflakeDriver) OpenDB() (*sql.DB, error) {
+	return sql.Open("snowflake", s.connStr)
+}
+
+// HealthCheck pings the open database connection within the deadline of ctx.
+func (s *SnowflakeDriver) HealthCheck(ctx context.Context) error {
+	return healthCheck(ctx, s.dbConn)
+}
+
+// ConnectionString returns the data source name as it was given.
+func (g *GenericSQLDriver) ConnectionString() string {
+	return g.connStr
+}
+
+// OpenDB opens a new connection pool with the database/sql driver and data
+// source name, it's independent of the one used to read the schema and must
+// be closed by the caller.
+func (g *GenericSQLDriver) OpenDB() (*sql.DB, error) {
+	return sql.Open(g.driverName, g.connStr)
+}
+
+// HealthCheck pings the open database connection within the deadline of ctx.
+func (g *GenericSQLDriver) HealthCheck(ctx context.Context) error {
+	return healthCheck(ctx, g.dbConn)
+}
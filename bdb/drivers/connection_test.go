@@ -0,0 +1,84 @@
+package drivers
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/volatiletech/sqlboiler/bdb"
+
+	"gopkg.in/DATA-DOG/go-sqlmock.v1"
+)
+
+func TestConnectionString(t *testing.T) {
+	t.Parallel()
+
+	drivers := []interface {
+		String() string
+		ConnectionString() string
+	}{
+		NewPostgresDriver("bob", "s3cret", "shop", "localhost", 5432, "disable"),
+		NewMySQLDriver("bob", "s3cret", "shop", "localhost", 3306, "true"),
+		NewMSSQLDriver("bob", "s3cret", "shop", "localhost", 1433, "true"),
+		NewClickHouseDriver("bob", "s3cret", "shop", "localhost", 9000, ""),
+		NewVerticaDriver("bob", "s3cret", "shop", "localhost", 5433, ""),
+		NewSnowflakeDriver("bob", "s3cret", "acme-eu1", "shop", "etl", "analyst"),
+	}
+
+	for i, d := range drivers {
+		if conn := d.ConnectionString(); !strings.Contains(conn, "s3cret") {
+			t.Errorf("%d) connection string should keep the password: %s", i, conn)
+		}
+		if strings.Contains(d.String(), "s3cret") {
+			t.Errorf("%d) String should still redact the password: %s", i, d.String())
+		}
+	}
+}
+
+func TestPostgresSQLDriverName(t *testing.T) {
+	p := &PostgresDriver{}
+	if name := p.sqlDriverName(); name != "postgres" {
+		t.Errorf("want postgres, got %s", name)
+	}
+
+	UsePgx = true
+	defer func() { UsePgx = false }()
+	if name := p.sqlDriverName(); name != "pgx" {
+		t.Errorf("want pgx, got %s", name)
+	}
+
+	PostgresSQLDriverName = "cloudsqlpostgres"
+	defer func() { PostgresSQLDriverName = "" }()
+	if name := p.sqlDriverName(); name != "cloudsqlpostgres" {
+		t.Errorf("want cloudsqlpostgres, got %s", name)
+	}
+}
+
+func TestHealthCheck(t *testing.T) {
+	t.Parallel()
+
+	db, _, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	var checker bdb.HealthChecker = &PostgresDriver{dbConn: db}
+	for i := 0; i < 2; i++ {
+		if err := checker.HealthCheck(context.Background()); err != nil {
+			t.Errorf("%d) health check failed: %v", i, err)
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Nanosecond)
+	defer cancel()
+	<-ctx.Done()
+	if err := checker.HealthCheck(ctx); err == nil || !strings.Contains(err.Error(), "deadline") {
+		t.Errorf("want a deadline error, got: %v", err)
+	}
+
+	if err := (&MySQLDriver{}).HealthCheck(context.Background()); err == nil {
+		t.Error("want an error without a connection")
+	}
+}
@@ -0,0 +1,389 @@
+package drivers
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/volatiletech/sqlboiler/bdb"
+)
+
+// GenericSQLDriver reads the schema of any database with a database/sql
+// driver and a standard information_schema. It only uses ANSI queries and
+// types, drivers for databases that differ can embed it and override the
+// methods that don't work.
+type GenericSQLDriver struct {
+	driverName string
+	connStr    string
+	dbConn     *sql.DB
+
+	// IndexedPlaceholders makes the queries use $1 style placeholders
+	// instead of ?, for database/sql drivers that need them.
+	IndexedPlaceholders bool
+}
+
+// NewGenericSQLDriver takes the name a database/sql driver is registered
+// under and its data source name and returns a pointer to a
+// GenericSQLDriver object. The database/sql driver must be imported by
+// the caller. Note that it is required to call GenericSQLDriver.Open() and
+// GenericSQLDriver.Close() to open and close the database connection once
+// an object has been obtained.
+func NewGenericSQLDriver(driverName, dsn string) *GenericSQLDriver {
+	driver := GenericSQLDriver{
+		driverName: driverName,
+		connStr:    dsn,
+	}
+
+	return &driver
+}
+
+// Open opens the database connection using the connection string
+func (g *GenericSQLDriver) Open() error {
+	var err error
+	g.dbConn, err = sql.Open(g.driverName, g.connStr)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// Close closes the database connection
+func (g *GenericSQLDriver) Close() {
+	g.dbConn.Close()
+}
+
+// conn returns the database connection with the StatementTimeout.
+func (g *GenericSQLDriver) conn() queryer {
+	return withStatementTimeout(g.dbConn)
+}
+
+// String returns the database/sql driver name, the format of the data
+// source name isn't known so it could leak a password.
+func (g *GenericSQLDriver) String() string {
+	return g.driverName
+}
+
+// rebind replaces the ? placeholders of query with $1 style ones when
+// IndexedPlaceholders is set.
+func (g *GenericSQLDriver) rebind(query string) string {
+	if g.IndexedPlaceholders {
+		return rebind(placeholderDollar, query)
+	}
+
+	return query
+}
+
+// UseLastInsertID returns false, not every database supports it
+func (g *GenericSQLDriver) UseLastInsertID() bool {
+	return false
+}
+
+// UseTopClause returns false, TOP isn't standard SQL
+func (g *GenericSQLDriver) UseTopClause() bool {
+	return false
+}
+
+// TableNames retrieves the base tables in schema from
+// information_schema.tables. It uses a whitelist and blacklist.
+func (g *GenericSQLDriver) TableNames(schema string, whitelist, blacklist []string) ([]string, error) {
+	var names []string
+
+	query := `select table_name from information_schema.tables where table_schema = ? and table_type = 'BASE TABLE'`
+	args := []interface{}{schema}
+	if len(whitelist) > 0 {
+		query += fmt.Sprintf(" and table_name in (%s)", questionMarks(len(whitelist)))
+		for _, w := range whitelist {
+			args = append(args, w)
+		}
+	} else if len(blacklist) > 0 {
+		query += fmt.Sprintf(" and table_name not in (%s)", questionMarks(len(blacklist)))
+		for _, b := range blacklist {
+			args = append(args, b)
+		}
+	}
+
+	query += " order by table_name"
+
+	rows, err := g.conn().Query(g.rebind(query), args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			return nil, err
+		}
+		names = append(names, name)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return names, nil
+}
+
+// Columns retrieves the columns of a table from information_schema.columns
+// and returns them as a []Column after TranslateColumnType() converts the
+// SQL types to Go types.
+func (g *GenericSQLDriver) Columns(schema, tableName string) ([]bdb.Column, error) {
+	var columns []bdb.Column
+
+	unique, err := g.uniqueColumns(schema, tableName)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := g.conn().Query(g.rebind(`
+	select column_name, data_type, column_default, is_nullable
+	from information_schema.columns
+	where table_schema = ? and table_name = ?
+	order by ordinal_position
+	`), schema, tableName)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var colName, colType, nullable string
+		var defaultValue *string
+		if err := rows.Scan(&colName, &colType, &defaultValue, &nullable); err != nil {
+			return nil, errors.Wrapf(err, "unable to scan for table %s", tableName)
+		}
+
+		column := bdb.Column{
+			Name:     colName,
+			DBType:   strings.ToLower(colType),
+			Nullable: strings.EqualFold(nullable, "YES"),
+			Unique:   unique[colName],
+		}
+		if defaultValue != nil {
+			column.Default = *defaultValue
+		}
+
+		columns = append(columns, column)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return columns, nil
+}
+
+// uniqueColumns finds the columns that have a unique constraint of their own
+func (g *GenericSQLDriver) uniqueColumns(schema, tableName string) (map[string]bool, error) {
+	constraints, err := g.constraintColumns(schema, tableName, "UNIQUE")
+	if err != nil {
+		return nil, err
+	}
+
+	unique := map[string]bool{}
+	for _, c := range constraints {
+		if len(c.columns) == 1 {
+			unique[c.columns[0]] = true
+		}
+	}
+
+	return unique, nil
+}
+
+// genericConstraint is a constraint and its columns in order
+type genericConstraint struct {
+	name    string
+	columns []string
+}
+
+// constraintColumns reads the constraints of a type, eg: PRIMARY KEY, and
+// their columns.
+func (g *GenericSQLDriver) constraintColumns(schema, tableName, constraintType string) ([]genericConstraint, error) {
+	query := g.rebind(`
+	select tc.constraint_name, kcu.column_name
+	from information_schema.table_constraints tc
+		inner join information_schema.key_column_usage kcu
+			on tc.constraint_schema = kcu.constraint_schema and tc.constraint_name = kcu.constraint_name and
+				tc.table_name = kcu.table_name
+	where tc.table_schema = ? and tc.table_name = ? and tc.constraint_type = ?
+	order by tc.constraint_name, kcu.ordinal_position
+	`)
+
+	rows, err := g.conn().Query(query, schema, tableName, constraintType)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var constraints []genericConstraint
+	for rows.Next() {
+		var name, column string
+		if err = rows.Scan(&name, &column); err != nil {
+			return nil, err
+		}
+
+		if len(constraints) == 0 || constraints[len(constraints)-1].name != name {
+			constraints = append(constraints, genericConstraint{name: name})
+		}
+		last := &constraints[len(constraints)-1]
+		last.columns = append(last.columns, column)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return constraints, nil
+}
+
+// matchType converts information_schema.referential_constraints'
+// match_option to one of the bdb Match constants, the standard calls
+// simple matching NONE.
+func matchType(option string) string {
+	switch option = strings.ToUpper(option); option {
+	case bdb.MatchFull, bdb.MatchPartial:
+		return option
+	default:
+		return bdb.MatchSimple
+	}
+}
+
+// PrimaryKeyInfo looks up the primary key for a table.
+func (g *GenericSQLDriver) PrimaryKeyInfo(schema, tableName string) (*bdb.PrimaryKey, error) {
+	constraints, err := g.constraintColumns(schema, tableName, "PRIMARY KEY")
+	if err != nil {
+		return nil, err
+	}
+	if len(constraints) == 0 {
+		return nil, nil
+	}
+
+	pkey := &bdb.PrimaryKey{
+		Name:    constraints[0].name,
+		Columns: constraints[0].columns,
+	}
+
+	return pkey, nil
+}
+
+// ForeignKeyInfo retrieves the foreign keys for a given table name, using
+// information_schema.referential_constraints to match each column with the
+// column it references.
+func (g *GenericSQLDriver) ForeignKeyInfo(schema, tableName string) ([]bdb.ForeignKey, error) {
+	var fkeys []bdb.ForeignKey
+
+	query := g.rebind(`
+	select rc.constraint_name, kcu.column_name, fkcu.table_name, fkcu.column_name, rc.match_option
+	from information_schema.referential_constraints rc
+		inner join information_schema.key_column_usage kcu
+			on rc.constraint_schema = kcu.constraint_schema and rc.constraint_name = kcu.constraint_name
+		inner join information_schema.key_column_usage fkcu
+			on rc.unique_constraint_schema = fkcu.constraint_schema and rc.unique_constraint_name = fkcu.constraint_name and
+				kcu.position_in_unique_constraint = fkcu.ordinal_position
+	where kcu.table_schema = ? and fkcu.table_schema = ? and kcu.table_name = ?
+	order by rc.constraint_name, kcu.ordinal_position
+	`)
+
+	rows, err := g.conn().Query(query, schema, schema, tableName)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var fkey bdb.ForeignKey
+
+		fkey.Schema = schema
+		fkey.Table = tableName
+		err = rows.Scan(&fkey.Name, &fkey.Column, &fkey.ForeignTable, &fkey.ForeignColumn, &fkey.MatchType)
+		if err != nil {
+			return nil, err
+		}
+		fkey.MatchType = matchType(fkey.MatchType)
+
+		fkeys = append(fkeys, fkey)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return fkeys, nil
+}
+
+// TranslateColumnType converts the ANSI SQL types to Go types, for example
+// "character varying" to "string" and "bigint" to "int64". Types that
+// aren't standard become strings.
+func (g *GenericSQLDriver) TranslateColumnType(c bdb.Column) bdb.Column {
+	if c.Nullable {
+		switch c.DBType {
+		case "smallint":
+			c.Type = "null.Int16"
+		case "integer", "int":
+			c.Type = "null.Int"
+		case "bigint":
+			c.Type = "null.Int64"
+		case "real":
+			c.Type = "null.Float32"
+		case "decimal", "numeric", "double precision", "float":
+			c.Type = "null.Float64"
+		case "boolean":
+			c.Type = "null.Bool"
+		case "date", "time", "timestamp", "time with time zone", "time without time zone",
+			"timestamp with time zone", "timestamp without time zone":
+			c.Type = "null.Time"
+		case "binary", "binary varying", "varbinary", "binary large object", "blob":
+			c.Type = "null.Bytes"
+		case "character", "character varying", "character large object", "char", "varchar", "text", "interval":
+			c.Type = "null.String"
+		default:
+			c.Type = "null.String"
+			c.UnknownType = true
+		}
+	} else {
+		switch c.DBType {
+		case "smallint":
+			c.Type = "int16"
+		case "integer", "int":
+			c.Type = "int"
+		case "bigint":
+			c.Type = "int64"
+		case "real":
+			c.Type = "float32"
+		case "decimal", "numeric", "double precision", "float":
+			c.Type = "float64"
+		case "boolean":
+			c.Type = "bool"
+		case "date", "time", "timestamp", "time with time zone", "time without time zone",
+			"timestamp with time zone", "timestamp without time zone":
+			c.Type = "time.Time"
+		case "binary", "binary varying", "varbinary", "binary large object", "blob":
+			c.Type = "[]byte"
+		case "character", "character varying", "character large object", "char", "varchar", "text", "interval":
+			c.Type = "string"
+		default:
+			c.Type = "string"
+			c.UnknownType = true
+		}
+	}
+
+	return c
+}
+
+// RightQuote is the quoting character for the right side of the identifier
+func (g *GenericSQLDriver) RightQuote() byte {
+	return '"'
+}
+
+// LeftQuote is the quoting character for the left side of the identifier
+func (g *GenericSQLDriver) LeftQuote() byte {
+	return '"'
+}
+
+// IndexPlaceholders returns IndexedPlaceholders
+func (g *GenericSQLDriver) IndexPlaceholders() bool {
+	return g.IndexedPlaceholders
+}
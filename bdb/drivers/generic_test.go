@@ -0,0 +1,76 @@
+package drivers
+
+import (
+	"testing"
+
+	"gopkg.in/DATA-DOG/go-sqlmock.v1"
+)
+
+func TestGenericSQLDriverColumns(t *testing.T) {
+	t.Parallel()
+
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	mock.ExpectQuery(`from information_schema.table_constraints tc`).
+		WithArgs("main", "users", "UNIQUE").
+		WillReturnRows(sqlmock.NewRows([]string{"constraint_name", "column_name"}).
+			AddRow("users_email_key", "email").
+			AddRow("users_name_org_key", "name").
+			AddRow("users_name_org_key", "org"))
+	mock.ExpectQuery(`from information_schema.columns`).
+		WithArgs("main", "users").
+		WillReturnRows(sqlmock.NewRows([]string{"column_name", "data_type", "column_default", "is_nullable"}).
+			AddRow("id", "BIGINT", nil, "NO").
+			AddRow("email", "CHARACTER VARYING", nil, "NO").
+			AddRow("name", "CHARACTER VARYING", "'bob'", "YES"))
+
+	g := &GenericSQLDriver{dbConn: db}
+	cols, err := g.Columns("main", "users")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(cols) != 3 {
+		t.Fatalf("want 3 columns, got: %#v", cols)
+	}
+	if c := cols[0]; c.DBType != "bigint" || c.Nullable || c.Unique {
+		t.Errorf("id was wrong: %#v", c)
+	}
+	if c := cols[1]; !c.Unique {
+		t.Errorf("email should be unique: %#v", c)
+	}
+	if c := cols[2]; c.Unique || !c.Nullable || c.Default != "'bob'" {
+		t.Errorf("name was wrong: %#v", c)
+	}
+
+	if c := g.TranslateColumnType(cols[0]); c.Type != "int64" {
+		t.Errorf("id type was wrong: %s", c.Type)
+	}
+	if c := g.TranslateColumnType(cols[2]); c.Type != "null.String" {
+		t.Errorf("name type was wrong: %s", c.Type)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestGenericSQLDriverPlaceholders(t *testing.T) {
+	t.Parallel()
+
+	query := "where table_schema = ? and table_name in (" + questionMarks(3) + ")"
+
+	g := &GenericSQLDriver{}
+	if got := g.rebind(query); got != "where table_schema = ? and table_name in (?, ?, ?)" {
+		t.Errorf("placeholders were wrong: %s", got)
+	}
+
+	g.IndexedPlaceholders = true
+	if got := g.rebind(query); got != "where table_schema = $1 and table_name in ($2, $3, $4)" {
+		t.Errorf("indexed placeholders were wrong: %s", got)
+	}
+}
@@ -0,0 +1,47 @@
+package drivers
+
+import "github.com/pkg/errors"
+
+// IntTypes are the Go types of the signed integer columns of a driver, for
+// projects that want them all as int or int64 rather than sized to fit.
+// An empty field keeps the driver's type, nullable columns get the null
+// type that goes with it.
+type IntTypes struct {
+	Smallint string
+	Integer  string
+	Bigint   string
+}
+
+// intNullTypes are the Go types IntTypes can use, with their null types
+var intNullTypes = map[string]string{
+	"int":   "null.Int",
+	"int16": "null.Int16",
+	"int32": "null.Int32",
+	"int64": "null.Int64",
+}
+
+// Validate checks that each type is one of int, int16, int32 or int64.
+func (i IntTypes) Validate() error {
+	for _, goType := range []string{i.Smallint, i.Integer, i.Bigint} {
+		if _, ok := intNullTypes[goType]; len(goType) != 0 && !ok {
+			return errors.Errorf("%s isn't an integer type, it must be one of int, int16, int32 or int64", goType)
+		}
+	}
+
+	return nil
+}
+
+// forDBType returns the Go type set for the database type, or an empty
+// string if it isn't an integer type or wasn't set.
+func (i IntTypes) forDBType(dbType string) string {
+	switch dbType {
+	case "smallint", "smallserial":
+		return i.Smallint
+	case "integer", "int", "serial":
+		return i.Integer
+	case "bigint", "bigserial":
+		return i.Bigint
+	}
+
+	return ""
+}
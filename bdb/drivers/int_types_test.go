@@ -0,0 +1,68 @@
+package drivers
+
+import (
+	"testing"
+
+	"github.com/volatiletech/sqlboiler/bdb"
+)
+
+func TestIntTypesValidate(t *testing.T) {
+	t.Parallel()
+
+	if err := (IntTypes{}).Validate(); err != nil {
+		t.Error(err)
+	}
+	if err := (IntTypes{Smallint: "int", Integer: "int64", Bigint: "int64"}).Validate(); err != nil {
+		t.Error(err)
+	}
+	if err := (IntTypes{Integer: "uint8"}).Validate(); err == nil {
+		t.Error("uint8 should not be allowed")
+	}
+}
+
+func TestPostgresIntTypes(t *testing.T) {
+	PostgresIntTypes = IntTypes{Smallint: "int", Integer: "int64"}
+	defer func() { PostgresIntTypes = IntTypes{} }()
+
+	p := &PostgresDriver{}
+	tests := []struct {
+		DBType   string
+		Nullable bool
+		Want     string
+	}{
+		{"smallint", false, "int"},
+		{"integer", false, "int64"},
+		{"serial", true, "null.Int64"},
+		{"bigint", false, "int64"},
+		{"bigint", true, "null.Int64"},
+	}
+
+	for i, test := range tests {
+		if c := p.TranslateColumnType(bdb.Column{DBType: test.DBType, Nullable: test.Nullable}); c.Type != test.Want {
+			t.Errorf("%d) %s want %s, got %s", i, test.DBType, test.Want, c.Type)
+		}
+	}
+}
+
+func TestMySQLIntTypes(t *testing.T) {
+	MySQLIntTypes = IntTypes{Integer: "int64", Bigint: "int32"}
+	defer func() { MySQLIntTypes = IntTypes{} }()
+
+	m := &MySQLDriver{}
+	tests := []struct {
+		Column bdb.Column
+		Want   string
+	}{
+		{bdb.Column{DBType: "int", FullDBType: "int(11)"}, "int64"},
+		{bdb.Column{DBType: "int", FullDBType: "int(11)", Nullable: true}, "null.Int64"},
+		{bdb.Column{DBType: "int", FullDBType: "int(10) unsigned"}, "uint"},
+		{bdb.Column{DBType: "bigint", FullDBType: "bigint(20)", Nullable: true}, "null.Int32"},
+		{bdb.Column{DBType: "smallint", FullDBType: "smallint(6)"}, "int16"},
+	}
+
+	for i, test := range tests {
+		if c := m.TranslateColumnType(test.Column); c.Type != test.Want {
+			t.Errorf("%d) %s want %s, got %s", i, test.Column.FullDBType, test.Want, c.Type)
+		}
+	}
+}
@@ -63,6 +63,11 @@ func (m *MSSQLDriver) Close() {
 	m.dbConn.Close()
 }
 
+// conn returns the database connection with the StatementTimeout.
+func (m *MSSQLDriver) conn() queryer {
+	return withStatementTimeout(m.dbConn)
+}
+
 // UseLastInsertID returns false for mssql
 func (m *MSSQLDriver) UseLastInsertID() bool {
 	return false
@@ -86,18 +91,20 @@ func (m *MSSQLDriver) TableNames(schema string, whitelist, blacklist []string) (
 
 	args := []interface{}{schema}
 	if len(whitelist) > 0 {
-		query += fmt.Sprintf(" AND table_name IN (%s);", strings.Repeat(",?", len(whitelist))[1:])
+		query += fmt.Sprintf(" AND table_name IN (%s)", questionMarks(len(whitelist)))
 		for _, w := range whitelist {
 			args = append(args, w)
 		}
 	} else if len(blacklist) > 0 {
-		query += fmt.Sprintf(" AND table_name not IN (%s);", strings.Repeat(",?", len(blacklist))[1:])
+		query += fmt.Sprintf(" AND table_name not IN (%s)", questionMarks(len(blacklist)))
 		for _, b := range blacklist {
 			args = append(args, b)
 		}
 	}
 
-	rows, err := m.dbConn.Query(query, args...)
+	query += " ORDER BY table_name;"
+
+	rows, err := m.conn().Query(query, args...)
 
 	if err != nil {
 		return nil, err
@@ -122,7 +129,7 @@ func (m *MSSQLDriver) TableNames(schema string, whitelist, blacklist []string) (
 func (m *MSSQLDriver) Columns(schema, tableName string) ([]bdb.Column, error) {
 	var columns []bdb.Column
 
-	rows, err := m.dbConn.Query(`
+	rows, err := m.conn().Query(`
 	SELECT column_name,
        CASE
          WHEN character_maximum_length IS NULL THEN data_type
@@ -151,10 +158,11 @@ func (m *MSSQLDriver) Columns(schema, tableName string) ([]bdb.Column, error) {
                              AND   constraint_name = tc.constraint_name) = 1) THEN 1
          ELSE 0
        END AS is_unique,
-	   COLUMNPROPERTY(object_id($1 + '.' + $2), c.column_name, 'IsIdentity') as is_identity
+	   COLUMNPROPERTY(object_id(? + '.' + ?), c.column_name, 'IsIdentity') as is_identity
 	FROM information_schema.columns c
-	WHERE table_schema = $1 AND table_name = $2;
-	`, schema, tableName)
+	WHERE table_schema = ? AND table_name = ?
+	ORDER BY c.ordinal_position;
+	`, schema, tableName, schema, tableName)
 
 	if err != nil {
 		return nil, err
@@ -172,12 +180,13 @@ func (m *MSSQLDriver) Columns(schema, tableName string) ([]bdb.Column, error) {
 		auto = strings.EqualFold(colType, "timestamp") || strings.EqualFold(colType, "rowversion")
 
 		column := bdb.Column{
-			Name:          colName,
-			FullDBType:    colFullType,
-			DBType:        colType,
-			Nullable:      nullable,
-			Unique:        unique,
-			AutoGenerated: auto,
+			Name:            colName,
+			FullDBType:      colFullType,
+			DBType:          colType,
+			Nullable:        nullable,
+			Unique:          unique,
+			AutoGenerated:   auto,
+			IsAutoIncrement: identity,
 		}
 
 		if defaultValue != nil && *defaultValue != "NULL" {
@@ -201,7 +210,7 @@ func (m *MSSQLDriver) PrimaryKeyInfo(schema, tableName string) (*bdb.PrimaryKey,
 	FROM   information_schema.table_constraints
 	WHERE  table_name = ? AND constraint_type = 'PRIMARY KEY' AND table_schema = ?;`
 
-	row := m.dbConn.QueryRow(query, tableName, schema)
+	row := m.conn().QueryRow(query, tableName, schema)
 	if err = row.Scan(&pkey.Name); err != nil {
 		if err == sql.ErrNoRows {
 			return nil, nil
@@ -212,10 +221,11 @@ func (m *MSSQLDriver) PrimaryKeyInfo(schema, tableName string) (*bdb.PrimaryKey,
 	queryColumns := `
 	SELECT column_name
 	FROM   information_schema.key_column_usage
-	WHERE  table_name = ? AND constraint_name = ? AND table_schema = ?;`
+	WHERE  table_name = ? AND constraint_name = ? AND table_schema = ?
+	ORDER BY ordinal_position;`
 
-	var rows *sql.Rows
-	if rows, err = m.dbConn.Query(queryColumns, tableName, pkey.Name, schema); err != nil {
+	var rows sqlRows
+	if rows, err = m.conn().Query(queryColumns, tableName, pkey.Name, schema); err != nil {
 		return nil, err
 	}
 	defer rows.Close()
@@ -250,18 +260,25 @@ func (m *MSSQLDriver) ForeignKeyInfo(schema, tableName string) ([]bdb.ForeignKey
 		ccu.table_name AS local_table ,
 		ccu.column_name AS local_column ,
 		kcu.table_name AS foreign_table ,
-		kcu.column_name AS foreign_column
+		kcu.column_name AS foreign_column ,
+		rc.match_option ,
+		sfk.is_not_trusted
 	FROM information_schema.constraint_column_usage ccu
-	INNER JOIN information_schema.referential_constraints rc ON ccu.constraint_name = rc.constraint_name
-	INNER JOIN information_schema.key_column_usage kcu ON kcu.constraint_name = rc.unique_constraint_name
+	INNER JOIN information_schema.referential_constraints rc
+		ON ccu.constraint_schema = rc.constraint_schema AND ccu.constraint_name = rc.constraint_name
+	INNER JOIN sys.foreign_keys sfk
+		ON sfk.name = rc.constraint_name AND SCHEMA_NAME(sfk.schema_id) = rc.constraint_schema
+	INNER JOIN information_schema.key_column_usage kcu
+		ON kcu.constraint_schema = rc.unique_constraint_schema AND kcu.constraint_name = rc.unique_constraint_name
 	WHERE ccu.table_schema = ?
 	  AND ccu.constraint_schema = ?
 	  AND ccu.table_name = ?
+	ORDER BY ccu.constraint_name, kcu.ordinal_position
 	`
 
-	var rows *sql.Rows
+	var rows sqlRows
 	var err error
-	if rows, err = m.dbConn.Query(query, schema, schema, tableName); err != nil {
+	if rows, err = m.conn().Query(query, schema, schema, tableName); err != nil {
 		return nil, err
 	}
 
@@ -269,11 +286,13 @@ func (m *MSSQLDriver) ForeignKeyInfo(schema, tableName string) ([]bdb.ForeignKey
 		var fkey bdb.ForeignKey
 		var sourceTable string
 
+		fkey.Schema = schema
 		fkey.Table = tableName
-		err = rows.Scan(&fkey.Name, &sourceTable, &fkey.Column, &fkey.ForeignTable, &fkey.ForeignColumn)
+		err = rows.Scan(&fkey.Name, &sourceTable, &fkey.Column, &fkey.ForeignTable, &fkey.ForeignColumn, &fkey.MatchType, &fkey.NotValid)
 		if err != nil {
 			return nil, err
 		}
+		fkey.MatchType = matchType(fkey.MatchType)
 
 		fkeys = append(fkeys, fkey)
 	}
@@ -318,8 +337,11 @@ func (m *MSSQLDriver) TranslateColumnType(c bdb.Column) bdb.Column {
 		case "uniqueidentifier":
 			c.Type = "null.String"
 			c.DBType = "uuid"
+		case "char", "nchar", "varchar", "nvarchar", "text", "ntext", "decimal", "numeric", "money", "smallmoney":
+			c.Type = "null.String"
 		default:
 			c.Type = "null.String"
+			c.UnknownType = true
 		}
 	} else {
 		switch c.DBType {
@@ -350,8 +372,11 @@ func (m *MSSQLDriver) TranslateColumnType(c bdb.Column) bdb.Column {
 		case "uniqueidentifier":
 			c.Type = "string"
 			c.DBType = "uuid"
+		case "char", "nchar", "varchar", "nvarchar", "text", "ntext", "decimal", "numeric", "money", "smallmoney":
+			c.Type = "string"
 		default:
 			c.Type = "string"
+			c.UnknownType = true
 		}
 	}
 
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/go-sql-driver/mysql"
 	"github.com/pkg/errors"
@@ -15,13 +16,26 @@ import (
 // this flag when generating. This flag only applies to MySQL so we're using
 // a global instead, to avoid breaking the interface. If TinyintAsBool is true
 // then tinyint(1) will be mapped in your generated structs to bool opposed to int8.
+// The display width decides this, so tinyint(1) unsigned is also a bool but
+// tinyint(4) stays an int8.
 var TinyintAsBool bool
 
+// SetAsSlice is a global that is set from main.go if a user specifies this
+// flag when generating. If SetAsSlice is true SET columns are mapped to
+// types.Set, the slice of their members, instead of a string.
+var SetAsSlice bool
+
+// MySQLIntTypes is a global that is set from main.go if a user configures it
+// when generating, it replaces the Go types of signed smallint, int and
+// bigint columns (int16, int and int64). Unsigned columns are left alone.
+var MySQLIntTypes IntTypes
+
 // MySQLDriver holds the database connection string and a handle
 // to the database connection.
 type MySQLDriver struct {
 	connStr string
 	dbConn  *sql.DB
+	tx      *sql.Tx
 }
 
 // NewMySQLDriver takes the database connection details as parameters and
@@ -68,14 +82,31 @@ func (m *MySQLDriver) Open() error {
 		return err
 	}
 
+	m.tx, err = beginSnapshot(m.dbConn)
+	if err != nil {
+		return errors.Wrap(err, "unable to begin snapshot transaction")
+	}
+
 	return nil
 }
 
 // Close closes the database connection
 func (m *MySQLDriver) Close() {
+	if m.tx != nil {
+		m.tx.Rollback()
+	}
 	m.dbConn.Close()
 }
 
+// conn returns the snapshot transaction if there is one, otherwise
+// the database connection itself, with the StatementTimeout.
+func (m *MySQLDriver) conn() queryer {
+	if m.tx != nil {
+		return withStatementTimeout(m.tx)
+	}
+	return withStatementTimeout(m.dbConn)
+}
+
 // UseLastInsertID returns false for postgres
 func (m *MySQLDriver) UseLastInsertID() bool {
 	return true
@@ -95,18 +126,20 @@ func (m *MySQLDriver) TableNames(schema string, whitelist, blacklist []string) (
 	query := fmt.Sprintf(`select table_name from information_schema.tables where table_schema = ? and table_type = 'BASE TABLE'`)
 	args := []interface{}{schema}
 	if len(whitelist) > 0 {
-		query += fmt.Sprintf(" and table_name in (%s);", strings.Repeat(",?", len(whitelist))[1:])
+		query += fmt.Sprintf(" and table_name in (%s)", questionMarks(len(whitelist)))
 		for _, w := range whitelist {
 			args = append(args, w)
 		}
 	} else if len(blacklist) > 0 {
-		query += fmt.Sprintf(" and table_name not in (%s);", strings.Repeat(",?", len(blacklist))[1:])
+		query += fmt.Sprintf(" and table_name not in (%s)", questionMarks(len(blacklist)))
 		for _, b := range blacklist {
 			args = append(args, b)
 		}
 	}
 
-	rows, err := m.dbConn.Query(query, args...)
+	query += " order by table_name;"
+
+	rows, err := m.conn().Query(query, args...)
 
 	if err != nil {
 		return nil, err
@@ -124,6 +157,35 @@ func (m *MySQLDriver) TableNames(schema string, whitelist, blacklist []string) (
 	return names, nil
 }
 
+// ModifiedTableNames returns the tables that were created or had their data
+// changed after since according to information_schema.tables. Tables
+// without an update_time (older InnoDB servers don't track it) are always
+// returned.
+func (m *MySQLDriver) ModifiedTableNames(schema string, since time.Time) ([]string, error) {
+	var names []string
+
+	rows, err := m.conn().Query(`
+	select table_name
+	from information_schema.tables
+	where table_schema = ? and table_type = 'BASE TABLE' and
+		(update_time is null or update_time > ? or create_time > ?)
+	`, schema, since, since)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			return nil, err
+		}
+		names = append(names, name)
+	}
+
+	return names, rows.Err()
+}
+
 // Columns takes a table name and attempts to retrieve the table information
 // from the database information_schema.columns. It retrieves the column names
 // and column types and returns those as a []Column after TranslateColumnType()
@@ -131,12 +193,13 @@ func (m *MySQLDriver) TableNames(schema string, whitelist, blacklist []string) (
 func (m *MySQLDriver) Columns(schema, tableName string) ([]bdb.Column, error) {
 	var columns []bdb.Column
 
-	rows, err := m.dbConn.Query(`
+	rows, err := m.conn().Query(`
 	select
 	c.column_name,
 	c.column_type,
 	if(c.data_type = 'enum', c.column_type, c.data_type),
 	if(extra = 'auto_increment','auto_increment', c.column_default),
+	c.column_comment,
 	c.is_nullable = 'YES',
 		exists (
 			select c.column_name
@@ -148,7 +211,8 @@ func (m *MySQLDriver) Columns(schema, tableName string) ([]bdb.Column, error) {
 				(select count(*) from information_schema.key_column_usage where table_schema = kcu.table_schema and table_name = tc.table_name and constraint_name = tc.constraint_name) = 1
 		) as is_unique
 	from information_schema.columns as c
-	where table_name = ? and table_schema = ?;
+	where table_name = ? and table_schema = ?
+	order by c.ordinal_position;
 	`, tableName, schema)
 
 	if err != nil {
@@ -157,10 +221,10 @@ func (m *MySQLDriver) Columns(schema, tableName string) ([]bdb.Column, error) {
 	defer rows.Close()
 
 	for rows.Next() {
-		var colName, colType, colFullType string
+		var colName, colType, colFullType, comment string
 		var nullable, unique bool
 		var defaultValue *string
-		if err := rows.Scan(&colName, &colFullType, &colType, &defaultValue, &nullable, &unique); err != nil {
+		if err := rows.Scan(&colName, &colFullType, &colType, &defaultValue, &comment, &nullable, &unique); err != nil {
 			return nil, errors.Wrapf(err, "unable to scan for table %s", tableName)
 		}
 
@@ -170,10 +234,21 @@ func (m *MySQLDriver) Columns(schema, tableName string) ([]bdb.Column, error) {
 			DBType:     colType,
 			Nullable:   nullable,
 			Unique:     unique,
+			Comment:    comment,
+		}
+
+		switch colType {
+		case "tinyint", "smallint", "mediumint", "int", "integer", "bigint":
+			column.DisplayWidth = mysqlDisplayWidth(colFullType)
+		}
+		column.Zerofill = strings.HasSuffix(colFullType, " zerofill")
+		if colType == "set" || strings.HasPrefix(colType, "enum") {
+			column.EnumValues = mysqlTypeValues(colFullType)
 		}
 
 		if defaultValue != nil && *defaultValue != "NULL" {
 			column.Default = *defaultValue
+			column.IsAutoIncrement = column.Default == "auto_increment"
 		}
 
 		columns = append(columns, column)
@@ -192,7 +267,7 @@ func (m *MySQLDriver) PrimaryKeyInfo(schema, tableName string) (*bdb.PrimaryKey,
 	from information_schema.table_constraints as tc
 	where tc.table_name = ? and tc.constraint_type = 'PRIMARY KEY' and tc.table_schema = ?;`
 
-	row := m.dbConn.QueryRow(query, tableName, schema)
+	row := m.conn().QueryRow(query, tableName, schema)
 	if err = row.Scan(&pkey.Name); err != nil {
 		if err == sql.ErrNoRows {
 			return nil, nil
@@ -203,10 +278,11 @@ func (m *MySQLDriver) PrimaryKeyInfo(schema, tableName string) (*bdb.PrimaryKey,
 	queryColumns := `
 	select kcu.column_name
 	from   information_schema.key_column_usage as kcu
-	where  table_name = ? and constraint_name = ? and table_schema = ?;`
+	where  table_name = ? and constraint_name = ? and table_schema = ?
+	order by kcu.ordinal_position;`
 
-	var rows *sql.Rows
-	if rows, err = m.dbConn.Query(queryColumns, tableName, pkey.Name, schema); err != nil {
+	var rows sqlRows
+	if rows, err = m.conn().Query(queryColumns, tableName, pkey.Name, schema); err != nil {
 		return nil, err
 	}
 	defer rows.Close()
@@ -240,11 +316,12 @@ func (m *MySQLDriver) ForeignKeyInfo(schema, tableName string) ([]bdb.ForeignKey
 	select constraint_name, table_name, column_name, referenced_table_name, referenced_column_name
 	from information_schema.key_column_usage
 	where table_schema = ? and referenced_table_schema = ? and table_name = ?
+	order by constraint_name, ordinal_position
 	`
 
-	var rows *sql.Rows
+	var rows sqlRows
 	var err error
-	if rows, err = m.dbConn.Query(query, schema, schema, tableName); err != nil {
+	if rows, err = m.conn().Query(query, schema, schema, tableName); err != nil {
 		return nil, err
 	}
 
@@ -252,12 +329,15 @@ func (m *MySQLDriver) ForeignKeyInfo(schema, tableName string) ([]bdb.ForeignKey
 		var fkey bdb.ForeignKey
 		var sourceTable string
 
+		fkey.Schema = schema
 		fkey.Table = tableName
 		err = rows.Scan(&fkey.Name, &sourceTable, &fkey.Column, &fkey.ForeignTable, &fkey.ForeignColumn)
 		if err != nil {
 			return nil, err
 		}
 
+		// mysql accepts MATCH clauses but ignores them
+		fkey.MatchType = bdb.MatchSimple
 		fkeys = append(fkeys, fkey)
 	}
 
@@ -268,16 +348,85 @@ func (m *MySQLDriver) ForeignKeyInfo(schema, tableName string) ([]bdb.ForeignKey
 	return fkeys, nil
 }
 
+// TriggerInfo retrieves the triggers of a table, mysql triggers fire on a
+// single event.
+func (m *MySQLDriver) TriggerInfo(schema, tableName string) ([]bdb.Trigger, error) {
+	var triggers []bdb.Trigger
+
+	query := `
+	select trigger_name, action_timing, event_manipulation, action_statement
+	from information_schema.triggers
+	where event_object_schema = ? and event_object_table = ?
+	order by trigger_name
+	`
+
+	rows, err := m.conn().Query(query, schema, tableName)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var trigger bdb.Trigger
+		if err = rows.Scan(&trigger.Name, &trigger.Timing, &trigger.Event, &trigger.Function); err != nil {
+			return nil, err
+		}
+
+		triggers = append(triggers, trigger)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return triggers, nil
+}
+
+// TableDetails reads the storage engine, collation and character set of a
+// table when ExtendedMetadata is enabled.
+func (m *MySQLDriver) TableDetails(schema string, t *bdb.Table) error {
+	if !ExtendedMetadata {
+		return nil
+	}
+
+	query := `
+	select coalesce(t.engine, ''), coalesce(t.table_collation, ''), coalesce(ccsa.character_set_name, '')
+	from information_schema.tables t
+		left join information_schema.collation_character_set_applicability ccsa on ccsa.collation_name = t.table_collation
+	where t.table_schema = ? and t.table_name = ?
+	`
+
+	row := m.conn().QueryRow(query, schema, t.Name)
+	return row.Scan(&t.Engine, &t.Collation, &t.Charset)
+}
+
+// DatabaseInfo reads the default character set and collation of the
+// database and the server's version.
+func (m *MySQLDriver) DatabaseInfo() (*bdb.DatabaseInfo, error) {
+	info := &bdb.DatabaseInfo{}
+	row := m.conn().QueryRow(`select @@character_set_database, @@collation_database, version()`)
+	if err := row.Scan(&info.Encoding, &info.Collation, &info.ServerVersion); err != nil {
+		return nil, errors.Wrap(err, "unable to get the database info")
+	}
+
+	return info, nil
+}
+
 // TranslateColumnType converts postgres database types to Go types, for example
 // "varchar" to "string" and "bigint" to "int64". It returns this parsed data
 // as a Column object.
 func (m *MySQLDriver) TranslateColumnType(c bdb.Column) bdb.Column {
+	if _, ok := mysqlSpatialTypes[c.DBType]; ok {
+		setSpatialType(&c, "[]byte", "null.Bytes")
+		return c
+	}
+
 	unsigned := strings.Contains(c.FullDBType, "unsigned")
 	if c.Nullable {
 		switch c.DBType {
 		case "tinyint":
 			// map tinyint(1) to bool if TinyintAsBool is true
-			if TinyintAsBool && c.FullDBType == "tinyint(1)" {
+			if TinyintAsBool && mysqlDisplayWidth(c.FullDBType) == 1 {
 				c.Type = "null.Bool"
 			} else if unsigned {
 				c.Type = "null.Uint8"
@@ -320,14 +469,18 @@ func (m *MySQLDriver) TranslateColumnType(c bdb.Column) bdb.Column {
 			c.Type = "null.Bytes"
 		case "json":
 			c.Type = "types.JSON"
+		case "char", "varchar", "tinytext", "text", "mediumtext", "longtext", "decimal", "numeric", "set", "year", "bit":
+			c.Type = "null.String"
 		default:
 			c.Type = "null.String"
+			// enum types are strings on purpose, the templates make constants for them
+			c.UnknownType = !strings.HasPrefix(c.DBType, "enum")
 		}
 	} else {
 		switch c.DBType {
 		case "tinyint":
 			// map tinyint(1) to bool if TinyintAsBool is true
-			if TinyintAsBool && c.FullDBType == "tinyint(1)" {
+			if TinyintAsBool && mysqlDisplayWidth(c.FullDBType) == 1 {
 				c.Type = "bool"
 			} else if unsigned {
 				c.Type = "uint8"
@@ -370,14 +523,81 @@ func (m *MySQLDriver) TranslateColumnType(c bdb.Column) bdb.Column {
 			c.Type = "[]byte"
 		case "json":
 			c.Type = "types.JSON"
+		case "char", "varchar", "tinytext", "text", "mediumtext", "longtext", "decimal", "numeric", "set", "year", "bit":
+			c.Type = "string"
 		default:
 			c.Type = "string"
+			// enum types are strings on purpose, the templates make constants for them
+			c.UnknownType = !strings.HasPrefix(c.DBType, "enum")
+		}
+	}
+
+	if goType := MySQLIntTypes.forDBType(c.DBType); len(goType) != 0 && !unsigned {
+		c.Type = goType
+		if c.Nullable {
+			c.Type = intNullTypes[goType]
 		}
 	}
 
+	// A nil types.Set is NULL, so it's used for nullable columns as well
+	if SetAsSlice && c.DBType == "set" {
+		c.Type = "types.Set"
+	}
+
 	return c
 }
 
+// mysqlDisplayWidth returns the display width of an integer column type,
+// eg: 11 for "int(11) unsigned". It returns 0 if there is no display width.
+func mysqlDisplayWidth(fullType string) int {
+	start := strings.IndexByte(fullType, '(')
+	end := strings.IndexByte(fullType, ')')
+	if start < 0 || end < start {
+		return 0
+	}
+
+	width, err := strconv.Atoi(fullType[start+1 : end])
+	if err != nil {
+		return 0
+	}
+
+	return width
+}
+
+// mysqlTypeValues returns the quoted values of an enum or set column type,
+// eg: a and b for "set('a','b')". A doubled quote is a quote.
+func mysqlTypeValues(fullType string) []string {
+	start := strings.IndexByte(fullType, '(')
+	end := strings.LastIndexByte(fullType, ')')
+	if start < 0 || end < start {
+		return nil
+	}
+
+	var values []string
+	var value []byte
+	quoted := false
+	list := fullType[start+1 : end]
+	for i := 0; i < len(list); i++ {
+		switch {
+		case list[i] != '\'' && quoted:
+			value = append(value, list[i])
+		case list[i] != '\'':
+			// the commas between values
+		case quoted && i+1 < len(list) && list[i+1] == '\'':
+			value = append(value, '\'')
+			i++
+		case quoted:
+			values = append(values, string(value))
+			value = value[:0]
+			quoted = false
+		default:
+			quoted = true
+		}
+	}
+
+	return values
+}
+
 // RightQuote is the quoting character for the right side of the identifier
 func (m *MySQLDriver) RightQuote() byte {
 	return '`'
@@ -0,0 +1,159 @@
+package drivers
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/volatiletech/sqlboiler/bdb"
+
+	"gopkg.in/DATA-DOG/go-sqlmock.v1"
+)
+
+func TestMySQLColumnsDisplayWidth(t *testing.T) {
+	t.Parallel()
+
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	mock.ExpectQuery(`from information_schema.columns`).
+		WithArgs("invoices", "app").
+		WillReturnRows(sqlmock.NewRows([]string{"column_name", "column_type", "data_type", "column_default", "column_comment", "is_nullable", "is_unique"}).
+			AddRow("id", "int(11)", "int", nil, "", false, true).
+			AddRow("number", "int(8) unsigned zerofill", "int", nil, "", false, false).
+			AddRow("total", "decimal(10,2) zerofill", "decimal", nil, "", false, false).
+			AddRow("code", "varchar(32)", "varchar", nil, "", false, false).
+			AddRow("count", "bigint", "bigint", nil, "", false, false).
+			AddRow("perms", "set('read','write')", "set", nil, "", false, false))
+
+	m := &MySQLDriver{dbConn: db}
+	columns, err := m.Columns("app", "invoices")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		Width    int
+		Zerofill bool
+	}{
+		{11, false},
+		{8, true},
+		{0, true},
+		{0, false},
+		{0, false},
+		{0, false},
+	}
+
+	if len(columns) != len(tests) {
+		t.Fatalf("want %d columns, got: %#v", len(tests), columns)
+	}
+	for i, test := range tests {
+		if c := columns[i]; c.DisplayWidth != test.Width || c.Zerofill != test.Zerofill {
+			t.Errorf("%d) %s want width %d and zerofill %t, got %d and %t", i, c.Name, test.Width, test.Zerofill, c.DisplayWidth, c.Zerofill)
+		}
+	}
+
+	if v := columns[5].EnumValues; !reflect.DeepEqual(v, []string{"read", "write"}) {
+		t.Errorf("perms values were wrong: %#v", v)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestMySQLTypeValues(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		Type string
+		Want []string
+	}{
+		{"enum('draft','published')", []string{"draft", "published"}},
+		{"set('read','write','it''s')", []string{"read", "write", "it's"}},
+		{"set('a,b','')", []string{"a,b", ""}},
+		{"varchar(32)", nil},
+	}
+
+	for i, test := range tests {
+		if got := mysqlTypeValues(test.Type); !reflect.DeepEqual(got, test.Want) {
+			t.Errorf("%d) want %#v, got %#v", i, test.Want, got)
+		}
+	}
+}
+
+func TestMySQLTranslateSetAsSlice(t *testing.T) {
+	m := &MySQLDriver{}
+	if c := m.TranslateColumnType(bdb.Column{DBType: "set"}); c.Type != "string" {
+		t.Errorf("want string, got %s", c.Type)
+	}
+
+	SetAsSlice = true
+	defer func() { SetAsSlice = false }()
+	for _, nullable := range []bool{false, true} {
+		if c := m.TranslateColumnType(bdb.Column{DBType: "set", Nullable: nullable}); c.Type != "types.Set" {
+			t.Errorf("want types.Set (nullable %t), got %s", nullable, c.Type)
+		}
+	}
+}
+
+func TestMySQLTableDetails(t *testing.T) {
+	ExtendedMetadata = true
+	defer func() { ExtendedMetadata = false }()
+
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	mock.ExpectQuery(`(?s)from information_schema.tables t.*collation_character_set_applicability`).
+		WithArgs("app", "logs").
+		WillReturnRows(sqlmock.NewRows([]string{"engine", "table_collation", "character_set_name"}).
+			AddRow("MyISAM", "latin1_swedish_ci", "latin1"))
+
+	m := &MySQLDriver{dbConn: db}
+	table := bdb.Table{Name: "logs"}
+	if err := m.TableDetails("app", &table); err != nil {
+		t.Fatal(err)
+	}
+
+	if table.Engine != "MyISAM" || table.Collation != "latin1_swedish_ci" || table.Charset != "latin1" {
+		t.Errorf("details were wrong: %#v", table)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestMySQLDatabaseInfo(t *testing.T) {
+	t.Parallel()
+
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	mock.ExpectQuery(`select @@character_set_database, @@collation_database, version\(\)`).
+		WillReturnRows(sqlmock.NewRows([]string{"@@character_set_database", "@@collation_database", "version()"}).
+			AddRow("utf8mb4", "utf8mb4_0900_ai_ci", "8.0.32"))
+
+	m := &MySQLDriver{dbConn: db}
+	info, err := m.DatabaseInfo()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := bdb.DatabaseInfo{Encoding: "utf8mb4", Collation: "utf8mb4_0900_ai_ci", ServerVersion: "8.0.32"}
+	if *info != want {
+		t.Errorf("want %#v, got %#v", want, *info)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
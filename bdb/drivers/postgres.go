@@ -4,21 +4,76 @@ import (
 	"database/sql"
 	"fmt"
 	"os"
+	"regexp"
 	"strings"
 
-	// Side-effect import sql driver
-
+	// Side-effect import sql drivers
+	_ "github.com/jackc/pgx/stdlib"
 	_ "github.com/lib/pq"
 	"github.com/pkg/errors"
 	"github.com/volatiletech/sqlboiler/bdb"
 	"github.com/volatiletech/sqlboiler/strmangle"
+	"golang.org/x/crypto/ssh"
 )
 
+// ExtendedMetadata is a global that is set from main.go if a user specifies
+// this flag when generating. When true, drivers that support it will read
+// additional table metadata (see bdb.TableDetailer) that is not needed for
+// generation but may be useful to other tools.
+var ExtendedMetadata bool
+
+// UsePgx is a global that is set from main.go if a user specifies this flag
+// when generating. If UsePgx is true the database connection is opened with
+// the github.com/jackc/pgx database/sql driver instead of github.com/lib/pq,
+// the same connection string is understood by both.
+var UsePgx bool
+
+// PostgresSQLDriverName is a global that is set from main.go if a user
+// specifies this flag when generating. When it isn't empty the database
+// connection is opened with the database/sql driver registered under this
+// name, eg: a lib/pq driver wrapped for tracing. It takes precedence over
+// UsePgx.
+var PostgresSQLDriverName string
+
+// PostgresCatalogTables is a global for tools built on the package, these
+// system catalog tables (eg: pg_catalog.pg_class, information_schema.columns)
+// are returned along with the tables of the schema being read, qualified
+// with their catalog so they can't collide with the schema's own tables.
+// Names without a schema are in pg_catalog. The whitelist and blacklist can
+// name them with or without the catalog. They don't have primary keys, so
+// sqlboiler itself can't generate models for them.
+var PostgresCatalogTables []string
+
+// PostgresSystemColumns is a global for tools built on the package, these
+// system columns (eg: xmin or ctid) are read from pg_attribute after the
+// columns of each table, they're marked with Column.IsSystem.
+// information_schema.columns doesn't list them. The models sqlboiler
+// generates select * which leaves them out, so it can't fill them in.
+var PostgresSystemColumns []string
+
+// PostgresOwnedTablesOnly is a global that is set from main.go if a user
+// specifies this flag when generating. When true TableNames only returns the
+// tables owned by the connecting role (current_user), instead of every table
+// of the schema it can see.
+var PostgresOwnedTablesOnly bool
+
+// PostgresSSHTunnel is a global that is set from main.go if a user
+// configures it when generating. When its Host isn't empty the database is
+// dialed from that SSH server, which only works with lib/pq.
+var PostgresSSHTunnel SSHTunnel
+
+// PostgresIntTypes is a global that is set from main.go if a user configures
+// it when generating, it replaces the Go types of smallint, integer and
+// bigint columns (int16, int and int64).
+var PostgresIntTypes IntTypes
+
 // PostgresDriver holds the database connection string and a handle
 // to the database connection.
 type PostgresDriver struct {
 	connStr string
 	dbConn  *sql.DB
+	tx      *sql.Tx
+	ssh     *ssh.Client
 }
 
 // NewPostgresDriver takes the database connection details as parameters and
@@ -33,6 +88,19 @@ func NewPostgresDriver(user, pass, dbname, host string, port int, sslmode string
 	return &driver
 }
 
+// NewPostgresDriverFromEnv returns a pointer to a PostgresDriver object
+// that connects with libpq's environment variables (PGHOST, PGPORT, PGUSER,
+// PGPASSWORD, PGPASSFILE, PGSSLMODE...), the same way psql does. The
+// connection string is left empty so the database/sql driver resolves
+// them. PGDATABASE must be set, there's nothing to generate without it.
+func NewPostgresDriverFromEnv() (*PostgresDriver, error) {
+	if len(os.Getenv("PGDATABASE")) == 0 {
+		return nil, errors.New("PGDATABASE must be set to connect with the libpq environment variables")
+	}
+
+	return &PostgresDriver{}, nil
+}
+
 // PostgresBuildQueryString builds a query string.
 func PostgresBuildQueryString(user, pass, dbname, host string, port int, sslmode string) string {
 	parts := []string{}
@@ -58,20 +126,92 @@ func PostgresBuildQueryString(user, pass, dbname, host string, port int, sslmode
 	return strings.Join(parts, " ")
 }
 
+// sqlDriverName is the database/sql driver the connection is opened with
+func (p *PostgresDriver) sqlDriverName() string {
+	if len(PostgresSQLDriverName) != 0 {
+		return PostgresSQLDriverName
+	}
+	if UsePgx {
+		return "pgx"
+	}
+	return "postgres"
+}
+
 // Open opens the database connection using the connection string
 func (p *PostgresDriver) Open() error {
 	var err error
-	p.dbConn, err = sql.Open("postgres", p.connStr)
+	if len(PostgresSSHTunnel.Host) != 0 {
+		if err = p.openSSH(); err != nil {
+			return err
+		}
+	} else if p.dbConn, err = sql.Open(p.sqlDriverName(), p.connStr); err != nil {
+		return err
+	}
+
+	p.tx, err = beginSnapshot(p.dbConn)
 	if err != nil {
+		return errors.Wrap(err, "unable to begin snapshot transaction")
+	}
+
+	return nil
+}
+
+// WithSavepoint runs fn inside a savepoint of the snapshot transaction, so
+// a failed query in fn doesn't abort the transaction. Without a snapshot fn
+// is just called.
+func (p *PostgresDriver) WithSavepoint(fn func() error) error {
+	if p.tx == nil {
+		return fn()
+	}
+
+	if _, err := p.tx.Exec("savepoint sqlboiler_table"); err != nil {
+		return errors.Wrap(err, "unable to create savepoint")
+	}
+
+	if err := fn(); err != nil {
+		if _, rbErr := p.tx.Exec("rollback to savepoint sqlboiler_table"); rbErr != nil {
+			return errors.Wrapf(err, "unable to roll back to savepoint (%s)", rbErr)
+		}
 		return err
 	}
 
+	_, err := p.tx.Exec("release savepoint sqlboiler_table")
+	return errors.Wrap(err, "unable to release savepoint")
+}
+
+// openSSH opens the database connection through PostgresSSHTunnel
+func (p *PostgresDriver) openSSH() error {
+	if p.sqlDriverName() != "postgres" {
+		return errors.New("an ssh tunnel can only be used with lib/pq")
+	}
+
+	var err error
+	if p.ssh, err = PostgresSSHTunnel.dial(); err != nil {
+		return err
+	}
+	p.dbConn = sql.OpenDB(pqDialConnector{dialer: sshDialer{client: p.ssh}, dsn: p.connStr})
+
 	return nil
 }
 
 // Close closes the database connection
 func (p *PostgresDriver) Close() {
+	if p.tx != nil {
+		p.tx.Rollback()
+	}
 	p.dbConn.Close()
+	if p.ssh != nil {
+		p.ssh.Close()
+	}
+}
+
+// conn returns the snapshot transaction if there is one, otherwise
+// the database connection itself, with the StatementTimeout.
+func (p *PostgresDriver) conn() queryer {
+	if p.tx != nil {
+		return withStatementTimeout(p.tx)
+	}
+	return withStatementTimeout(p.dbConn)
 }
 
 // UseLastInsertID returns false for postgres
@@ -84,27 +224,54 @@ func (m *PostgresDriver) UseTopClause() bool {
 	return false
 }
 
+// postgresNotInternal leaves the internal tables postgres keeps for itself
+// out of the table names: TOAST tables and the schemas of temporary tables.
+// They're never wanted even when the schema filter would find them.
+const postgresNotInternal = ` and table_schema <> 'pg_toast' and table_schema not like 'pg\_temp\_%'` +
+	` and table_schema not like 'pg\_toast\_temp\_%' and table_name not like 'pg\_toast\_%'`
+
 // TableNames connects to the postgres database and
-// retrieves all table names from the information_schema where the
-// table schema is schema. It uses a whitelist and blacklist.
+// retrieves all table names from the information_schema, and the
+// materialized views, where the table schema is schema. It uses a whitelist
+// and blacklist.
 func (p *PostgresDriver) TableNames(schema string, whitelist, blacklist []string) ([]string, error) {
 	var names []string
 
-	query := fmt.Sprintf(`select table_name from information_schema.tables where table_schema = $1`)
+	// Materialized views aren't in information_schema.tables
+	query := `select table_name from (
+		select table_schema, table_name from information_schema.tables
+		union all
+		select schemaname, matviewname from pg_matviews
+	) as relations where table_schema = $1`
 	args := []interface{}{schema}
+	if PostgresOwnedTablesOnly {
+		query += ` and exists (
+			select 1
+			from pg_class pgc
+			inner join pg_namespace pgn on pgn.oid = pgc.relnamespace
+			inner join pg_roles pgr on pgr.oid = pgc.relowner
+			where pgn.nspname = table_schema and pgc.relname = table_name and pgr.rolname = current_user
+		)`
+	}
 	if len(whitelist) > 0 {
-		query += fmt.Sprintf(" and table_name in (%s);", strmangle.Placeholders(true, len(whitelist), 2, 1))
+		query += fmt.Sprintf(" and table_name in (%s)", strmangle.Placeholders(true, len(whitelist), 2, 1))
 		for _, w := range whitelist {
 			args = append(args, w)
 		}
-	} else if len(blacklist) > 0 {
-		query += fmt.Sprintf(" and table_name not in (%s);", strmangle.Placeholders(true, len(blacklist), 2, 1))
-		for _, b := range blacklist {
-			args = append(args, b)
+	} else {
+		if len(blacklist) > 0 {
+			query += fmt.Sprintf(" and table_name not in (%s)", strmangle.Placeholders(true, len(blacklist), 2, 1))
+			for _, b := range blacklist {
+				args = append(args, b)
+			}
 		}
+		// Only a whitelist can ask for internal tables
+		query += postgresNotInternal
 	}
 
-	rows, err := p.dbConn.Query(query, args...)
+	query += " order by table_name;"
+
+	rows, err := p.conn().Query(query, args...)
 
 	if err != nil {
 		return nil, err
@@ -119,17 +286,65 @@ func (p *PostgresDriver) TableNames(schema string, whitelist, blacklist []string
 		names = append(names, name)
 	}
 
+	// Done with the rows, the snapshot transaction can only run one query at a time
+	rows.Close()
+
+	for _, name := range PostgresCatalogTables {
+		catalog, table := postgresCatalogTable(name)
+		// The lists can name the table with or without its catalog
+		qualified := catalog + "." + table
+		listed := func(list []string) bool {
+			return strmangle.SetInclude(table, list) || strmangle.SetInclude(qualified, list)
+		}
+		if len(whitelist) > 0 && !listed(whitelist) || len(whitelist) == 0 && listed(blacklist) {
+			continue
+		}
+
+		var exists bool
+		row := p.conn().QueryRow(`select exists(select 1 from information_schema.tables where table_schema = $1 and table_name = $2)`, catalog, table)
+		if err := row.Scan(&exists); err != nil {
+			return nil, err
+		}
+		if exists {
+			// Qualified so they don't collide with the tables of the schema
+			names = append(names, qualified)
+		}
+	}
+
 	return names, nil
 }
 
+// postgresCatalogTable splits a PostgresCatalogTables entry in its schema
+// and table name.
+func postgresCatalogTable(name string) (schema, table string) {
+	if i := strings.IndexByte(name, '.'); i >= 0 {
+		return name[:i], name[i+1:]
+	}
+
+	return "pg_catalog", name
+}
+
+// postgresCatalogSchema returns the schema and name of tableName if it's one
+// of the PostgresCatalogTables, which TableNames qualifies with their
+// catalog, otherwise schema and tableName.
+func postgresCatalogSchema(schema, tableName string) (string, string) {
+	for _, name := range PostgresCatalogTables {
+		if catalog, table := postgresCatalogTable(name); catalog+"."+table == tableName {
+			return catalog, table
+		}
+	}
+
+	return schema, tableName
+}
+
 // Columns takes a table name and attempts to retrieve the table information
 // from the database information_schema.columns. It retrieves the column names
 // and column types and returns those as a []Column after TranslateColumnType()
 // converts the SQL types to Go types, for example: "varchar" to "string"
 func (p *PostgresDriver) Columns(schema, tableName string) ([]bdb.Column, error) {
-	var columns []bdb.Column
+	schema, tableName = postgresCatalogSchema(schema, tableName)
 
-	rows, err := p.dbConn.Query(`
+	rows, err := p.conn().Query(`
 		select
 		c.column_name,
 		(
@@ -155,8 +370,31 @@ func (p *PostgresDriver) Columns(schema, tableName string) ([]bdb.Column, error)
 		) as column_type,
 
 		c.udt_name,
-		e.data_type as array_type,
+		coalesce(pgt.typtype::text, '') as udt_kind,
+		(
+			case when pgat.typtype = 'e'
+			then
+			(
+				select 'enum.' || pgat.typname || '(''' || string_agg(pg_enum.enumlabel, ''',''' order by pg_enum.enumsortorder) || ''')'
+				from pg_enum
+				where pg_enum.enumtypid = pgat.oid
+			)
+			else e.data_type
+			end
+		) as array_type,
 		c.column_default,
+		-- identity columns were added in Postgres 10, they have no default
+		-- but a sequence owned by the column
+		coalesce(to_jsonb(c) ->> 'identity_generation', '') as identity_generation,
+		coalesce(case when to_jsonb(c) ->> 'is_identity' = 'YES' then pg_get_serial_sequence(
+			quote_ident(c.table_schema) || '.' || quote_ident(c.table_name), c.column_name
+		) end, '') as identity_sequence,
+		-- generation_expression was added in Postgres 12, going through
+		-- jsonb gives null instead of failing on older servers
+		coalesce(to_jsonb(c) ->> 'generation_expression', '') as generation_expression,
+		coalesce(case when c.data_type = 'numeric' then c.numeric_precision end, 0) as numeric_precision,
+		coalesce(case when c.data_type = 'numeric' then c.numeric_scale end, 0) as numeric_scale,
+		coalesce(col_description((quote_ident(c.table_schema) || '.' || quote_ident(c.table_name))::regclass, c.ordinal_position), '') as column_comment,
 
 		c.is_nullable = 'YES' as is_nullable,
 		(select exists(
@@ -173,16 +411,20 @@ func (p *PostgresDriver) Columns(schema, tableName string) ([]bdb.Column, error)
 			inner join pg_index pgi on pgi.indexrelid = pgc.oid
 			inner join pg_attribute pga on pga.attrelid = pgi.indrelid and pga.attnum = ANY(pgi.indkey)
 			where
-				pgix.schemaname = $1 and pgix.tablename = c.table_name and pga.attname = c.column_name and pgi.indisunique = true
+				pgix.schemaname = $1 and pgix.tablename = c.table_name and pga.attname = c.column_name and pgi.indisunique = true and pgi.indpred is null
 		)) as is_unique
 
 		from information_schema.columns as c
 		inner join pg_namespace as pgn on pgn.nspname = c.udt_schema
 		left join pg_type pgt on c.data_type = 'USER-DEFINED' and pgn.oid = pgt.typnamespace and c.udt_name = pgt.typname
+		left join pg_type pgat on c.data_type = 'ARRAY' and pgat.oid = (
+			select typelem from pg_type where pg_type.typnamespace = pgn.oid and pg_type.typname = c.udt_name
+		)
 		left join information_schema.element_types e
 			on ((c.table_catalog, c.table_schema, c.table_name, 'TABLE', c.dtd_identifier)
 			= (e.object_catalog, e.object_schema, e.object_name, e.object_type, e.collection_type_identifier))
-		where c.table_name = $2 and c.table_schema = $1;
+		where c.table_name = $2 and c.table_schema = $1
+		order by c.ordinal_position;
 	`, schema, tableName)
 
 	if err != nil {
@@ -190,11 +432,51 @@ func (p *PostgresDriver) Columns(schema, tableName string) ([]bdb.Column, error)
 	}
 	defer rows.Close()
 
+	columns, err := postgresScanColumns(rows, tableName)
+	if err != nil {
+		return nil, err
+	}
+
+	// The snapshot transaction can only run one query at a time
+	rows.Close()
+
+	if len(columns) == 0 {
+		// Materialized views aren't in information_schema.columns
+		if columns, err = p.matviewColumns(schema, tableName); err != nil {
+			return nil, errors.Wrapf(err, "unable to read materialized view columns for table %s", tableName)
+		}
+	}
+
+	if len(PostgresSystemColumns) != 0 {
+		system, err := p.systemColumns(schema, tableName)
+		if err != nil {
+			return nil, errors.Wrapf(err, "unable to read system columns for table %s", tableName)
+		}
+		columns = append(columns, system...)
+	}
+
+	for _, c := range columns {
+		if isPostGISType(c.UDTName) {
+			if err = p.spatialColumns(schema, tableName, columns); err != nil {
+				return nil, errors.Wrapf(err, "unable to read spatial columns for table %s", tableName)
+			}
+			break
+		}
+	}
+
+	return columns, nil
+}
+
+// postgresScanColumns reads the columns of a table from rows of the
+// information_schema.columns query, or the matviewColumns one.
+func postgresScanColumns(rows sqlRows, tableName string) ([]bdb.Column, error) {
+	var columns []bdb.Column
 	for rows.Next() {
-		var colName, colType, udtName string
+		var colName, colType, udtName, udtKind, identity, identitySequence, generation, comment string
 		var defaultValue, arrayType *string
+		var precision, scale int
 		var nullable, unique bool
-		if err := rows.Scan(&colName, &colType, &udtName, &arrayType, &defaultValue, &nullable, &unique); err != nil {
+		if err := rows.Scan(&colName, &colType, &udtName, &udtKind, &arrayType, &defaultValue, &identity, &identitySequence, &generation, &precision, &scale, &comment, &nullable, &unique); err != nil {
 			return nil, errors.Wrapf(err, "unable to scan for table %s", tableName)
 		}
 
@@ -205,29 +487,262 @@ func (p *PostgresDriver) Columns(schema, tableName string) ([]bdb.Column, error)
 			UDTName:  udtName,
 			Nullable: nullable,
 			Unique:   unique,
+			Comment:  comment,
+
+			NumericPrecision: precision,
+			NumericScale:     scale,
 		}
+		if len(generation) != 0 {
+			// Generated columns can't be written
+			column.GenerationExpression = generation
+			column.AutoGenerated = true
+		}
+		if udtKind == "c" {
+			column.DBType = "composite"
+		}
+		column.EnumValues = strmangle.ParseEnumVals(column.EnumDBType())
 		if defaultValue != nil {
 			column.Default = *defaultValue
+			column.SequenceName = postgresSequenceName(column.Default)
+			column.IsAutoIncrement = len(column.SequenceName) != 0
+		}
+		if len(identity) != 0 {
+			// GENERATED ALWAYS identity columns can't be written
+			column.Identity = identity
+			column.SequenceName = identitySequence
+			column.IsAutoIncrement = true
+			column.AutoGenerated = identity == "ALWAYS"
+		}
+
+		columns = append(columns, column)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return columns, nil
+}
+
+// matviewColumns reads the columns of a materialized view from the catalogs
+// the way information_schema.columns would report them.
+func (p *PostgresDriver) matviewColumns(schema, tableName string) ([]bdb.Column, error) {
+	rows, err := p.conn().Query(`
+	select
+	a.attname,
+	(
+		case when t.typtype = 'e'
+		then
+		(
+			select 'enum.' || t.typname || '(''' || string_agg(pg_enum.enumlabel, ''',''' order by pg_enum.enumsortorder) || ''')'
+			from pg_enum
+			where pg_enum.enumtypid = t.oid
+		)
+		when t.typelem <> 0 and t.typlen = -1 then 'ARRAY'
+		-- a typmod of -1 gives the names information_schema uses, eg:
+		-- character instead of bpchar
+		when tn.nspname = 'pg_catalog' then format_type(t.oid, -1)
+		else 'USER-DEFINED'
+		end
+	) as column_type,
+	t.typname as udt_name,
+	(case when tn.nspname = 'pg_catalog' or (t.typelem <> 0 and t.typlen = -1) then '' else t.typtype::text end) as udt_kind,
+	(
+		case when et.typtype = 'e'
+		then
+		(
+			select 'enum.' || et.typname || '(''' || string_agg(pg_enum.enumlabel, ''',''' order by pg_enum.enumsortorder) || ''')'
+			from pg_enum
+			where pg_enum.enumtypid = et.oid
+		)
+		else format_type(et.oid, -1)
+		end
+	) as array_type,
+	null as column_default,
+	'' as identity_generation,
+	'' as identity_sequence,
+	'' as generation_expression,
+	coalesce(case when t.typname = 'numeric' and a.atttypmod <> -1 then ((a.atttypmod - 4) >> 16) & 65535 end, 0) as numeric_precision,
+	coalesce(case when t.typname = 'numeric' and a.atttypmod <> -1 then (a.atttypmod - 4) & 65535 end, 0) as numeric_scale,
+	coalesce(col_description(a.attrelid, a.attnum), '') as column_comment,
+	not a.attnotnull as is_nullable,
+	exists(
+		select 1
+		from pg_index pgi
+		where pgi.indrelid = a.attrelid and pgi.indisunique and pgi.indpred is null and
+			pgi.indnatts = 1 and pgi.indkey[0] = a.attnum
+	) as is_unique
+
+	from pg_attribute a
+	inner join pg_class pgc on pgc.oid = a.attrelid and pgc.relkind = 'm'
+	inner join pg_namespace pgn on pgn.oid = pgc.relnamespace
+	-- domains are reported as their base type
+	inner join pg_type t on t.oid = (
+		select coalesce(nullif(typbasetype, 0), oid) from pg_type where oid = a.atttypid
+	)
+	inner join pg_namespace tn on tn.oid = t.typnamespace
+	left join pg_type et on t.typelem <> 0 and t.typlen = -1 and et.oid = t.typelem
+	where pgn.nspname = $1 and pgc.relname = $2 and a.attnum > 0 and not a.attisdropped
+	order by a.attnum;
+	`, schema, tableName)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return postgresScanColumns(rows, tableName)
+}
+
+// systemColumns reads the PostgresSystemColumns of a table from
+// pg_attribute, where they have negative attribute numbers. They can't be
+// written so they're AutoGenerated.
+func (p *PostgresDriver) systemColumns(schema, tableName string) ([]bdb.Column, error) {
+	query := fmt.Sprintf(`
+	select a.attname, format_type(a.atttypid, a.atttypmod)
+	from pg_attribute a
+	where a.attrelid = (quote_ident($1) || '.' || quote_ident($2))::regclass and a.attnum < 0 and
+		a.attname in (%s)
+	order by a.attnum desc`, strmangle.Placeholders(true, len(PostgresSystemColumns), 3, 1))
+
+	args := []interface{}{schema, tableName}
+	for _, c := range PostgresSystemColumns {
+		args = append(args, c)
+	}
+
+	rows, err := p.conn().Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var columns []bdb.Column
+	for rows.Next() {
+		var column bdb.Column
+		if err = rows.Scan(&column.Name, &column.DBType); err != nil {
+			return nil, err
 		}
 
+		column.IsSystem = true
+		column.AutoGenerated = true
 		columns = append(columns, column)
 	}
 
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
 	return columns, nil
 }
 
+// rgxSequenceDefault matches a default taken from a sequence,
+// eg: nextval('users_id_seq'::regclass)
+var rgxSequenceDefault = regexp.MustCompile(`^nextval\('((?:[^']|'')+)'(?:::regclass)?\)$`)
+
+// postgresSequenceName returns the name of the sequence used in a nextval
+// default value, or an empty string if the default doesn't use one.
+func postgresSequenceName(def string) string {
+	match := rgxSequenceDefault.FindStringSubmatch(def)
+	if match == nil {
+		return ""
+	}
+
+	return strings.Replace(match[1], "''", "'", -1)
+}
+
+// SequenceLastValue reads the last value of a sequence, sequence is the name
+// used in a nextval default so it's parsed as a regclass. Postgres 10 added
+// pg_sequences, older servers are read from the sequence relation itself.
+// Either way the user needs the USAGE or SELECT privilege on the sequence,
+// pg_sequences shows a NULL value without it.
+func (p *PostgresDriver) SequenceLastValue(sequence string) (*int64, error) {
+	version, err := p.serverVersion()
+	if err != nil {
+		return nil, err
+	}
+
+	query := `
+	select pgs.last_value
+	from pg_sequences pgs
+		inner join pg_namespace pgn on pgn.nspname = pgs.schemaname
+		inner join pg_class pgc on pgc.relnamespace = pgn.oid and pgc.relname = pgs.sequencename
+	where pgc.oid = $1::regclass`
+	args := []interface{}{sequence}
+	if version < 100000 {
+		// The name comes from the column default, where it's already quoted
+		query = fmt.Sprintf("select case when is_called then last_value end from %s", sequence)
+		args = nil
+	}
+
+	var value *int64
+	if err = p.conn().QueryRow(query, args...).Scan(&value); err != nil {
+		return nil, err
+	}
+
+	return value, nil
+}
+
+// SequenceInfo describes a sequence, sequence is the name used in a nextval
+// default so it's parsed as a regclass. The cache size is only known from
+// Postgres 10, when pg_sequence was added. information_schema.sequences only
+// shows the sequences the user has a privilege on, nil is returned for others.
+func (p *PostgresDriver) SequenceInfo(sequence string) (*bdb.Sequence, error) {
+	version, err := p.serverVersion()
+	if err != nil {
+		return nil, err
+	}
+
+	cacheSize := "0"
+	if version >= 100000 {
+		cacheSize = "coalesce((select pgs.seqcache from pg_sequence pgs where pgs.seqrelid = pgc.oid), 0)"
+	}
+
+	query := fmt.Sprintf(`
+	select s.sequence_schema, s.sequence_name, s.data_type,
+		s.start_value::bigint, s.increment::bigint, s.minimum_value::bigint, s.maximum_value::bigint,
+		s.cycle_option = 'YES' as cycle, %s as cache_size,
+		coalesce((
+			select pgt.relname || '.' || pga.attname
+			from pg_depend pgd
+				inner join pg_class pgt on pgt.oid = pgd.refobjid
+				inner join pg_attribute pga on pga.attrelid = pgd.refobjid and pga.attnum = pgd.refobjsubid
+			where pgd.classid = 'pg_class'::regclass and pgd.objid = pgc.oid and pgd.deptype in ('a', 'i')
+			limit 1
+		), '') as owned_by
+	from information_schema.sequences s
+		inner join pg_namespace pgn on pgn.nspname = s.sequence_schema
+		inner join pg_class pgc on pgc.relnamespace = pgn.oid and pgc.relname = s.sequence_name
+	where pgc.oid = $1::regclass`, cacheSize)
+
+	seq := &bdb.Sequence{}
+	err = p.conn().QueryRow(query, sequence).Scan(&seq.Schema, &seq.Name, &seq.DataType,
+		&seq.Start, &seq.Increment, &seq.Min, &seq.Max, &seq.Cycle, &seq.CacheSize, &seq.OwnedBy)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return seq, nil
+}
+
 // PrimaryKeyInfo looks up the primary key for a table.
 func (p *PostgresDriver) PrimaryKeyInfo(schema, tableName string) (*bdb.PrimaryKey, error) {
+	schema, tableName = postgresCatalogSchema(schema, tableName)
+
 	pkey := &bdb.PrimaryKey{}
 	var err error
 
 	query := `
-	select tc.constraint_name
+	select tc.constraint_name,
+		coalesce((select obj_description(pgcon.oid, 'pg_constraint') from pg_constraint pgcon
+			where pgcon.conrelid = (quote_ident(tc.table_schema) || '.' || quote_ident(tc.table_name))::regclass
+			and pgcon.conname = tc.constraint_name), '') as constraint_comment
 	from information_schema.table_constraints as tc
 	where tc.table_name = $1 and tc.constraint_type = 'PRIMARY KEY' and tc.table_schema = $2;`
 
-	row := p.dbConn.QueryRow(query, tableName, schema)
-	if err = row.Scan(&pkey.Name); err != nil {
+	row := p.conn().QueryRow(query, tableName, schema)
+	if err = row.Scan(&pkey.Name, &pkey.Comment); err != nil {
 		if err == sql.ErrNoRows {
 			return nil, nil
 		}
@@ -237,10 +752,11 @@ func (p *PostgresDriver) PrimaryKeyInfo(schema, tableName string) (*bdb.PrimaryK
 	queryColumns := `
 	select kcu.column_name
 	from   information_schema.key_column_usage as kcu
-	where  constraint_name = $1 and table_schema = $2;`
+	where  constraint_name = $1 and table_schema = $2
+	order by kcu.ordinal_position;`
 
-	var rows *sql.Rows
-	if rows, err = p.dbConn.Query(queryColumns, pkey.Name, schema); err != nil {
+	var rows sqlRows
+	if rows, err = p.conn().Query(queryColumns, pkey.Name, schema); err != nil {
 		return nil, err
 	}
 	defer rows.Close()
@@ -268,6 +784,8 @@ func (p *PostgresDriver) PrimaryKeyInfo(schema, tableName string) (*bdb.PrimaryK
 
 // ForeignKeyInfo retrieves the foreign keys for a given table name.
 func (p *PostgresDriver) ForeignKeyInfo(schema, tableName string) ([]bdb.ForeignKey, error) {
+	schema, tableName = postgresCatalogSchema(schema, tableName)
+
 	var fkeys []bdb.ForeignKey
 
 	query := `
@@ -276,19 +794,24 @@ func (p *PostgresDriver) ForeignKeyInfo(schema, tableName string) ([]bdb.Foreign
 		pgc.relname as source_table,
 		pgasrc.attname as source_column,
 		dstlookupname.relname as dest_table,
-		pgadst.attname as dest_column
+		pgadst.attname as dest_column,
+		case pgcon.confmatchtype when 'f' then 'FULL' when 'p' then 'PARTIAL' else 'SIMPLE' end as match_type,
+		coalesce(obj_description(pgcon.oid, 'pg_constraint'), '') as constraint_comment,
+		not pgcon.convalidated as not_valid
 	from pg_namespace pgn
 		inner join pg_class pgc on pgn.oid = pgc.relnamespace and pgc.relkind = 'r'
 		inner join pg_constraint pgcon on pgn.oid = pgcon.connamespace and pgc.oid = pgcon.conrelid
 		inner join pg_class dstlookupname on pgcon.confrelid = dstlookupname.oid
-		inner join pg_attribute pgasrc on pgc.oid = pgasrc.attrelid and pgasrc.attnum = ANY(pgcon.conkey)
-		inner join pg_attribute pgadst on pgcon.confrelid = pgadst.attrelid and pgadst.attnum = ANY(pgcon.confkey)
+		cross join lateral unnest(pgcon.conkey, pgcon.confkey) with ordinality as k(srcnum, dstnum, n)
+		inner join pg_attribute pgasrc on pgc.oid = pgasrc.attrelid and pgasrc.attnum = k.srcnum
+		inner join pg_attribute pgadst on pgcon.confrelid = pgadst.attrelid and pgadst.attnum = k.dstnum
 	where pgn.nspname = $2 and pgc.relname = $1 and pgcon.contype = 'f'
+	order by pgcon.conname, k.n
 	`
 
-	var rows *sql.Rows
+	var rows sqlRows
 	var err error
-	if rows, err = p.dbConn.Query(query, tableName, schema); err != nil {
+	if rows, err = p.conn().Query(query, tableName, schema); err != nil {
 		return nil, err
 	}
 
@@ -296,8 +819,9 @@ func (p *PostgresDriver) ForeignKeyInfo(schema, tableName string) ([]bdb.Foreign
 		var fkey bdb.ForeignKey
 		var sourceTable string
 
+		fkey.Schema = schema
 		fkey.Table = tableName
-		err = rows.Scan(&fkey.Name, &sourceTable, &fkey.Column, &fkey.ForeignTable, &fkey.ForeignColumn)
+		err = rows.Scan(&fkey.Name, &sourceTable, &fkey.Column, &fkey.ForeignTable, &fkey.ForeignColumn, &fkey.MatchType, &fkey.Comment, &fkey.NotValid)
 		if err != nil {
 			return nil, err
 		}
@@ -312,110 +836,728 @@ func (p *PostgresDriver) ForeignKeyInfo(schema, tableName string) ([]bdb.Foreign
 	return fkeys, nil
 }
 
+// IndexInfo retrieves the indexes for a given table name. Columns of an
+// index that only appear in its include list (Postgres 11+) are returned in
+// bdb.Index.Include rather than bdb.Index.Columns.
+func (p *PostgresDriver) IndexInfo(schema, tableName string) ([]bdb.Index, error) {
+	schema, tableName = postgresCatalogSchema(schema, tableName)
+
+	var indexes []bdb.Index
+
+	version, err := p.serverVersion()
+	if err != nil {
+		return nil, err
+	}
+
+	// indnkeyatts was added alongside include columns in Postgres 11,
+	// before that every column of an index is a key column.
+	keyAtts := "pgi.indnatts"
+	if version >= 110000 {
+		keyAtts = "pgi.indnkeyatts"
+	}
+
+	query := fmt.Sprintf(`
+	select
+		pgc.relname as index_name,
+		pgi.indisunique,
+		coalesce(pga.attname, '') as attname,
+		-- expression keys have no attribute, their attnum is 0
+		(case when k.attnum = 0 then pg_get_indexdef(pgi.indexrelid, k.n::int, true) else '' end) as expression,
+		k.n > %s as is_included,
+		coalesce(pgi.indoption[k.n - 1] & 1 = 1, false) as is_descending,
+		coalesce(obj_description(pgi.indexrelid, 'pg_class'), '') as index_comment,
+		coalesce(pg_get_expr(pgi.indpred, pgi.indrelid), '') as index_predicate
+	from pg_index pgi
+		inner join pg_class pgc on pgc.oid = pgi.indexrelid
+		inner join pg_class pgt on pgt.oid = pgi.indrelid
+		inner join pg_namespace pgn on pgn.oid = pgt.relnamespace
+		cross join lateral unnest(pgi.indkey::int2[]) with ordinality as k(attnum, n)
+		left join pg_attribute pga on pga.attrelid = pgi.indrelid and pga.attnum = k.attnum
+	where pgn.nspname = $1 and pgt.relname = $2
+	order by pgc.relname, k.n
+	`, keyAtts)
+
+	var rows sqlRows
+	if rows, err = p.conn().Query(query, schema, tableName); err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var name, column, expression, comment, predicate string
+		var unique, included, descending bool
+		if err = rows.Scan(&name, &unique, &column, &expression, &included, &descending, &comment, &predicate); err != nil {
+			return nil, err
+		}
+
+		if len(indexes) == 0 || indexes[len(indexes)-1].Name != name {
+			indexes = append(indexes, bdb.Index{Name: name, Unique: unique, Comment: comment, Predicate: predicate})
+		}
+
+		index := &indexes[len(indexes)-1]
+		if included {
+			index.Include = append(index.Include, column)
+		} else {
+			index.Columns = append(index.Columns, bdb.IndexColumn{Name: column, Expression: expression, Descending: descending})
+		}
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return indexes, nil
+}
+
+// CheckInfo retrieves the check constraints of a table. The expression is
+// the one Postgres gives back, eg: (price > 0::numeric)
+func (p *PostgresDriver) CheckInfo(schema, tableName string) ([]bdb.Check, error) {
+	schema, tableName = postgresCatalogSchema(schema, tableName)
+
+	var checks []bdb.Check
+
+	query := `
+	select pgcon.conname, pg_get_constraintdef(pgcon.oid, true), not pgcon.convalidated, pga.attname
+	from pg_constraint pgcon
+		inner join pg_class pgc on pgc.oid = pgcon.conrelid
+		inner join pg_namespace pgn on pgn.oid = pgc.relnamespace
+		left join pg_attribute pga on pga.attrelid = pgcon.conrelid and pga.attnum = any(pgcon.conkey)
+	where pgcon.contype = 'c' and pgn.nspname = $1 and pgc.relname = $2
+	order by pgcon.conname, pga.attnum
+	`
+
+	rows, err := p.conn().Query(query, schema, tableName)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var name, def string
+		var notValid bool
+		var column sql.NullString
+		if err = rows.Scan(&name, &def, &notValid, &column); err != nil {
+			return nil, err
+		}
+
+		if len(checks) == 0 || checks[len(checks)-1].Name != name {
+			expr := strings.TrimSuffix(strings.TrimPrefix(def, "CHECK "), " NOT VALID")
+			checks = append(checks, bdb.Check{Name: name, Expression: expr, NotValid: notValid})
+		}
+
+		if column.Valid {
+			check := &checks[len(checks)-1]
+			check.Columns = append(check.Columns, column.String)
+		}
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return checks, nil
+}
+
+// ExclusionInfo retrieves the exclusion constraints of a table with the
+// operator and operator class of each column or expression.
+func (p *PostgresDriver) ExclusionInfo(schema, tableName string) ([]bdb.Exclusion, error) {
+	schema, tableName = postgresCatalogSchema(schema, tableName)
+
+	var exclusions []bdb.Exclusion
+
+	query := `
+	select
+		pgcon.conname,
+		pg_get_constraintdef(pgcon.oid, true),
+		pgam.amname,
+		coalesce(pga.attname, ''),
+		pgo.oprname,
+		coalesce(pgopc.opcname, '')
+	from pg_constraint pgcon
+		inner join pg_class pgc on pgc.oid = pgcon.conrelid
+		inner join pg_namespace pgn on pgn.oid = pgc.relnamespace
+		inner join pg_index pgi on pgi.indexrelid = pgcon.conindid
+		inner join pg_class pgic on pgic.oid = pgi.indexrelid
+		inner join pg_am pgam on pgam.oid = pgic.relam
+		cross join lateral unnest(pgcon.conkey, pgcon.conexclop) with ordinality as k(attnum, opoid, n)
+		inner join pg_operator pgo on pgo.oid = k.opoid
+		left join pg_attribute pga on pga.attrelid = pgcon.conrelid and pga.attnum = k.attnum
+		left join pg_opclass pgopc on pgopc.oid = pgi.indclass[k.n - 1]
+	where pgcon.contype = 'x' and pgn.nspname = $1 and pgc.relname = $2
+	order by pgcon.conname, k.n
+	`
+
+	rows, err := p.conn().Query(query, schema, tableName)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var name, def, method string
+		var elem bdb.ExclusionElement
+		if err = rows.Scan(&name, &def, &method, &elem.Column, &elem.Operator, &elem.OpClass); err != nil {
+			return nil, err
+		}
+
+		if len(exclusions) == 0 || exclusions[len(exclusions)-1].Name != name {
+			exclusions = append(exclusions, bdb.Exclusion{Name: name, Method: method, Definition: def})
+		}
+
+		exclusion := &exclusions[len(exclusions)-1]
+		exclusion.Elements = append(exclusion.Elements, elem)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return exclusions, nil
+}
+
+// TriggerInfo retrieves the triggers of a table, a trigger that fires on
+// several events is returned once with the events joined by OR.
+func (p *PostgresDriver) TriggerInfo(schema, tableName string) ([]bdb.Trigger, error) {
+	schema, tableName = postgresCatalogSchema(schema, tableName)
+
+	var triggers []bdb.Trigger
+
+	query := `
+	select trigger_name, action_timing, event_manipulation, action_statement
+	from information_schema.triggers
+	where event_object_schema = $1 and event_object_table = $2
+	order by trigger_name, event_manipulation
+	`
+
+	rows, err := p.conn().Query(query, schema, tableName)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var trigger bdb.Trigger
+		if err = rows.Scan(&trigger.Name, &trigger.Timing, &trigger.Event, &trigger.Function); err != nil {
+			return nil, err
+		}
+
+		if len(triggers) != 0 && triggers[len(triggers)-1].Name == trigger.Name {
+			last := &triggers[len(triggers)-1]
+			last.Event += " OR " + trigger.Event
+			continue
+		}
+
+		trigger.Function = strings.TrimPrefix(trigger.Function, "EXECUTE FUNCTION ")
+		trigger.Function = strings.TrimPrefix(trigger.Function, "EXECUTE PROCEDURE ")
+		triggers = append(triggers, trigger)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return triggers, nil
+}
+
+// PolicyInfo retrieves the row level security policies of a table from
+// pg_policies.
+func (p *PostgresDriver) PolicyInfo(schema, tableName string) ([]bdb.Policy, error) {
+	schema, tableName = postgresCatalogSchema(schema, tableName)
+
+	var policies []bdb.Policy
+
+	query := `
+	select policyname, cmd, permissive = 'PERMISSIVE', array_to_string(roles, ','),
+		coalesce(qual, ''), coalesce(with_check, '')
+	from pg_policies
+	where schemaname = $1 and tablename = $2
+	order by policyname
+	`
+
+	rows, err := p.conn().Query(query, schema, tableName)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var policy bdb.Policy
+		var roles string
+		if err = rows.Scan(&policy.Name, &policy.Command, &policy.Permissive, &roles, &policy.Using, &policy.WithCheck); err != nil {
+			return nil, err
+		}
+
+		policy.Roles = strings.Split(roles, ",")
+		policies = append(policies, policy)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return policies, nil
+}
+
+// serverVersion returns the server_version_num of the postgres server
+func (p *PostgresDriver) serverVersion() (int, error) {
+	var version int
+	if err := p.conn().QueryRow("show server_version_num").Scan(&version); err != nil {
+		return 0, errors.Wrap(err, "unable to get postgres server version")
+	}
+
+	return version, nil
+}
+
+// DatabaseInfo reads the encoding and default collation of the database
+// from pg_database, and the server's version.
+func (p *PostgresDriver) DatabaseInfo() (*bdb.DatabaseInfo, error) {
+	query := `
+	select pg_encoding_to_char(pgd.encoding), pgd.datcollate, current_setting('server_version')
+	from pg_database pgd
+	where pgd.datname = current_database();`
+
+	info := &bdb.DatabaseInfo{}
+	row := p.conn().QueryRow(query)
+	if err := row.Scan(&info.Encoding, &info.Collation, &info.ServerVersion); err != nil {
+		return nil, errors.Wrap(err, "unable to get the database info")
+	}
+
+	return info, nil
+}
+
+// rgxDblinkCall matches a call to dblink in a view definition, eg:
+// dblink('remote', 'select id from users') or public.dblink(...)
+var rgxDblinkCall = regexp.MustCompile(`(?i)(^|[^\w$])dblink\s*\(`)
+
+// TableDetails reads whether the table is a view and the definition of
+// views from pg_class. The rest of the table metadata is only read when
+// ExtendedMetadata is enabled.
+func (p *PostgresDriver) TableDetails(schema string, t *bdb.Table) error {
+	schema, tableName := postgresCatalogSchema(schema, t.Name)
+
+	query := `
+	select pgc.oid, pgc.relpersistence, pgc.relkind, pg_total_relation_size(pgc.oid)
+	from pg_class pgc
+	inner join pg_namespace pgn on pgn.oid = pgc.relnamespace
+	where pgn.nspname = $1 and pgc.relname = $2;`
+
+	var oid uint32
+	var size int64
+	var persistence, kind string
+	row := p.conn().QueryRow(query, schema, tableName)
+	if err := row.Scan(&oid, &persistence, &kind, &size); err != nil {
+		return err
+	}
+
+	t.IsView = kind == "v" || kind == "m"
+	t.IsMaterialized = kind == "m"
+	if t.IsView {
+		// Materialized views aren't in information_schema.views, they can
+		// only be refreshed.
+		query = `
+		select pg_get_viewdef((quote_ident($1) || '.' || quote_ident($2))::regclass, true),
+			coalesce((select is_updatable = 'YES' from information_schema.views where table_schema = $1 and table_name = $2), false);`
+
+		row = p.conn().QueryRow(query, schema, tableName)
+		if err := row.Scan(&t.ViewDefinition, &t.IsUpdatable); err != nil {
+			return err
+		}
+		t.IsRemote = rgxDblinkCall.MatchString(t.ViewDefinition)
+	}
+
+	if !ExtendedMetadata {
+		return nil
+	}
+	t.OID = oid
+	t.TotalSizeBytes = size
+
+	t.IsPartitioned = kind == "p"
+	if t.IsPartitioned {
+		key, err := p.partitionKey(schema, tableName)
+		if err != nil {
+			return err
+		}
+		t.PartitionKey = key
+	}
+
+	if kind == "r" {
+		parents, err := p.inheritedTables(schema, tableName)
+		if err != nil {
+			return err
+		}
+		t.Inherits = parents
+	}
+
+	if kind == "r" || kind == "p" {
+		if err := p.columnStorage(schema, tableName, t); err != nil {
+			return err
+		}
+	}
+
+	grants, err := p.grants(schema, tableName)
+	if err != nil {
+		return err
+	}
+	t.Grants = grants
+
+	switch persistence {
+	case "u":
+		t.Persistence = bdb.PersistenceUnlogged
+	case "t":
+		t.Persistence = bdb.PersistenceTemporary
+	default:
+		t.Persistence = bdb.PersistencePermanent
+	}
+
+	return nil
+}
+
+// columnStorage fills in the storage and compression of the table's columns
+// from pg_attribute. Unlike information_schema, pg_attribute keeps the
+// columns that were dropped (renamed to ........pg.dropped.N........) so
+// they're filtered out. The rows are matched to the columns by name, so a
+// dropped column can't be mistaken for another one and the gaps it leaves in
+// the attribute numbers don't matter.
+func (p *PostgresDriver) columnStorage(schema, tableName string, t *bdb.Table) error {
+	version, err := p.serverVersion()
+	if err != nil {
+		return err
+	}
+
+	// attcompression was added in Postgres 14
+	compression := "''"
+	if version >= 140000 {
+		compression = "pga.attcompression::text"
+	}
+
+	query := fmt.Sprintf(`
+	select pga.attname,
+		case pga.attstorage when 'p' then 'plain' when 'm' then 'main' when 'e' then 'external' else 'extended' end,
+		case %s when 'p' then 'pglz' when 'l' then 'lz4' else '' end
+	from pg_attribute pga
+		inner join pg_class pgc on pgc.oid = pga.attrelid
+		inner join pg_namespace pgn on pgn.oid = pgc.relnamespace
+	where pgn.nspname = $1 and pgc.relname = $2 and pga.attnum > 0 and not pga.attisdropped
+	`, compression)
+
+	rows, err := p.conn().Query(query, schema, tableName)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var name, storage, method string
+		if err = rows.Scan(&name, &storage, &method); err != nil {
+			return err
+		}
+
+		for i := range t.Columns {
+			if t.Columns[i].DBName == name {
+				t.Columns[i].Storage = storage
+				t.Columns[i].Compression = method
+			}
+		}
+	}
+
+	return rows.Err()
+}
+
+// inheritedTables returns the tables a table INHERITS from, in the order
+// they were given. Partitions are in pg_inherits as well, their parent is
+// a partitioned table so they're left out. Parents in another schema are
+// qualified with it.
+func (p *PostgresDriver) inheritedTables(schema, tableName string) ([]string, error) {
+	query := `
+	select case when parentn.nspname = $1 then parent.relname else parentn.nspname || '.' || parent.relname end
+	from pg_inherits pgi
+		inner join pg_class pgc on pgc.oid = pgi.inhrelid
+		inner join pg_namespace pgn on pgn.oid = pgc.relnamespace
+		inner join pg_class parent on parent.oid = pgi.inhparent
+		inner join pg_namespace parentn on parentn.oid = parent.relnamespace
+	where pgn.nspname = $1 and pgc.relname = $2 and parent.relkind <> 'p'
+	order by pgi.inhseqno
+	`
+
+	rows, err := p.conn().Query(query, schema, tableName)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var parents []string
+	for rows.Next() {
+		var parent string
+		if err = rows.Scan(&parent); err != nil {
+			return nil, err
+		}
+		parents = append(parents, parent)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return parents, nil
+}
+
+// grants reads the privileges granted on a table from
+// information_schema.role_table_grants, it only has the grants the current
+// user gave or was given (directly or through a role).
+func (p *PostgresDriver) grants(schema, tableName string) ([]bdb.Grant, error) {
+	query := `
+	select grantee, privilege_type, is_grantable = 'YES'
+	from information_schema.role_table_grants
+	where table_schema = $1 and table_name = $2
+	order by grantee, privilege_type
+	`
+
+	rows, err := p.conn().Query(query, schema, tableName)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var grants []bdb.Grant
+	for rows.Next() {
+		var grant bdb.Grant
+		if err = rows.Scan(&grant.Grantee, &grant.Privilege, &grant.Grantable); err != nil {
+			return nil, err
+		}
+		grants = append(grants, grant)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return grants, nil
+}
+
+// partitionKey resolves the pg_partitioned_table.partattrs of a table to
+// column names, in the order of the key.
+func (p *PostgresDriver) partitionKey(schema, tableName string) ([]string, error) {
+	query := `
+	select pga.attname
+	from pg_partitioned_table pgpt
+		inner join pg_class pgc on pgc.oid = pgpt.partrelid
+		inner join pg_namespace pgn on pgn.oid = pgc.relnamespace
+		cross join unnest(pgpt.partattrs::int2[]) with ordinality as k(attnum, n)
+		inner join pg_attribute pga on pga.attrelid = pgpt.partrelid and pga.attnum = k.attnum
+	where pgn.nspname = $1 and pgc.relname = $2
+	order by k.n
+	`
+
+	rows, err := p.conn().Query(query, schema, tableName)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var columns []string
+	for rows.Next() {
+		var column string
+		if err = rows.Scan(&column); err != nil {
+			return nil, err
+		}
+		columns = append(columns, column)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return columns, nil
+}
+
+// CompositeFields returns the attributes of the composite type typeName as
+// columns, with their Go types already translated. Composite columns have
+// the DBType "composite", their UDTName is the name of the type and they're
+// flagged with UnknownType.
+func (p *PostgresDriver) CompositeFields(schema, typeName string) ([]bdb.Column, error) {
+	var fields []bdb.Column
+
+	rows, err := p.conn().Query(`
+	select a.attribute_name, a.data_type, a.attribute_udt_name, e.data_type as array_type, a.is_nullable = 'YES'
+	from information_schema.attributes a
+	left join information_schema.element_types e
+		on ((a.udt_catalog, a.udt_schema, a.udt_name, 'USER-DEFINED TYPE', a.dtd_identifier)
+		= (e.object_catalog, e.object_schema, e.object_name, e.object_type, e.collection_type_identifier))
+	where a.udt_schema = $1 and a.udt_name = $2
+	order by a.ordinal_position
+	`, schema, typeName)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var field bdb.Column
+		if err := rows.Scan(&field.Name, &field.DBType, &field.UDTName, &field.ArrType, &field.Nullable); err != nil {
+			return nil, errors.Wrapf(err, "unable to scan attributes of type %s", typeName)
+		}
+
+		fields = append(fields, p.TranslateColumnType(field))
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return fields, nil
+}
+
 // TranslateColumnType converts postgres database types to Go types, for example
 // "varchar" to "string" and "bigint" to "int64". It returns this parsed data
 // as a Column object.
+//
+// Arrays and the user defined types that have a Go type of their own are
+// resolved first, whether they're nullable doesn't change their types (a
+// NULL array is a nil slice). Every other type gets its base Go type, which
+// is swapped for its null type when the column is nullable.
 func (p *PostgresDriver) TranslateColumnType(c bdb.Column) bdb.Column {
-	if c.Nullable {
-		switch c.DBType {
-		case "bigint", "bigserial":
-			c.Type = "null.Int64"
-		case "integer", "serial":
-			c.Type = "null.Int"
-		case "smallint", "smallserial":
-			c.Type = "null.Int16"
-		case "decimal", "numeric", "double precision":
-			c.Type = "null.Float64"
-		case "real":
-			c.Type = "null.Float32"
-		case "bit", "interval", "bit varying", "character", "money", "character varying", "cidr", "inet", "macaddr", "text", "uuid", "xml":
-			c.Type = "null.String"
-		case `"char"`:
-			c.Type = "null.Byte"
-		case "bytea":
-			c.Type = "null.Bytes"
-		case "json", "jsonb":
-			c.Type = "null.JSON"
-		case "boolean":
-			c.Type = "null.Bool"
-		case "date", "time", "timestamp without time zone", "timestamp with time zone":
-			c.Type = "null.Time"
-		case "ARRAY":
-			if c.ArrType == nil {
-				panic("unable to get postgres ARRAY underlying type")
-			}
-			c.Type = getArrayType(c)
-			// Make DBType something like ARRAYinteger for parsing with randomize.Struct
-			c.DBType = c.DBType + *c.ArrType
-		case "USER-DEFINED":
-			if c.UDTName == "hstore" {
-				c.Type = "types.HStore"
-				c.DBType = "hstore"
-			} else {
-				c.Type = "string"
-				fmt.Fprintf(os.Stderr, "Warning: Incompatible data type detected: %s\n", c.UDTName)
-			}
-		default:
-			c.Type = "null.String"
+	switch c.DBType {
+	case "ARRAY":
+		if c.ArrType == nil {
+			panic("unable to get postgres ARRAY underlying type")
 		}
-	} else {
-		switch c.DBType {
-		case "bigint", "bigserial":
-			c.Type = "int64"
-		case "integer", "serial":
-			c.Type = "int"
-		case "smallint", "smallserial":
-			c.Type = "int16"
-		case "decimal", "numeric", "double precision":
-			c.Type = "float64"
-		case "real":
-			c.Type = "float32"
-		case "bit", "interval", "uuint", "bit varying", "character", "money", "character varying", "cidr", "inet", "macaddr", "text", "uuid", "xml":
-			c.Type = "string"
-		case `"char"`:
-			c.Type = "types.Byte"
-		case "json", "jsonb":
-			c.Type = "types.JSON"
-		case "bytea":
-			c.Type = "[]byte"
-		case "boolean":
-			c.Type = "bool"
-		case "date", "time", "timestamp without time zone", "timestamp with time zone":
-			c.Type = "time.Time"
-		case "ARRAY":
-			c.Type = getArrayType(c)
-			// Make DBType something like ARRAYinteger for parsing with randomize.Struct
-			c.DBType = c.DBType + *c.ArrType
-		case "USER-DEFINED":
-			if c.UDTName == "hstore" {
-				c.Type = "types.HStore"
-				c.DBType = "hstore"
-			} else {
-				c.Type = "string"
-				fmt.Printf("Warning: Incompatible data type detected: %s\n", c.UDTName)
-			}
-		default:
-			c.Type = "string"
+		c.Type = getArrayType(c)
+		// Make DBType something like ARRAYinteger for parsing with randomize.Struct
+		c.DBType = c.DBType + *c.ArrType
+		return c
+	case "USER-DEFINED":
+		if c.UDTName == "hstore" {
+			c.Type = "types.HStore"
+			c.DBType = "hstore"
+			return c
+		}
+		if isPostGISType(c.UDTName) {
+			c.DBType = c.UDTName
+			setSpatialType(&c, "[]byte", "null.Bytes")
+			return c
 		}
+
+		c.Type = "string"
+		fmt.Fprintf(os.Stderr, "Warning: Incompatible data type detected: %s\n", c.UDTName)
+		c.UnknownType = true
+	default:
+		c.Type = postgresBaseType(&c)
+		if goType := PostgresIntTypes.forDBType(c.DBType); len(goType) != 0 {
+			c.Type = goType
+		}
+	}
+
+	if c.Nullable {
+		c.Type = postgresNullTypes[c.Type]
 	}
 
 	return c
 }
 
+// postgresBaseType returns the Go type of a NOT NULL column that isn't an
+// array or user defined type, flagging text representations and unknown
+// types on the column.
+func postgresBaseType(c *bdb.Column) string {
+	switch c.DBType {
+	case "bigint", "bigserial":
+		return "int64"
+	case "integer", "serial":
+		return "int"
+	case "smallint", "smallserial":
+		return "int16"
+	case "decimal", "numeric", "double precision":
+		if isUnconstrainedNumeric(*c) {
+			return "types.Decimal"
+		}
+		return "float64"
+	case "real":
+		return "float32"
+	case "bit", "interval", "uuint", "bit varying", "character", "money", "character varying", "cidr", "inet", "macaddr", "text", "uuid", "xml":
+		return "string"
+	case "tsvector", "tsquery":
+		// Full text search documents and queries, these are kept as their
+		// text representation and the DBType is left as is.
+		return "string"
+	case "composite":
+		// Composite types have no Go type of their own, they're read as
+		// their text, eg: (1,"some text"), and flagged so a Go type is
+		// picked for them. CompositeFields describes their attributes.
+		c.UnknownType = true
+		return "string"
+	case "pg_lsn", "txid_snapshot", "pg_snapshot", "point", "line", "lseg", "box", "path", "polygon", "circle",
+		"int4range", "int8range", "numrange", "tsrange", "tstzrange", "daterange", "macaddr8", "jsonpath",
+		"xid", "cid", "tid":
+		// System, geometric and range types are kept in their text
+		// representation, they're flagged so it's clear that's on purpose.
+		c.TextRepresentation = true
+		return "string"
+	case `"char"`:
+		return "types.Byte"
+	case "name":
+		// The type of the identifiers in the catalogs, at most 63 bytes
+		return "string"
+	case "oid":
+		// Object identifiers are unsigned 4 byte integers
+		return "uint32"
+	case "regclass", "regcollation", "regconfig", "regdictionary", "regnamespace", "regoper",
+		"regoperator", "regproc", "regprocedure", "regrole", "regtype":
+		// The oid alias types read as the name of the object, eg: public.users
+		return "string"
+	case "json", "jsonb":
+		return "types.JSON"
+	case "bytea":
+		return "[]byte"
+	case "boolean":
+		return "bool"
+	case "date", "time", "timestamp without time zone", "timestamp with time zone":
+		return "time.Time"
+	default:
+		// enum types are strings on purpose, the templates make constants for them
+		c.UnknownType = !strings.HasPrefix(c.DBType, "enum")
+		return "string"
+	}
+}
+
+// postgresNullTypes are the null types of the base types of postgresBaseType
+var postgresNullTypes = map[string]string{
+	"int64":         "null.Int64",
+	"int":           "null.Int",
+	"int16":         "null.Int16",
+	"int32":         "null.Int32",
+	"uint32":        "null.Uint32",
+	"float64":       "null.Float64",
+	"float32":       "null.Float32",
+	"types.Decimal": "types.NullDecimal",
+	"string":        "null.String",
+	"types.Byte":    "null.Byte",
+	"types.JSON":    "null.JSON",
+	"[]byte":        "null.Bytes",
+	"bool":          "null.Bool",
+	"time.Time":     "null.Time",
+}
+
+// isUnconstrainedNumeric checks for a numeric without a precision, it holds
+// any number of digits so it can't be a float64.
+func isUnconstrainedNumeric(c bdb.Column) bool {
+	return c.DBType == "numeric" && c.NumericPrecision == 0
+}
+
 // getArrayType returns the correct boil.Array type for each database type
 func getArrayType(c bdb.Column) string {
 	switch *c.ArrType {
-	case "bigint", "bigserial", "integer", "serial", "smallint", "smallserial":
+	case "bigint", "bigserial", "integer", "serial", "smallint", "smallserial", "oid":
 		return "types.Int64Array"
 	case "bytea":
 		return "types.BytesArray"
-	case "bit", "interval", "uuint", "bit varying", "character", "money", "character varying", "cidr", "inet", "macaddr", "text", "uuid", "xml":
+	case "bit", "interval", "uuint", "bit varying", "character", "money", "character varying", "cidr", "inet", "macaddr", "text", "uuid", "xml", "name":
 		return "types.StringArray"
 	case "boolean":
 		return "types.BoolArray"
 	case "decimal", "numeric", "double precision", "real":
 		return "types.Float64Array"
 	default:
+		// Arrays of enums are string arrays like enum columns are strings,
+		// there's no generated enum type for a typed slice
 		return "types.StringArray"
 	}
 }
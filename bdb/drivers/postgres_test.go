@@ -0,0 +1,1433 @@
+package drivers
+
+import (
+	"errors"
+	"os"
+	"reflect"
+	"testing"
+
+	"github.com/volatiletech/sqlboiler/bdb"
+	"gopkg.in/DATA-DOG/go-sqlmock.v1"
+)
+
+func TestPostgresPrimaryKeyInfoOrder(t *testing.T) {
+	t.Parallel()
+
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	mock.ExpectQuery(`select tc.constraint_name`).
+		WithArgs("shipments", "public").
+		WillReturnRows(sqlmock.NewRows([]string{"constraint_name", "constraint_comment"}).AddRow("shipments_pkey", "One row per order line"))
+	mock.ExpectQuery(`order by kcu.ordinal_position`).
+		WithArgs("shipments_pkey", "public").
+		WillReturnRows(sqlmock.NewRows([]string{"column_name"}).
+			AddRow("warehouse_id").
+			AddRow("order_id").
+			AddRow("line"))
+
+	p := &PostgresDriver{dbConn: db}
+	pkey, err := p.PrimaryKeyInfo("public", "shipments")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if pkey.Comment != "One row per order line" {
+		t.Errorf("comment was wrong: %q", pkey.Comment)
+	}
+
+	want := []string{"warehouse_id", "order_id", "line"}
+	if len(pkey.Columns) != len(want) {
+		t.Fatalf("want %d columns, got: %v", len(want), pkey.Columns)
+	}
+	for i, c := range want {
+		if pkey.Columns[i] != c {
+			t.Errorf("%d) want column %s, got: %s", i, c, pkey.Columns[i])
+		}
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestPostgresSequenceName(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		Default string
+		Want    string
+	}{
+		{"", ""},
+		{"0", ""},
+		{"now()", ""},
+		{"nextval('users_id_seq'::regclass)", "users_id_seq"},
+		{"nextval('shared.global_id_seq'::regclass)", "shared.global_id_seq"},
+		{`nextval('"Odd''Name_seq"'::regclass)`, `"Odd'Name_seq"`},
+		{"nextval('plain_seq')", "plain_seq"},
+	}
+
+	for i, test := range tests {
+		if got := postgresSequenceName(test.Default); got != test.Want {
+			t.Errorf("%d) want %q, got %q", i, test.Want, got)
+		}
+	}
+}
+
+func TestPostgresColumnsSerial(t *testing.T) {
+	t.Parallel()
+
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	cols := []string{"column_name", "column_type", "udt_name", "udt_kind", "array_type", "column_default", "identity_generation",
+		"identity_sequence", "generation_expression",
+		"numeric_precision", "numeric_scale", "column_comment", "is_nullable", "is_unique"}
+	mock.ExpectQuery(`from information_schema.columns`).
+		WithArgs("public", "users").
+		WillReturnRows(sqlmock.NewRows(cols).
+			AddRow("id", "integer", "int4", "", nil, "nextval('users_id_seq'::regclass)", "", "", "", 0, 0, "", false, true).
+			AddRow("big_id", "bigint", "int8", "", nil, "nextval('users_big_id_seq'::regclass)", "", "", "", 0, 0, "", false, false).
+			AddRow("identity_id", "integer", "int4", "", nil, nil, "ALWAYS", "public.users_identity_id_seq", "", 0, 0, "", false, false).
+			AddRow("ref", "smallint", "int2", "", nil, nil, "BY DEFAULT", "public.users_ref_seq", "", 0, 0, "", false, false).
+			AddRow("age", "integer", "int4", "", nil, nil, "", "", "", 0, 0, "", false, false))
+
+	p := &PostgresDriver{dbConn: db}
+	columns, err := p.Columns("public", "users")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		Type      string
+		Sequence  string
+		Default   bool
+		Identity  string
+		Generated bool
+	}{
+		{"int", "users_id_seq", true, "", false},
+		{"int64", "users_big_id_seq", true, "", false},
+		{"int", "public.users_identity_id_seq", true, "ALWAYS", true},
+		{"int16", "public.users_ref_seq", true, "BY DEFAULT", false},
+		{"int", "", false, "", false},
+	}
+
+	if len(columns) != len(tests) {
+		t.Fatalf("want %d columns, got: %#v", len(tests), columns)
+	}
+	for i, test := range tests {
+		c := p.TranslateColumnType(columns[i])
+		auto := len(test.Sequence) != 0
+		if c.Type != test.Type || c.SequenceName != test.Sequence || c.IsAutoIncrement != auto || c.HasDefault() != test.Default {
+			t.Errorf("%d) %s was wrong: %#v", i, c.Name, c)
+		}
+		if c.Identity != test.Identity || c.AutoGenerated != test.Generated {
+			t.Errorf("%d) %s has the wrong identity: %#v", i, c.Name, c)
+		}
+	}
+
+	if withDefault := bdb.FilterColumnsByDefault(true, columns); len(withDefault) != 4 {
+		t.Errorf("want the serial and identity columns to get their values from the database, got: %#v", withDefault)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestPostgresColumnsGenerated(t *testing.T) {
+	t.Parallel()
+
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	cols := []string{"column_name", "column_type", "udt_name", "udt_kind", "array_type", "column_default", "identity_generation",
+		"identity_sequence", "generation_expression",
+		"numeric_precision", "numeric_scale", "column_comment", "is_nullable", "is_unique"}
+	mock.ExpectQuery(`to_jsonb\(c\) ->> 'generation_expression'`).
+		WithArgs("public", "lines").
+		WillReturnRows(sqlmock.NewRows(cols).
+			AddRow("qty", "integer", "int4", "", nil, nil, "", "", "", 0, 0, "", false, false).
+			AddRow("total", "numeric", "numeric", "", nil, nil, "", "", "((qty)::numeric * price)", 0, 0, "", true, false))
+
+	p := &PostgresDriver{dbConn: db}
+	columns, err := p.Columns("public", "lines")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(columns) != 2 {
+		t.Fatalf("want 2 columns, got: %#v", columns)
+	}
+	if c := columns[0]; c.AutoGenerated || len(c.GenerationExpression) != 0 {
+		t.Errorf("qty isn't generated: %#v", c)
+	}
+	if c := columns[1]; !c.AutoGenerated || c.GenerationExpression != "((qty)::numeric * price)" || c.HasDefault() {
+		t.Errorf("total was wrong: %#v", c)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestPostgresColumnsArrayOfEnum(t *testing.T) {
+	t.Parallel()
+
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	cols := []string{"column_name", "column_type", "udt_name", "udt_kind", "array_type", "column_default", "identity_generation",
+		"identity_sequence", "generation_expression",
+		"numeric_precision", "numeric_scale", "column_comment", "is_nullable", "is_unique"}
+	mock.ExpectQuery(`from information_schema.columns`).
+		WithArgs("public", "diaries").
+		WillReturnRows(sqlmock.NewRows(cols).
+			AddRow("mood", "enum.mood('sad','ok','happy')", "mood", "e", nil, nil, "", "", "", 0, 0, "", false, false).
+			AddRow("moods", "ARRAY", "_mood", "", "enum.mood('sad','ok','happy')", nil, "", "", "", 0, 0, "", true, false).
+			AddRow("tags", "ARRAY", "_text", "", "text", nil, "", "", "", 0, 0, "", false, false))
+
+	p := &PostgresDriver{dbConn: db}
+	columns, err := p.Columns("public", "diaries")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(columns) != 3 {
+		t.Fatalf("want 3 columns, got: %#v", columns)
+	}
+	want := []string{"sad", "ok", "happy"}
+	if !reflect.DeepEqual(columns[0].EnumValues, want) || !reflect.DeepEqual(columns[1].EnumValues, want) {
+		t.Errorf("enum values were wrong: %#v", columns)
+	}
+	if columns[2].EnumValues != nil {
+		t.Errorf("a text array has no enum values: %#v", columns[2])
+	}
+
+	c := p.TranslateColumnType(columns[1])
+	if c.Type != "types.StringArray" || c.DBType != "ARRAYenum.mood('sad','ok','happy')" || c.UnknownType {
+		t.Errorf("the array of an enum was wrong: %#v", c)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestPostgresColumnsSystem(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	PostgresSystemColumns = []string{"xmin", "ctid"}
+	defer func() { PostgresSystemColumns = nil }()
+
+	cols := []string{"column_name", "column_type", "udt_name", "udt_kind", "array_type", "column_default", "identity_generation",
+		"identity_sequence", "generation_expression",
+		"numeric_precision", "numeric_scale", "column_comment", "is_nullable", "is_unique"}
+	mock.ExpectQuery(`from information_schema.columns`).
+		WithArgs("public", "users").
+		WillReturnRows(sqlmock.NewRows(cols).
+			AddRow("id", "integer", "int4", "", nil, nil, "", "", "", 0, 0, "", false, true))
+	mock.ExpectQuery(`a.attnum < 0 and\s+a.attname in \(\$3,\$4\)`).
+		WithArgs("public", "users", "xmin", "ctid").
+		WillReturnRows(sqlmock.NewRows([]string{"attname", "format_type"}).
+			AddRow("ctid", "tid").
+			AddRow("xmin", "xid"))
+
+	p := &PostgresDriver{dbConn: db}
+	columns, err := p.Columns("public", "users")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(columns) != 3 {
+		t.Fatalf("want the system columns after id, got: %#v", columns)
+	}
+	if columns[0].IsSystem {
+		t.Error("id is not a system column")
+	}
+	for _, c := range columns[1:] {
+		c = p.TranslateColumnType(c)
+		if !c.IsSystem || !c.AutoGenerated || c.Type != "string" || c.UnknownType {
+			t.Errorf("%s was wrong: %#v", c.Name, c)
+		}
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestPostgresTableNamesOwnedOnly(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	PostgresOwnedTablesOnly = true
+	defer func() { PostgresOwnedTablesOnly = false }()
+
+	mock.ExpectQuery(`(?s)where table_schema = \$1 and exists \(.*pgr.rolname = current_user\s*\) and table_name not in \(\$2\) and table_schema <> 'pg_toast'.* order by table_name`).
+		WithArgs("public", "migrations").
+		WillReturnRows(sqlmock.NewRows([]string{"table_name"}).AddRow("users"))
+
+	p := &PostgresDriver{dbConn: db}
+	names, err := p.TableNames("public", nil, []string{"migrations"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(names, []string{"users"}) {
+		t.Errorf("want users, got %v", names)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestPostgresTableNamesInternal(t *testing.T) {
+	t.Parallel()
+
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	mock.ExpectQuery(`and table_name not like 'pg\\_toast\\_%' order by table_name`).
+		WithArgs("pg_toast").
+		WillReturnRows(sqlmock.NewRows([]string{"table_name"}))
+	mock.ExpectQuery(`where table_schema = \$1 and table_name in \(\$2\) order by table_name`).
+		WithArgs("pg_toast", "pg_toast_2619").
+		WillReturnRows(sqlmock.NewRows([]string{"table_name"}).AddRow("pg_toast_2619"))
+
+	p := &PostgresDriver{dbConn: db}
+	names, err := p.TableNames("pg_toast", nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(names) != 0 {
+		t.Errorf("want no tables, got %v", names)
+	}
+
+	names, err = p.TableNames("pg_toast", []string{"pg_toast_2619"}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(names, []string{"pg_toast_2619"}) {
+		t.Errorf("a whitelisted internal table should be read, got %v", names)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestPostgresSequenceLastValue(t *testing.T) {
+	t.Parallel()
+
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	mock.ExpectQuery(`show server_version_num`).
+		WillReturnRows(sqlmock.NewRows([]string{"server_version_num"}).AddRow(120000))
+	mock.ExpectQuery(`from pg_sequences pgs`).
+		WithArgs("users_id_seq").
+		WillReturnRows(sqlmock.NewRows([]string{"last_value"}).AddRow(42))
+	mock.ExpectQuery(`show server_version_num`).
+		WillReturnRows(sqlmock.NewRows([]string{"server_version_num"}).AddRow(120000))
+	mock.ExpectQuery(`from pg_sequences pgs`).
+		WithArgs("unused_seq").
+		WillReturnRows(sqlmock.NewRows([]string{"last_value"}).AddRow(nil))
+	mock.ExpectQuery(`show server_version_num`).
+		WillReturnRows(sqlmock.NewRows([]string{"server_version_num"}).AddRow(90600))
+	mock.ExpectQuery(`select case when is_called then last_value end from public\.users_id_seq`).
+		WillReturnRows(sqlmock.NewRows([]string{"last_value"}).AddRow(7))
+
+	p := &PostgresDriver{dbConn: db}
+	if v, err := p.SequenceLastValue("users_id_seq"); err != nil || v == nil || *v != 42 {
+		t.Errorf("want 42, got %v: %v", v, err)
+	}
+	if v, err := p.SequenceLastValue("unused_seq"); err != nil || v != nil {
+		t.Errorf("want no value, got %v: %v", v, err)
+	}
+	if v, err := p.SequenceLastValue("public.users_id_seq"); err != nil || v == nil || *v != 7 {
+		t.Errorf("want 7, got %v: %v", v, err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestPostgresSequenceInfo(t *testing.T) {
+	t.Parallel()
+
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	columns := []string{"sequence_schema", "sequence_name", "data_type", "start_value", "increment", "minimum_value", "maximum_value", "cycle", "cache_size", "owned_by"}
+	mock.ExpectQuery(`show server_version_num`).
+		WillReturnRows(sqlmock.NewRows([]string{"server_version_num"}).AddRow(120000))
+	mock.ExpectQuery(`(?s)from pg_sequence pgs.*from information_schema.sequences s`).
+		WithArgs("users_id_seq").
+		WillReturnRows(sqlmock.NewRows(columns).
+			AddRow("public", "users_id_seq", "integer", 1, 1, 1, 2147483647, false, 20, "users.id"))
+	mock.ExpectQuery(`show server_version_num`).
+		WillReturnRows(sqlmock.NewRows([]string{"server_version_num"}).AddRow(90600))
+	mock.ExpectQuery(`(?s)0 as cache_size.*from information_schema.sequences s`).
+		WithArgs("ticket_seq").
+		WillReturnRows(sqlmock.NewRows(columns).
+			AddRow("public", "ticket_seq", "bigint", 1, 1, 1, 999, true, 0, ""))
+	mock.ExpectQuery(`show server_version_num`).
+		WillReturnRows(sqlmock.NewRows([]string{"server_version_num"}).AddRow(120000))
+	mock.ExpectQuery(`(?s)from information_schema.sequences s`).
+		WithArgs("secret_seq").
+		WillReturnRows(sqlmock.NewRows(columns))
+
+	p := &PostgresDriver{dbConn: db}
+	seq, err := p.SequenceInfo("users_id_seq")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := bdb.Sequence{Schema: "public", Name: "users_id_seq", DataType: "integer", Start: 1, Increment: 1, Min: 1, Max: 2147483647, CacheSize: 20, OwnedBy: "users.id"}
+	if *seq != want {
+		t.Errorf("want %#v, got %#v", want, *seq)
+	}
+
+	if seq, err = p.SequenceInfo("ticket_seq"); err != nil {
+		t.Fatal(err)
+	}
+	if !seq.Cycle || seq.CacheSize != 0 || seq.OwnedBy != "" {
+		t.Errorf("ticket_seq should cycle alone: %#v", seq)
+	}
+
+	if seq, err = p.SequenceInfo("secret_seq"); err != nil {
+		t.Fatal(err)
+	}
+	if seq != nil {
+		t.Errorf("a sequence without privileges should have no info: %#v", seq)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestPostgresForeignKeyInfoMatchType(t *testing.T) {
+	t.Parallel()
+
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	mock.ExpectQuery(`from pg_namespace pgn`).
+		WithArgs("shipments", "public").
+		WillReturnRows(sqlmock.NewRows([]string{"conname", "source_table", "source_column", "dest_table", "dest_column", "match_type", "constraint_comment", "not_valid"}).
+			AddRow("shipments_order_fkey", "shipments", "order_id", "orders", "id", "FULL", "The order being shipped", false).
+			AddRow("shipments_order_fkey", "shipments", "order_line", "orders", "line", "FULL", "The order being shipped", false).
+			AddRow("shipments_user_fkey", "shipments", "user_id", "users", "id", "SIMPLE", "", true))
+
+	p := &PostgresDriver{dbConn: db}
+	fkeys, err := p.ForeignKeyInfo("public", "shipments")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []string{bdb.MatchFull, bdb.MatchFull, bdb.MatchSimple}
+	if len(fkeys) != len(want) {
+		t.Fatalf("want %d foreign keys, got: %#v", len(want), fkeys)
+	}
+	for i, w := range want {
+		if fkeys[i].MatchType != w {
+			t.Errorf("%d) want match type %s, got %s", i, w, fkeys[i].MatchType)
+		}
+	}
+	if fkeys[0].Comment != "The order being shipped" || fkeys[2].Comment != "" {
+		t.Errorf("comments were wrong: %#v", fkeys)
+	}
+	if fkeys[0].NotValid || !fkeys[2].NotValid {
+		t.Errorf("shipments_user_fkey should be the only one NOT VALID: %#v", fkeys)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestPostgresIndexInfoComment(t *testing.T) {
+	t.Parallel()
+
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	mock.ExpectQuery(`show server_version_num`).
+		WillReturnRows(sqlmock.NewRows([]string{"server_version_num"}).AddRow(130000))
+	mock.ExpectQuery(`from pg_index pgi`).
+		WithArgs("public", "shipments").
+		WillReturnRows(sqlmock.NewRows([]string{"index_name", "indisunique", "attname", "expression", "is_included", "is_descending", "index_comment", "index_predicate"}).
+			AddRow("shipments_sent_idx", false, "sent_at", "", false, true, "For the tracking page", "").
+			AddRow("shipments_sent_idx", false, "id", "", true, false, "For the tracking page", "").
+			AddRow("shipments_user_idx", false, "user_id", "", false, false, "", ""))
+
+	p := &PostgresDriver{dbConn: db}
+	indexes, err := p.IndexInfo("public", "shipments")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(indexes) != 2 {
+		t.Fatalf("want 2 indexes, got: %#v", indexes)
+	}
+	if indexes[0].Comment != "For the tracking page" || indexes[1].Comment != "" {
+		t.Errorf("comments were wrong: %#v", indexes)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestPostgresIndexInfoPartial(t *testing.T) {
+	t.Parallel()
+
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	mock.ExpectQuery(`show server_version_num`).
+		WillReturnRows(sqlmock.NewRows([]string{"server_version_num"}).AddRow(130000))
+	mock.ExpectQuery(`(?s)pg_get_expr\(pgi.indpred, pgi.indrelid\).*from pg_index pgi`).
+		WithArgs("public", "users").
+		WillReturnRows(sqlmock.NewRows([]string{"index_name", "indisunique", "attname", "expression", "is_included", "is_descending", "index_comment", "index_predicate"}).
+			AddRow("users_email_key", true, "email", "", false, false, "", "(deleted_at IS NULL)").
+			AddRow("users_pkey", true, "id", "", false, false, "", ""))
+
+	p := &PostgresDriver{dbConn: db}
+	indexes, err := p.IndexInfo("public", "users")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(indexes) != 2 {
+		t.Fatalf("want 2 indexes, got: %#v", indexes)
+	}
+	if !indexes[0].Unique || indexes[0].Predicate != "(deleted_at IS NULL)" {
+		t.Errorf("email should be a partial unique index: %#v", indexes[0])
+	}
+	if !indexes[1].Unique || indexes[1].Predicate != "" {
+		t.Errorf("the primary key should cover every row: %#v", indexes[1])
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestPostgresIndexInfoExpression(t *testing.T) {
+	t.Parallel()
+
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	mock.ExpectQuery(`show server_version_num`).
+		WillReturnRows(sqlmock.NewRows([]string{"server_version_num"}).AddRow(130000))
+	// Expression keys have no attribute, the left join keeps them
+	mock.ExpectQuery(`(?s)pg_get_indexdef\(pgi.indexrelid, k.n::int, true\).*left join pg_attribute pga`).
+		WithArgs("public", "users").
+		WillReturnRows(sqlmock.NewRows([]string{"index_name", "indisunique", "attname", "expression", "is_included", "is_descending", "index_comment", "index_predicate"}).
+			AddRow("users_lower_name_idx", false, "", "lower(name)", false, false, "", "").
+			AddRow("users_tenant_email_key", true, "tenant_id", "", false, false, "", "").
+			AddRow("users_tenant_email_key", true, "", "lower(email)", false, false, "", ""))
+
+	p := &PostgresDriver{dbConn: db}
+	indexes, err := p.IndexInfo("public", "users")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(indexes) != 2 {
+		t.Fatalf("want 2 indexes, got: %#v", indexes)
+	}
+	if c := indexes[0].Columns; len(c) != 1 || c[0].Name != "" || c[0].Expression != "lower(name)" {
+		t.Errorf("the expression index should keep its expression: %#v", indexes[0])
+	}
+	want := []bdb.IndexColumn{{Name: "tenant_id"}, {Expression: "lower(email)"}}
+	if !reflect.DeepEqual(indexes[1].Columns, want) || !indexes[1].HasExpression() {
+		t.Errorf("the unique index should have both keys: %#v", indexes[1])
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestPostgresForeignKeyInfoSameTable(t *testing.T) {
+	t.Parallel()
+
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	mock.ExpectQuery(`from pg_namespace pgn`).
+		WithArgs("messages", "public").
+		WillReturnRows(sqlmock.NewRows([]string{"conname", "source_table", "source_column", "dest_table", "dest_column", "match_type", "constraint_comment", "not_valid"}).
+			AddRow("messages_recipient_fkey", "messages", "recipient_id", "users", "id", "SIMPLE", "", false).
+			AddRow("messages_sender_fkey", "messages", "sender_id", "users", "id", "SIMPLE", "", false))
+
+	p := &PostgresDriver{dbConn: db}
+	fkeys, err := p.ForeignKeyInfo("public", "messages")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(fkeys) != 2 {
+		t.Fatalf("want both keys to users, got: %#v", fkeys)
+	}
+	if fkeys[0].Column != "recipient_id" || fkeys[1].Column != "sender_id" {
+		t.Errorf("the keys should keep their columns: %#v", fkeys)
+	}
+	names := bdb.RelationshipNames(fkeys)
+	if names["messages_recipient_fkey"] != "recipient" || names["messages_sender_fkey"] != "sender" {
+		t.Errorf("relationship names were wrong: %v", names)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestPostgresCompositeFields(t *testing.T) {
+	t.Parallel()
+
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	mock.ExpectQuery(`from information_schema.attributes`).
+		WithArgs("public", "address").
+		WillReturnRows(sqlmock.NewRows([]string{"attribute_name", "data_type", "attribute_udt_name", "array_type", "is_nullable"}).
+			AddRow("street", "text", "text", nil, true).
+			AddRow("number", "integer", "int4", nil, false).
+			AddRow("tags", "ARRAY", "_text", "text", true))
+
+	p := &PostgresDriver{dbConn: db}
+	fields, err := p.CompositeFields("public", "address")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []struct {
+		Name string
+		Type string
+	}{
+		{"street", "null.String"},
+		{"number", "int"},
+		{"tags", "types.StringArray"},
+	}
+	if len(fields) != len(want) {
+		t.Fatalf("want %d fields, got: %#v", len(want), fields)
+	}
+	for i, w := range want {
+		if fields[i].Name != w.Name || fields[i].Type != w.Type {
+			t.Errorf("%d) want %s %s, got: %s %s", i, w.Name, w.Type, fields[i].Name, fields[i].Type)
+		}
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestPostgresCheckInfo(t *testing.T) {
+	t.Parallel()
+
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	mock.ExpectQuery(`from pg_constraint pgcon`).
+		WithArgs("public", "products").
+		WillReturnRows(sqlmock.NewRows([]string{"conname", "def", "not_valid", "attname"}).
+			AddRow("discount_less", "CHECK (discount < price)", false, "price").
+			AddRow("discount_less", "CHECK (discount < price)", false, "discount").
+			AddRow("price_positive", "CHECK (price > 0::numeric) NOT VALID", true, "price"))
+
+	p := &PostgresDriver{dbConn: db}
+	checks, err := p.CheckInfo("public", "products")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(checks) != 2 {
+		t.Fatalf("want 2 checks, got: %#v", checks)
+	}
+	if c := checks[0]; c.Name != "discount_less" || c.Expression != "(discount < price)" || len(c.Columns) != 2 || c.NotValid {
+		t.Errorf("first check was wrong: %#v", c)
+	}
+	if c := checks[1]; c.Expression != "(price > 0::numeric)" || len(c.Columns) != 1 || c.Columns[0] != "price" || !c.NotValid {
+		t.Errorf("second check was wrong: %#v", c)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestPostgresTableDetailsView(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	// Views are read without extended metadata
+	mock.ExpectQuery(`select pgc.oid, pgc.relpersistence, pgc.relkind`).
+		WithArgs("public", "monthly_sales").
+		WillReturnRows(sqlmock.NewRows([]string{"oid", "relpersistence", "relkind", "pg_total_relation_size"}).AddRow(16390, "p", "m", 16384))
+	mock.ExpectQuery(`select pg_get_viewdef`).
+		WithArgs("public", "monthly_sales").
+		WillReturnRows(sqlmock.NewRows([]string{"pg_get_viewdef", "is_updatable"}).AddRow(" SELECT sum(total) AS total FROM sales;", false))
+
+	p := &PostgresDriver{dbConn: db}
+	table := &bdb.Table{Name: "monthly_sales"}
+	if err := p.TableDetails("public", table); err != nil {
+		t.Fatal(err)
+	}
+
+	if !table.IsView || !table.IsMaterialized || table.IsUpdatable {
+		t.Errorf("want a read only materialized view: %#v", table)
+	}
+	if table.ViewDefinition != " SELECT sum(total) AS total FROM sales;" {
+		t.Errorf("view definition was wrong: %q", table.ViewDefinition)
+	}
+	if table.OID != 0 || table.TotalSizeBytes != 0 {
+		t.Errorf("want no extended metadata: %#v", table)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestPostgresTableDetailsUpdatableView(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	mock.ExpectQuery(`select pgc.oid, pgc.relpersistence, pgc.relkind`).
+		WithArgs("public", "active_users").
+		WillReturnRows(sqlmock.NewRows([]string{"oid", "relpersistence", "relkind", "pg_total_relation_size"}).AddRow(16395, "p", "v", 0))
+	mock.ExpectQuery(`(?s)select pg_get_viewdef.*information_schema.views`).
+		WithArgs("public", "active_users").
+		WillReturnRows(sqlmock.NewRows([]string{"pg_get_viewdef", "is_updatable"}).AddRow(" SELECT users.id FROM users WHERE users.active;", true))
+
+	p := &PostgresDriver{dbConn: db}
+	table := &bdb.Table{Name: "active_users", IsUpdatable: true}
+	if err := p.TableDetails("public", table); err != nil {
+		t.Fatal(err)
+	}
+	if !table.IsView || !table.IsUpdatable {
+		t.Errorf("want an updatable view: %#v", table)
+	}
+
+	mock.ExpectQuery(`select pgc.oid, pgc.relpersistence, pgc.relkind`).
+		WithArgs("public", "user_counts").
+		WillReturnRows(sqlmock.NewRows([]string{"oid", "relpersistence", "relkind", "pg_total_relation_size"}).AddRow(16396, "p", "v", 0))
+	mock.ExpectQuery(`(?s)select pg_get_viewdef.*information_schema.views`).
+		WithArgs("public", "user_counts").
+		WillReturnRows(sqlmock.NewRows([]string{"pg_get_viewdef", "is_updatable"}).AddRow(" SELECT count(*) AS count FROM users;", false))
+
+	table = &bdb.Table{Name: "user_counts", IsUpdatable: true}
+	if err := p.TableDetails("public", table); err != nil {
+		t.Fatal(err)
+	}
+	if !table.IsView || table.IsUpdatable {
+		t.Errorf("want a read only view: %#v", table)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestPostgresDatabaseInfo(t *testing.T) {
+	t.Parallel()
+
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	mock.ExpectQuery(`(?s)pg_encoding_to_char\(pgd.encoding\), pgd.datcollate.*where pgd.datname = current_database\(\)`).
+		WillReturnRows(sqlmock.NewRows([]string{"pg_encoding_to_char", "datcollate", "current_setting"}).
+			AddRow("UTF8", "en_US.UTF-8", "14.5"))
+
+	p := &PostgresDriver{dbConn: db}
+	info, err := p.DatabaseInfo()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := bdb.DatabaseInfo{Encoding: "UTF8", Collation: "en_US.UTF-8", ServerVersion: "14.5"}
+	if *info != want {
+		t.Errorf("want %#v, got %#v", want, *info)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestPostgresTableDetailsDblinkView(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	def := " SELECT u.id, u.name FROM dblink('legacy'::text, 'select id, name from users'::text) u(id integer, name text);"
+	mock.ExpectQuery(`select pgc.oid, pgc.relpersistence, pgc.relkind`).
+		WithArgs("public", "legacy_users").
+		WillReturnRows(sqlmock.NewRows([]string{"oid", "relpersistence", "relkind", "pg_total_relation_size"}).AddRow(16420, "p", "v", 0))
+	mock.ExpectQuery(`select pg_get_viewdef`).
+		WithArgs("public", "legacy_users").
+		WillReturnRows(sqlmock.NewRows([]string{"pg_get_viewdef", "is_updatable"}).AddRow(def, false))
+
+	p := &PostgresDriver{dbConn: db}
+	table := &bdb.Table{Name: "legacy_users"}
+	if err := p.TableDetails("public", table); err != nil {
+		t.Fatal(err)
+	}
+
+	if !table.IsView || !table.IsRemote {
+		t.Errorf("want a remote view: %#v", table)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+
+	tests := []struct {
+		Def  string
+		Want bool
+	}{
+		{" SELECT t.a FROM public.dblink('x', 'select 1') t(a integer);", true},
+		{" SELECT t.a FROM DBLINK ('x', 'select 1') t(a integer);", true},
+		{" SELECT my_dblink(a) AS a FROM t;", false},
+		{" SELECT dblink_servers.name FROM dblink_servers;", false},
+	}
+	for i, test := range tests {
+		if got := rgxDblinkCall.MatchString(test.Def); got != test.Want {
+			t.Errorf("%d) want %t, got %t", i, test.Want, got)
+		}
+	}
+}
+
+func TestPostgresExclusionInfo(t *testing.T) {
+	t.Parallel()
+
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	def := "EXCLUDE USING gist (room_id WITH =, during WITH &&)"
+	mock.ExpectQuery(`where pgcon.contype = 'x'`).
+		WithArgs("public", "bookings").
+		WillReturnRows(sqlmock.NewRows([]string{"conname", "def", "amname", "attname", "oprname", "opcname"}).
+			AddRow("no_double_booking", def, "gist", "room_id", "=", "gist_int4_ops").
+			AddRow("no_double_booking", def, "gist", "during", "&&", "range_ops"))
+
+	p := &PostgresDriver{dbConn: db}
+	exclusions, err := p.ExclusionInfo("public", "bookings")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(exclusions) != 1 {
+		t.Fatalf("want 1 exclusion, got: %#v", exclusions)
+	}
+	e := exclusions[0]
+	if e.Name != "no_double_booking" || e.Method != "gist" || e.Definition != def {
+		t.Errorf("exclusion was wrong: %#v", e)
+	}
+	if len(e.Elements) != 2 || e.Elements[1].Column != "during" || e.Elements[1].Operator != "&&" {
+		t.Errorf("elements were wrong: %#v", e.Elements)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestPostgresCatalogSchema(t *testing.T) {
+	PostgresCatalogTables = []string{"pg_class", "information_schema.columns"}
+	defer func() { PostgresCatalogTables = nil }()
+
+	tests := []struct {
+		Table  string
+		Schema string
+		Name   string
+	}{
+		{"pg_catalog.pg_class", "pg_catalog", "pg_class"},
+		{"information_schema.columns", "information_schema", "columns"},
+		{"columns", "public", "columns"},
+		{"users", "public", "users"},
+	}
+
+	for i, test := range tests {
+		if schema, name := postgresCatalogSchema("public", test.Table); schema != test.Schema || name != test.Name {
+			t.Errorf("%d) %s: want %s.%s, got %s.%s", i, test.Table, test.Schema, test.Name, schema, name)
+		}
+	}
+}
+
+func TestPostgresTableNamesCatalog(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	PostgresCatalogTables = []string{"pg_class", "pg_attribute", "information_schema.columns"}
+	defer func() { PostgresCatalogTables = nil }()
+
+	exists := func(catalog, table string) {
+		mock.ExpectQuery(`select exists`).
+			WithArgs(catalog, table).
+			WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(true))
+	}
+
+	// A public table with the same name as a catalog table is kept apart
+	mock.ExpectQuery(`table_name not in \(\$2\)`).
+		WithArgs("public", "pg_attribute").
+		WillReturnRows(sqlmock.NewRows([]string{"table_name"}).AddRow("columns"))
+	exists("pg_catalog", "pg_class")
+	exists("information_schema", "columns")
+
+	mock.ExpectQuery(`table_name in \(\$2,\$3\)`).
+		WithArgs("public", "users", "pg_catalog.pg_attribute").
+		WillReturnRows(sqlmock.NewRows([]string{"table_name"}).AddRow("users"))
+	exists("pg_catalog", "pg_attribute")
+
+	p := &PostgresDriver{dbConn: db}
+	names, err := p.TableNames("public", nil, []string{"pg_attribute"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"columns", "pg_catalog.pg_class", "information_schema.columns"}; !reflect.DeepEqual(names, want) {
+		t.Errorf("want %v, got %v", want, names)
+	}
+
+	names, err = p.TableNames("public", []string{"users", "pg_catalog.pg_attribute"}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"users", "pg_catalog.pg_attribute"}; !reflect.DeepEqual(names, want) {
+		t.Errorf("want only the whitelisted tables %v, got %v", want, names)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestPostgresTypeMappings(t *testing.T) {
+	t.Parallel()
+
+	mappings := map[string]bdb.TypeMapping{}
+	for _, m := range (&PostgresDriver{}).TypeMappings() {
+		if len(m.Type) == 0 || len(m.NullableType) == 0 {
+			t.Errorf("%s was not translated: %#v", m.DBType, m)
+		}
+		mappings[m.DBType] = m
+	}
+
+	tests := []bdb.TypeMapping{
+		{DBType: "bigint", Type: "int64", NullableType: "null.Int64"},
+		{DBType: "jsonb", Type: "types.JSON", NullableType: "null.JSON"},
+		{DBType: "integer[]", Type: "types.Int64Array", NullableType: "types.Int64Array"},
+		{DBType: "numeric", Type: "types.Decimal", NullableType: "types.NullDecimal"},
+		{DBType: "numeric(10,2)", Type: "float64", NullableType: "null.Float64"},
+	}
+
+	for i, test := range tests {
+		if got := mappings[test.DBType]; got != test {
+			t.Errorf("%d) want %#v, got %#v", i, test, got)
+		}
+	}
+}
+
+func TestPostgresTriggerInfo(t *testing.T) {
+	t.Parallel()
+
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	mock.ExpectQuery(`from information_schema.triggers`).
+		WithArgs("public", "posts").
+		WillReturnRows(sqlmock.NewRows([]string{"trigger_name", "action_timing", "event_manipulation", "action_statement"}).
+			AddRow("audit_posts", "AFTER", "DELETE", "EXECUTE FUNCTION audit()").
+			AddRow("audit_posts", "AFTER", "UPDATE", "EXECUTE FUNCTION audit()").
+			AddRow("set_updated_at", "BEFORE", "UPDATE", "EXECUTE PROCEDURE set_updated_at()"))
+
+	p := &PostgresDriver{dbConn: db}
+	triggers, err := p.TriggerInfo("public", "posts")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []bdb.Trigger{
+		{Name: "audit_posts", Timing: "AFTER", Event: "DELETE OR UPDATE", Function: "audit()"},
+		{Name: "set_updated_at", Timing: "BEFORE", Event: "UPDATE", Function: "set_updated_at()"},
+	}
+	if len(triggers) != len(want) {
+		t.Fatalf("want %d triggers, got: %#v", len(want), triggers)
+	}
+	for i, w := range want {
+		if triggers[i] != w {
+			t.Errorf("%d) want %#v, got %#v", i, w, triggers[i])
+		}
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestPostgresPolicyInfo(t *testing.T) {
+	t.Parallel()
+
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	mock.ExpectQuery(`from pg_policies`).
+		WithArgs("public", "posts").
+		WillReturnRows(sqlmock.NewRows([]string{"policyname", "cmd", "permissive", "roles", "qual", "with_check"}).
+			AddRow("own_posts", "ALL", true, "author,editor", "(author_id = current_user_id())", "").
+			AddRow("published_only", "SELECT", false, "public", "published", ""))
+
+	p := &PostgresDriver{dbConn: db}
+	policies, err := p.PolicyInfo("public", "posts")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []bdb.Policy{
+		{Name: "own_posts", Command: "ALL", Permissive: true, Roles: []string{"author", "editor"}, Using: "(author_id = current_user_id())"},
+		{Name: "published_only", Command: "SELECT", Roles: []string{"public"}, Using: "published"},
+	}
+	if !reflect.DeepEqual(policies, want) {
+		t.Errorf("want %#v, got %#v", want, policies)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestPostgresTableDetailsPartitioned(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	ExtendedMetadata = true
+	defer func() { ExtendedMetadata = false }()
+
+	mock.ExpectQuery(`select pgc.oid, pgc.relpersistence, pgc.relkind`).
+		WithArgs("public", "measurements").
+		WillReturnRows(sqlmock.NewRows([]string{"oid", "relpersistence", "relkind", "pg_total_relation_size"}).AddRow(16401, "p", "p", 0))
+	mock.ExpectQuery(`from pg_partitioned_table pgpt`).
+		WithArgs("public", "measurements").
+		WillReturnRows(sqlmock.NewRows([]string{"attname"}).AddRow("city_id").AddRow("logdate"))
+	mock.ExpectQuery(`show server_version_num`).
+		WillReturnRows(sqlmock.NewRows([]string{"server_version_num"}).AddRow(140000))
+	mock.ExpectQuery(`from pg_attribute pga`).
+		WithArgs("public", "measurements").
+		WillReturnRows(sqlmock.NewRows([]string{"attname", "storage", "compression"}).
+			AddRow("city_id", "plain", "").
+			AddRow("readings", "extended", "lz4"))
+	mock.ExpectQuery(`from information_schema.role_table_grants`).
+		WithArgs("public", "measurements").
+		WillReturnRows(sqlmock.NewRows([]string{"grantee", "privilege_type", "is_grantable"}))
+
+	p := &PostgresDriver{dbConn: db}
+	table := &bdb.Table{
+		Name: "measurements",
+		Columns: []bdb.Column{
+			{Name: "city_id", DBName: "city_id"},
+			{Name: "readings", DBName: "readings"},
+		},
+	}
+	if err := p.TableDetails("public", table); err != nil {
+		t.Fatal(err)
+	}
+
+	if !table.IsPartitioned {
+		t.Errorf("want a partitioned table: %#v", table)
+	}
+	if len(table.PartitionKey) != 2 || table.PartitionKey[0] != "city_id" || table.PartitionKey[1] != "logdate" {
+		t.Errorf("partition key was wrong: %#v", table.PartitionKey)
+	}
+	if c := table.Columns[0]; c.Storage != "plain" || len(c.Compression) != 0 {
+		t.Errorf("city_id storage was wrong: %#v", c)
+	}
+	if c := table.Columns[1]; c.Storage != "extended" || c.Compression != "lz4" {
+		t.Errorf("readings storage was wrong: %#v", c)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestPostgresTableDetailsInherits(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	ExtendedMetadata = true
+	defer func() { ExtendedMetadata = false }()
+
+	mock.ExpectQuery(`select pgc.oid, pgc.relpersistence, pgc.relkind`).
+		WithArgs("public", "capitals").
+		WillReturnRows(sqlmock.NewRows([]string{"oid", "relpersistence", "relkind", "pg_total_relation_size"}).AddRow(16412, "p", "r", 8192000))
+	mock.ExpectQuery(`from pg_inherits pgi`).
+		WithArgs("public", "capitals").
+		WillReturnRows(sqlmock.NewRows([]string{"relname"}).AddRow("cities").AddRow("audit.tracked"))
+	mock.ExpectQuery(`show server_version_num`).
+		WillReturnRows(sqlmock.NewRows([]string{"server_version_num"}).AddRow(130000))
+	mock.ExpectQuery(`from pg_attribute pga`).
+		WithArgs("public", "capitals").
+		WillReturnRows(sqlmock.NewRows([]string{"attname", "storage"}))
+	mock.ExpectQuery(`from information_schema.role_table_grants`).
+		WithArgs("public", "capitals").
+		WillReturnRows(sqlmock.NewRows([]string{"grantee", "privilege_type", "is_grantable"}).
+			AddRow("app", "INSERT", false).
+			AddRow("app", "SELECT", true))
+
+	p := &PostgresDriver{dbConn: db}
+	table := &bdb.Table{Name: "capitals"}
+	if err := p.TableDetails("public", table); err != nil {
+		t.Fatal(err)
+	}
+
+	if want := []string{"cities", "audit.tracked"}; !reflect.DeepEqual(table.Inherits, want) {
+		t.Errorf("want parents %v, got %v", want, table.Inherits)
+	}
+	if table.OID != 16412 {
+		t.Errorf("want oid 16412, got %d", table.OID)
+	}
+	if table.TotalSizeBytes != 8192000 {
+		t.Errorf("want a size of 8192000 bytes, got %d", table.TotalSizeBytes)
+	}
+	wantGrants := []bdb.Grant{{Grantee: "app", Privilege: "INSERT"}, {Grantee: "app", Privilege: "SELECT", Grantable: true}}
+	if !reflect.DeepEqual(table.Grants, wantGrants) {
+		t.Errorf("want grants %v, got %v", wantGrants, table.Grants)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestPostgresColumnStorageDropped(t *testing.T) {
+	t.Parallel()
+
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	// total was the third column, the second was dropped and left a gap.
+	// The query filters out attisdropped rows, even if one was returned it
+	// has the name postgres gives dropped columns and matches none of them.
+	mock.ExpectQuery(`show server_version_num`).
+		WillReturnRows(sqlmock.NewRows([]string{"server_version_num"}).AddRow(140000))
+	mock.ExpectQuery(`pga.attnum > 0 and not pga.attisdropped`).
+		WithArgs("public", "orders").
+		WillReturnRows(sqlmock.NewRows([]string{"attname", "storage", "compression"}).
+			AddRow("id", "plain", "").
+			AddRow("........pg.dropped.2........", "extended", "").
+			AddRow("total", "main", "lz4"))
+
+	p := &PostgresDriver{dbConn: db}
+	table := &bdb.Table{Name: "orders", Columns: []bdb.Column{{Name: "id", DBName: "id"}, {Name: "total", DBName: "total"}}}
+	if err := p.columnStorage("public", "orders", table); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(table.Columns) != 2 {
+		t.Fatalf("the dropped column shouldn't be added, got: %#v", table.Columns)
+	}
+	if c := table.Columns[0]; c.Storage != "plain" || c.Compression != "" {
+		t.Errorf("id was wrong: %#v", c)
+	}
+	if c := table.Columns[1]; c.Storage != "main" || c.Compression != "lz4" {
+		t.Errorf("total was wrong: %#v", c)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestPostgresTranslateTextRepresentation(t *testing.T) {
+	t.Parallel()
+
+	p := &PostgresDriver{}
+	for _, dbType := range []string{"pg_lsn", "txid_snapshot", "point", "tstzrange"} {
+		c := p.TranslateColumnType(bdb.Column{DBType: dbType})
+		if c.Type != "string" || c.DBType != dbType || !c.TextRepresentation {
+			t.Errorf("%s was wrong: %#v", dbType, c)
+		}
+
+		c = p.TranslateColumnType(bdb.Column{DBType: dbType, Nullable: true})
+		if c.Type != "null.String" || !c.TextRepresentation {
+			t.Errorf("nullable %s was wrong: %#v", dbType, c)
+		}
+	}
+
+	if c := p.TranslateColumnType(bdb.Column{DBType: "text"}); c.TextRepresentation {
+		t.Errorf("text should not be flagged: %#v", c)
+	}
+}
+
+func TestPostgresTranslateNullableCombinations(t *testing.T) {
+	t.Parallel()
+
+	text, moods := "text", "enum.mood('sad','ok')"
+	tests := []struct {
+		Column  bdb.Column
+		Type    string
+		DBType  string
+		Unknown bool
+	}{
+		{bdb.Column{DBType: "ARRAY", ArrType: &text}, "types.StringArray", "ARRAYtext", false},
+		{bdb.Column{DBType: "ARRAY", ArrType: &text, Nullable: true}, "types.StringArray", "ARRAYtext", false},
+		{bdb.Column{DBType: "enum.mood('sad','ok')"}, "string", "enum.mood('sad','ok')", false},
+		{bdb.Column{DBType: "enum.mood('sad','ok')", Nullable: true}, "null.String", "enum.mood('sad','ok')", false},
+		{bdb.Column{DBType: "ARRAY", ArrType: &moods}, "types.StringArray", "ARRAYenum.mood('sad','ok')", false},
+		{bdb.Column{DBType: "ARRAY", ArrType: &moods, Nullable: true}, "types.StringArray", "ARRAYenum.mood('sad','ok')", false},
+		{bdb.Column{DBType: "numeric", Nullable: true}, "types.NullDecimal", "numeric", false},
+		{bdb.Column{DBType: "USER-DEFINED", UDTName: "hstore", Nullable: true}, "types.HStore", "hstore", false},
+		{bdb.Column{DBType: "USER-DEFINED", UDTName: "citext"}, "string", "USER-DEFINED", true},
+		{bdb.Column{DBType: "USER-DEFINED", UDTName: "citext", Nullable: true}, "null.String", "USER-DEFINED", true},
+	}
+
+	p := &PostgresDriver{}
+	for i, test := range tests {
+		c := p.TranslateColumnType(test.Column)
+		if c.Type != test.Type || c.DBType != test.DBType || c.UnknownType != test.Unknown {
+			t.Errorf("%d) want %s (%s, unknown %t), got %s (%s, unknown %t)", i, test.Type, test.DBType, test.Unknown, c.Type, c.DBType, c.UnknownType)
+		}
+	}
+}
+
+func TestPostgresTranslateCatalogTypes(t *testing.T) {
+	t.Parallel()
+
+	p := &PostgresDriver{}
+	tests := []struct {
+		DBType   string
+		Nullable bool
+		Want     string
+	}{
+		{`"char"`, false, "types.Byte"},
+		{`"char"`, true, "null.Byte"},
+		{"name", false, "string"},
+		{"name", true, "null.String"},
+		{"oid", false, "uint32"},
+		{"oid", true, "null.Uint32"},
+		{"regclass", false, "string"},
+		{"regproc", true, "null.String"},
+		{"regtype", false, "string"},
+	}
+
+	for i, test := range tests {
+		c := p.TranslateColumnType(bdb.Column{DBType: test.DBType, Nullable: test.Nullable})
+		if c.Type != test.Want || c.DBType != test.DBType || c.UnknownType {
+			t.Errorf("%d) %s want %s, got: %#v", i, test.DBType, test.Want, c)
+		}
+	}
+}
+
+func TestNewPostgresDriverFromEnv(t *testing.T) {
+	old, ok := os.LookupEnv("PGDATABASE")
+	defer func() {
+		if ok {
+			os.Setenv("PGDATABASE", old)
+		} else {
+			os.Unsetenv("PGDATABASE")
+		}
+	}()
+
+	os.Unsetenv("PGDATABASE")
+	if _, err := NewPostgresDriverFromEnv(); err == nil {
+		t.Error("expected an error without PGDATABASE")
+	}
+
+	os.Setenv("PGDATABASE", "sqlboiler")
+	p, err := NewPostgresDriverFromEnv()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(p.connStr) != 0 {
+		t.Errorf("connection string should be left to the environment: %q", p.connStr)
+	}
+}
+
+func TestPostgresWithSavepoint(t *testing.T) {
+	t.Parallel()
+
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	mock.ExpectBegin()
+	mock.ExpectExec(`^savepoint sqlboiler_table$`).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(`^rollback to savepoint sqlboiler_table$`).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(`^savepoint sqlboiler_table$`).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(`^release savepoint sqlboiler_table$`).WillReturnResult(sqlmock.NewResult(0, 0))
+
+	p := &PostgresDriver{dbConn: db}
+	if p.tx, err = db.Begin(); err != nil {
+		t.Fatal(err)
+	}
+
+	failure := errors.New("permission denied for table pilots")
+	if err = p.WithSavepoint(func() error { return failure }); err != failure {
+		t.Errorf("want the error of the table, got: %v", err)
+	}
+	if err = p.WithSavepoint(func() error { return nil }); err != nil {
+		t.Error(err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestPostgresTablesMatview(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	ExtendedMetadata = true
+	defer func() { ExtendedMetadata = false }()
+
+	mock.ExpectQuery(`(?s)union all\s+select schemaname, matviewname from pg_matviews\s+\) as relations where table_schema = \$1`).
+		WithArgs("public").
+		WillReturnRows(sqlmock.NewRows([]string{"table_name"}).AddRow("monthly_sales"))
+	mock.ExpectQuery(`from information_schema.columns`).
+		WithArgs("public", "monthly_sales").
+		WillReturnRows(sqlmock.NewRows([]string{"column_name"}))
+	columns := []string{"column_name", "column_type", "udt_name", "udt_kind", "array_type", "column_default", "identity_generation",
+		"identity_sequence", "generation_expression", "numeric_precision", "numeric_scale", "column_comment", "is_nullable", "is_unique"}
+	// The catalogs keep dropped columns, they're left out like information_schema does
+	// format_type with a typmod of -1 names char(n) character, like information_schema
+	mock.ExpectQuery(`(?s)format_type\(t.oid, -1\).*pgc.relkind = 'm'.*a.attnum > 0 and not a.attisdropped`).
+		WithArgs("public", "monthly_sales").
+		WillReturnRows(sqlmock.NewRows(columns).
+			AddRow("month", "date", "date", "", nil, nil, "", "", "", 0, 0, "", true, true).
+			AddRow("total", "numeric", "numeric", "", nil, nil, "", "", "", 12, 2, "", true, false).
+			AddRow("region", "character", "bpchar", "", nil, nil, "", "", "", 0, 0, "", true, false))
+	mock.ExpectQuery(`tc.constraint_type = 'PRIMARY KEY'`).
+		WillReturnRows(sqlmock.NewRows([]string{"constraint_name", "constraint_comment"}))
+	mock.ExpectQuery(`pgcon.contype = 'c'`).
+		WillReturnRows(sqlmock.NewRows([]string{"conname", "pg_get_constraintdef", "not_valid", "attname"}))
+	mock.ExpectQuery(`as source_table`).
+		WillReturnRows(sqlmock.NewRows([]string{"conname"}))
+	mock.ExpectQuery(`server_version_num`).
+		WillReturnRows(sqlmock.NewRows([]string{"server_version_num"}).AddRow(120000))
+	mock.ExpectQuery(`from pg_index`).
+		WillReturnRows(sqlmock.NewRows([]string{"indexname"}))
+	mock.ExpectQuery(`pgam.amname`).
+		WillReturnRows(sqlmock.NewRows([]string{"conname"}))
+	mock.ExpectQuery(`select pgc.oid, pgc.relpersistence, pgc.relkind`).
+		WithArgs("public", "monthly_sales").
+		WillReturnRows(sqlmock.NewRows([]string{"oid", "relpersistence", "relkind", "size"}).AddRow(16384, "p", "m", 8192))
+	mock.ExpectQuery(`select pg_get_viewdef`).
+		WithArgs("public", "monthly_sales").
+		WillReturnRows(sqlmock.NewRows([]string{"pg_get_viewdef", "is_updatable"}).AddRow(" SELECT 1;", false))
+	mock.ExpectQuery(`from information_schema.role_table_grants`).
+		WillReturnRows(sqlmock.NewRows([]string{"grantee", "privilege_type", "is_grantable"}))
+
+	tables, err := bdb.Tables(&PostgresDriver{dbConn: db}, "public", nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(tables) != 1 {
+		t.Fatalf("want the materialized view, got: %#v", tables)
+	}
+	if len(tables[0].Columns) != 3 || tables[0].Columns[0].Type != "null.Time" || tables[0].Columns[1].Type != "null.Float64" ||
+		tables[0].Columns[2].Type != "null.String" || tables[0].Columns[2].UnknownType {
+		t.Errorf("the columns of the materialized view were wrong: %#v", tables[0].Columns)
+	}
+	if !tables[0].IsView || !tables[0].IsMaterialized {
+		t.Errorf("want a materialized view: %#v", tables[0])
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
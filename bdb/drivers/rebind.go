@@ -0,0 +1,78 @@
+package drivers
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/volatiletech/sqlboiler/strmangle"
+)
+
+// placeholderStyle is how a database/sql driver numbers query placeholders,
+// the introspection queries shared by drivers are written with ? and
+// rebound to the driver's style. The ? drivers (mysql, mssql, snowflake,
+// vertica, clickhouse and spanner) use them as they are. The postgres
+// queries are left numbered since they use an argument more than once, eg: $1
+// for the schema in several subqueries, which ? can't express.
+type placeholderStyle int
+
+const (
+	placeholderQuestion placeholderStyle = iota // ? (mysql, clickhouse)
+	placeholderDollar                           // $1 (postgres)
+	placeholderColon                            // :1 (oracle)
+	placeholderAt                               // @p1 (mssql)
+)
+
+// placeholder returns the n-th placeholder in the style, from 1.
+func (s placeholderStyle) placeholder(n int) string {
+	switch s {
+	case placeholderDollar:
+		return "$" + strconv.Itoa(n)
+	case placeholderColon:
+		return ":" + strconv.Itoa(n)
+	case placeholderAt:
+		return "@p" + strconv.Itoa(n)
+	default:
+		return "?"
+	}
+}
+
+// rebind replaces the ? placeholders of query with the style's, numbered
+// from 1. Question marks in quoted strings and identifiers are left alone.
+func rebind(style placeholderStyle, query string) string {
+	if style == placeholderQuestion {
+		return query
+	}
+
+	buf := strmangle.GetBuffer()
+	defer strmangle.PutBuffer(buf)
+
+	var quote byte
+	n := 0
+	for i := 0; i < len(query); i++ {
+		c := query[i]
+		switch {
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			}
+		case c == '\'' || c == '"' || c == '`':
+			quote = c
+		case c == '?':
+			n++
+			buf.WriteString(style.placeholder(n))
+			continue
+		}
+		buf.WriteByte(c)
+	}
+
+	return buf.String()
+}
+
+// questionMarks returns n ? placeholders joined with commas, for an in list.
+func questionMarks(n int) string {
+	if n == 0 {
+		return ""
+	}
+
+	return strings.Repeat("?, ", n-1) + "?"
+}
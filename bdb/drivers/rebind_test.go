@@ -0,0 +1,35 @@
+package drivers
+
+import "testing"
+
+func TestRebind(t *testing.T) {
+	t.Parallel()
+
+	query := `select a from b where c = ? and d = '?' and "e?" in (?, ?)`
+	tests := []struct {
+		Style placeholderStyle
+		Want  string
+	}{
+		{placeholderQuestion, query},
+		{placeholderDollar, `select a from b where c = $1 and d = '?' and "e?" in ($2, $3)`},
+		{placeholderColon, `select a from b where c = :1 and d = '?' and "e?" in (:2, :3)`},
+		{placeholderAt, `select a from b where c = @p1 and d = '?' and "e?" in (@p2, @p3)`},
+	}
+
+	for i, test := range tests {
+		if got := rebind(test.Style, query); got != test.Want {
+			t.Errorf("%d) want %s, got %s", i, test.Want, got)
+		}
+	}
+}
+
+func TestQuestionMarks(t *testing.T) {
+	t.Parallel()
+
+	if got := questionMarks(0); got != "" {
+		t.Errorf("want nothing, got %s", got)
+	}
+	if got := questionMarks(3); got != "?, ?, ?" {
+		t.Errorf("want 3 placeholders, got %s", got)
+	}
+}
@@ -0,0 +1,110 @@
+package drivers
+
+import (
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+// redactedPassword replaces passwords in redacted connection strings, it's
+// what net/url uses as well.
+const redactedPassword = "xxxxx"
+
+// rgxKeyValuePassword matches the password of a key=value connection string
+var rgxKeyValuePassword = regexp.MustCompile(`(password=)('(?:[^'\\]|\\.)*'|\S*)`)
+
+// redactKeyValue masks the password of a key=value connection string, eg:
+// user=bob password=secret becomes user=bob password=xxxxx
+func redactKeyValue(connStr string) string {
+	return rgxKeyValuePassword.ReplaceAllString(connStr, "${1}"+redactedPassword)
+}
+
+// redactURL masks the password of a url connection string, both the one in
+// the user info and one given as a password parameter.
+func redactURL(connStr string) string {
+	u, err := url.Parse(connStr)
+	if err != nil {
+		// Without the url we can't tell where the password is
+		return redactedPassword
+	}
+
+	if _, ok := u.User.Password(); ok {
+		u.User = url.UserPassword(u.User.Username(), redactedPassword)
+	}
+
+	query := u.Query()
+	if len(query.Get("password")) != 0 {
+		query.Set("password", redactedPassword)
+		u.RawQuery = query.Encode()
+	}
+
+	return u.String()
+}
+
+// redactMySQL masks the password of a mysql data source name of the form:
+// user:password@tcp(host:port)/dbname?params
+//
+// It's split the way mysql.ParseDSN does: the password may contain a ? or an
+// @, so the last / starts the dbname and the last @ before it ends the
+// password. The params are escaped and have neither.
+func redactMySQL(dsn string) string {
+	slash := strings.LastIndexByte(dsn, '/')
+	if slash < 0 {
+		return dsn
+	}
+
+	at := strings.LastIndexByte(dsn[:slash], '@')
+	if at < 0 {
+		return dsn
+	}
+
+	colon := strings.IndexByte(dsn[:at], ':')
+	if colon < 0 {
+		return dsn
+	}
+
+	return dsn[:colon+1] + redactedPassword + dsn[at:]
+}
+
+// String returns the connection string with the password masked, it's
+// safe to log.
+func (p *PostgresDriver) String() string {
+	return redactKeyValue(p.connStr)
+}
+
+// String returns the connection string with the password masked, it's
+// safe to log.
+func (m *MySQLDriver) String() string {
+	return redactMySQL(m.connStr)
+}
+
+// String returns the connection string with the password masked, it's
+// safe to log.
+func (m *MSSQLDriver) String() string {
+	return redactURL(m.connStr)
+}
+
+// String returns the connection string, spanner authenticates with
+// credentials from the environment so it has no password to mask.
+func (s *SpannerDriver) String() string {
+	return s.connStr
+}
+
+// String returns the connection string with the password masked, it's
+// safe to log.
+func (c *ClickHouseDriver) String() string {
+	return redactURL(c.connStr)
+}
+
+// String returns the connection string with the password masked, it's
+// safe to log.
+func (v *VerticaDriver) String() string {
+	return redactURL(v.connStr)
+}
+
+// String returns the data source name with the password masked, it's safe
+// to log.
+func (s *SnowflakeDriver) String() string {
+	// The data source name is a url without its scheme and leading //
+	return strings.TrimPrefix(redactURL("//"+s.connStr), "//")
+}
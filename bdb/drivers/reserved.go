@@ -0,0 +1,127 @@
+package drivers
+
+import "strings"
+
+// postgresReservedWords are the key words marked reserved (and reserved
+// "can be function or type") in the postgres documentation:
+// https://www.postgresql.org/docs/current/static/sql-keywords-appendix.html
+var postgresReservedWords = map[string]struct{}{
+	"all": {}, "analyse": {}, "analyze": {}, "and": {}, "any": {}, "array": {}, "as": {}, "asc": {},
+	"asymmetric": {}, "authorization": {}, "binary": {}, "both": {}, "case": {}, "cast": {}, "check": {},
+	"collate": {}, "collation": {}, "column": {}, "concurrently": {}, "constraint": {}, "create": {},
+	"cross": {}, "current_catalog": {}, "current_date": {}, "current_role": {}, "current_schema": {},
+	"current_time": {}, "current_timestamp": {}, "current_user": {}, "default": {}, "deferrable": {},
+	"desc": {}, "distinct": {}, "do": {}, "else": {}, "end": {}, "except": {}, "false": {}, "fetch": {},
+	"for": {}, "foreign": {}, "freeze": {}, "from": {}, "full": {}, "grant": {}, "group": {}, "having": {},
+	"ilike": {}, "in": {}, "initially": {}, "inner": {}, "intersect": {}, "into": {}, "is": {}, "isnull": {},
+	"join": {}, "lateral": {}, "leading": {}, "left": {}, "like": {}, "limit": {}, "localtime": {},
+	"localtimestamp": {}, "natural": {}, "not": {}, "notnull": {}, "null": {}, "offset": {}, "on": {},
+	"only": {}, "or": {}, "order": {}, "outer": {}, "overlaps": {}, "placing": {}, "primary": {},
+	"references": {}, "returning": {}, "right": {}, "select": {}, "session_user": {}, "similar": {},
+	"some": {}, "symmetric": {}, "table": {}, "tablesample": {}, "then": {}, "to": {}, "trailing": {},
+	"true": {}, "union": {}, "unique": {}, "user": {}, "using": {}, "variadic": {}, "verbose": {},
+	"when": {}, "where": {}, "window": {}, "with": {},
+}
+
+// mysqlReservedWords are the words marked (R) in the mysql documentation:
+// https://dev.mysql.com/doc/refman/8.0/en/keywords.html
+var mysqlReservedWords = map[string]struct{}{
+	"accessible": {}, "add": {}, "all": {}, "alter": {}, "analyze": {}, "and": {}, "as": {}, "asc": {},
+	"asensitive": {}, "before": {}, "between": {}, "bigint": {}, "binary": {}, "blob": {}, "both": {},
+	"by": {}, "call": {}, "cascade": {}, "case": {}, "change": {}, "char": {}, "character": {}, "check": {},
+	"collate": {}, "column": {}, "condition": {}, "constraint": {}, "continue": {}, "convert": {},
+	"create": {}, "cross": {}, "cube": {}, "cume_dist": {}, "current_date": {}, "current_time": {},
+	"current_timestamp": {}, "current_user": {}, "cursor": {}, "database": {}, "databases": {},
+	"day_hour": {}, "day_microsecond": {}, "day_minute": {}, "day_second": {}, "dec": {}, "decimal": {},
+	"declare": {}, "default": {}, "delayed": {}, "delete": {}, "dense_rank": {}, "desc": {}, "describe": {},
+	"deterministic": {}, "distinct": {}, "distinctrow": {}, "div": {}, "double": {}, "drop": {}, "dual": {},
+	"each": {}, "else": {}, "elseif": {}, "empty": {}, "enclosed": {}, "escaped": {}, "except": {},
+	"exists": {}, "exit": {}, "explain": {}, "false": {}, "fetch": {}, "first_value": {}, "float": {},
+	"float4": {}, "float8": {}, "for": {}, "force": {}, "foreign": {}, "from": {}, "fulltext": {},
+	"function": {}, "generated": {}, "get": {}, "grant": {}, "group": {}, "grouping": {}, "groups": {},
+	"having": {}, "high_priority": {}, "hour_microsecond": {}, "hour_minute": {}, "hour_second": {},
+	"if": {}, "ignore": {}, "in": {}, "index": {}, "infile": {}, "inner": {}, "inout": {}, "insensitive": {},
+	"insert": {}, "int": {}, "int1": {}, "int2": {}, "int3": {}, "int4": {}, "int8": {}, "integer": {},
+	"interval": {}, "into": {}, "io_after_gtids": {}, "io_before_gtids": {}, "is": {}, "iterate": {},
+	"join": {}, "json_table": {}, "key": {}, "keys": {}, "kill": {}, "lag": {}, "last_value": {},
+	"lateral": {}, "lead": {}, "leading": {}, "leave": {}, "left": {}, "like": {}, "limit": {}, "linear": {},
+	"lines": {}, "load": {}, "localtime": {}, "localtimestamp": {}, "lock": {}, "long": {}, "longblob": {},
+	"longtext": {}, "loop": {}, "low_priority": {}, "master_bind": {}, "master_ssl_verify_server_cert": {},
+	"match": {}, "maxvalue": {}, "mediumblob": {}, "mediumint": {}, "mediumtext": {}, "middleint": {},
+	"minute_microsecond": {}, "minute_second": {}, "mod": {}, "modifies": {}, "natural": {}, "not": {},
+	"no_write_to_binlog": {}, "nth_value": {}, "ntile": {}, "null": {}, "numeric": {}, "of": {}, "on": {},
+	"optimize": {}, "optimizer_costs": {}, "option": {}, "optionally": {}, "or": {}, "order": {}, "out": {},
+	"outer": {}, "outfile": {}, "over": {}, "partition": {}, "percent_rank": {}, "precision": {},
+	"primary": {}, "procedure": {}, "purge": {}, "range": {}, "rank": {}, "read": {}, "reads": {},
+	"read_write": {}, "real": {}, "recursive": {}, "references": {}, "regexp": {}, "release": {},
+	"rename": {}, "repeat": {}, "replace": {}, "require": {}, "resignal": {}, "restrict": {}, "return": {},
+	"revoke": {}, "right": {}, "rlike": {}, "row": {}, "rows": {}, "row_number": {}, "schema": {},
+	"schemas": {}, "second_microsecond": {}, "select": {}, "sensitive": {}, "separator": {}, "set": {},
+	"show": {}, "signal": {}, "smallint": {}, "spatial": {}, "specific": {}, "sql": {}, "sqlexception": {},
+	"sqlstate": {}, "sqlwarning": {}, "sql_big_result": {}, "sql_calc_found_rows": {},
+	"sql_small_result": {}, "ssl": {}, "starting": {}, "stored": {}, "straight_join": {}, "system": {},
+	"table": {}, "terminated": {}, "then": {}, "tinyblob": {}, "tinyint": {}, "tinytext": {}, "to": {},
+	"trailing": {}, "trigger": {}, "true": {}, "undo": {}, "union": {}, "unique": {}, "unlock": {},
+	"unsigned": {}, "update": {}, "usage": {}, "use": {}, "using": {}, "utc_date": {}, "utc_time": {},
+	"utc_timestamp": {}, "values": {}, "varbinary": {}, "varchar": {}, "varcharacter": {}, "varying": {},
+	"virtual": {}, "when": {}, "where": {}, "while": {}, "window": {}, "with": {}, "write": {}, "xor": {},
+	"year_month": {}, "zerofill": {},
+}
+
+// mssqlReservedWords are the reserved keywords in the transact-sql
+// documentation:
+// https://docs.microsoft.com/en-us/sql/t-sql/language-elements/reserved-keywords-transact-sql
+var mssqlReservedWords = map[string]struct{}{
+	"add": {}, "all": {}, "alter": {}, "and": {}, "any": {}, "as": {}, "asc": {}, "authorization": {},
+	"backup": {}, "begin": {}, "between": {}, "break": {}, "browse": {}, "bulk": {}, "by": {}, "cascade": {},
+	"case": {}, "check": {}, "checkpoint": {}, "close": {}, "clustered": {}, "coalesce": {}, "collate": {},
+	"column": {}, "commit": {}, "compute": {}, "constraint": {}, "contains": {}, "containstable": {},
+	"continue": {}, "convert": {}, "create": {}, "cross": {}, "current": {}, "current_date": {},
+	"current_time": {}, "current_timestamp": {}, "current_user": {}, "cursor": {}, "database": {},
+	"dbcc": {}, "deallocate": {}, "declare": {}, "default": {}, "delete": {}, "deny": {}, "desc": {},
+	"disk": {}, "distinct": {}, "distributed": {}, "double": {}, "drop": {}, "dump": {}, "else": {},
+	"end": {}, "errlvl": {}, "escape": {}, "except": {}, "exec": {}, "execute": {}, "exists": {}, "exit": {},
+	"external": {}, "fetch": {}, "file": {}, "fillfactor": {}, "for": {}, "foreign": {}, "freetext": {},
+	"freetexttable": {}, "from": {}, "full": {}, "function": {}, "goto": {}, "grant": {}, "group": {},
+	"having": {}, "holdlock": {}, "identity": {}, "identity_insert": {}, "identitycol": {}, "if": {},
+	"in": {}, "index": {}, "inner": {}, "insert": {}, "intersect": {}, "into": {}, "is": {}, "join": {},
+	"key": {}, "kill": {}, "left": {}, "like": {}, "lineno": {}, "load": {}, "merge": {}, "national": {},
+	"nocheck": {}, "nonclustered": {}, "not": {}, "null": {}, "nullif": {}, "of": {}, "off": {},
+	"offsets": {}, "on": {}, "open": {}, "opendatasource": {}, "openquery": {}, "openrowset": {},
+	"openxml": {}, "option": {}, "or": {}, "order": {}, "outer": {}, "over": {}, "percent": {}, "pivot": {},
+	"plan": {}, "precision": {}, "primary": {}, "print": {}, "proc": {}, "procedure": {}, "public": {},
+	"raiserror": {}, "read": {}, "readtext": {}, "reconfigure": {}, "references": {}, "replication": {},
+	"restore": {}, "restrict": {}, "return": {}, "revert": {}, "revoke": {}, "right": {}, "rollback": {},
+	"rowcount": {}, "rowguidcol": {}, "rule": {}, "save": {}, "schema": {}, "securityaudit": {},
+	"select": {}, "semantickeyphrasetable": {}, "semanticsimilaritydetailstable": {},
+	"semanticsimilaritytable": {}, "session_user": {}, "set": {}, "setuser": {}, "shutdown": {}, "some": {},
+	"statistics": {}, "system_user": {}, "table": {}, "tablesample": {}, "textsize": {}, "then": {},
+	"to": {}, "top": {}, "tran": {}, "transaction": {}, "trigger": {}, "truncate": {}, "try_convert": {},
+	"tsequal": {}, "union": {}, "unique": {}, "unpivot": {}, "update": {}, "updatetext": {}, "use": {},
+	"user": {}, "values": {}, "varying": {}, "view": {}, "waitfor": {}, "when": {}, "where": {}, "while": {},
+	"with": {}, "writetext": {},
+}
+
+// isReserved looks up a word in a reserved word list, case insensitively.
+func isReserved(words map[string]struct{}, word string) bool {
+	_, ok := words[strings.ToLower(word)]
+	return ok
+}
+
+// IsReserved checks if word is a reserved word in postgres, such
+// identifiers must be quoted.
+func (p *PostgresDriver) IsReserved(word string) bool {
+	return isReserved(postgresReservedWords, word)
+}
+
+// IsReserved checks if word is a reserved word in mysql, such
+// identifiers must be quoted.
+func (m *MySQLDriver) IsReserved(word string) bool {
+	return isReserved(mysqlReservedWords, word)
+}
+
+// IsReserved checks if word is a reserved word in mssql, such
+// identifiers must be quoted.
+func (m *MSSQLDriver) IsReserved(word string) bool {
+	return isReserved(mssqlReservedWords, word)
+}
@@ -0,0 +1,31 @@
+package drivers
+
+import (
+	"testing"
+
+	"github.com/volatiletech/sqlboiler/bdb"
+)
+
+func TestIsReserved(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		Driver   bdb.ReservedWorder
+		Word     string
+		Reserved bool
+	}{
+		{&PostgresDriver{}, "user", true},
+		{&PostgresDriver{}, "USER", true},
+		{&PostgresDriver{}, "rank", false},
+		{&MySQLDriver{}, "rank", true},
+		{&MySQLDriver{}, "user", false},
+		{&MSSQLDriver{}, "user", true},
+		{&MSSQLDriver{}, "name", false},
+	}
+
+	for i, test := range tests {
+		if got := test.Driver.IsReserved(test.Word); got != test.Reserved {
+			t.Errorf("%d) %s: want %t, got %t", i, test.Word, test.Reserved, got)
+		}
+	}
+}
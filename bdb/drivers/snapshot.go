@@ -0,0 +1,45 @@
+package drivers
+
+import (
+	"context"
+	"database/sql"
+)
+
+// ConsistentSnapshot is a global that is set from main.go if a user specifies
+// this flag when generating. If ConsistentSnapshot is true then drivers that
+// support it run every introspection query inside a single read only,
+// repeatable read transaction so the metadata can't change part way through.
+var ConsistentSnapshot bool
+
+// sqlRows is the part of *sql.Rows the drivers read
+type sqlRows interface {
+	Next() bool
+	Scan(dest ...interface{}) error
+	Columns() ([]string, error)
+	Err() error
+	Close() error
+}
+
+// sqlRow is the part of *sql.Row the drivers read
+type sqlRow interface {
+	Scan(dest ...interface{}) error
+}
+
+// queryer runs the drivers' queries on a *sql.DB or *sql.Tx, see conn
+type queryer interface {
+	Query(query string, args ...interface{}) (sqlRows, error)
+	QueryRow(query string, args ...interface{}) sqlRow
+}
+
+// beginSnapshot starts the transaction used for a consistent snapshot,
+// if ConsistentSnapshot is false it does nothing and returns nil.
+func beginSnapshot(db *sql.DB) (*sql.Tx, error) {
+	if !ConsistentSnapshot {
+		return nil, nil
+	}
+
+	return db.BeginTx(context.Background(), &sql.TxOptions{
+		Isolation: sql.LevelRepeatableRead,
+		ReadOnly:  true,
+	})
+}
@@ -0,0 +1,445 @@
+package drivers
+
+import (
+	"database/sql"
+	"fmt"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+	// Side-effect import sql driver
+	_ "github.com/snowflakedb/gosnowflake"
+	"github.com/volatiletech/sqlboiler/bdb"
+)
+
+// SnowflakeDriver holds the database connection string and a handle
+// to the database connection.
+//
+// Snowflake stores unquoted identifiers in uppercase, the driver returns the
+// names as they are and only folds the uppercase ones to lowercase for the
+// Go names (see FoldGoName), so the generated code reads like it does for
+// other databases while its SQL names the tables and columns exactly.
+type SnowflakeDriver struct {
+	connStr string
+	dbConn  *sql.DB
+}
+
+// NewSnowflakeDriver takes the database connection details as parameters and
+// returns a pointer to a SnowflakeDriver object. Note that it is required to
+// call SnowflakeDriver.Open() and SnowflakeDriver.Close() to open and close
+// the database connection once an object has been obtained.
+func NewSnowflakeDriver(user, pass, account, dbname, warehouse, role string) *SnowflakeDriver {
+	driver := SnowflakeDriver{
+		connStr: SnowflakeBuildQueryString(user, pass, account, dbname, warehouse, role),
+	}
+
+	return &driver
+}
+
+// SnowflakeBuildQueryString builds a data source name for gosnowflake, eg:
+// user:pass@account/dbname?warehouse=wh
+func SnowflakeBuildQueryString(user, pass, account, dbname, warehouse, role string) string {
+	query := url.Values{}
+	if len(warehouse) != 0 {
+		query.Add("warehouse", warehouse)
+	}
+	if len(role) != 0 {
+		query.Add("role", role)
+	}
+
+	u := &url.URL{
+		User:     url.UserPassword(user, pass),
+		Host:     account,
+		Path:     "/" + dbname,
+		RawQuery: query.Encode(),
+	}
+
+	// Without a scheme the url starts with //, gosnowflake doesn't want it
+	return strings.TrimPrefix(u.String(), "//")
+}
+
+// Open opens the database connection using the connection string
+func (s *SnowflakeDriver) Open() error {
+	var err error
+	s.dbConn, err = sql.Open("snowflake", s.connStr)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// Close closes the database connection
+func (s *SnowflakeDriver) Close() {
+	s.dbConn.Close()
+}
+
+// conn returns the database connection with the StatementTimeout.
+func (s *SnowflakeDriver) conn() queryer {
+	return withStatementTimeout(s.dbConn)
+}
+
+// UseLastInsertID returns false for snowflake
+func (s *SnowflakeDriver) UseLastInsertID() bool {
+	return false
+}
+
+// UseTopClause returns false to indicate Snowflake doesnt support SQL TOP clause
+func (s *SnowflakeDriver) UseTopClause() bool {
+	return false
+}
+
+// FoldGoName lowercases the uppercase names, which are the unquoted ones,
+// for their Go names. Names that were created quoted with other casing are
+// left as they are.
+func (s *SnowflakeDriver) FoldGoName(name string) string {
+	if name == strings.ToUpper(name) {
+		return strings.ToLower(name)
+	}
+
+	return name
+}
+
+// snowflakeSchema is the snowflake identifier of the schema given in the
+// config, a lowercase name is resolved like an unquoted one, in uppercase.
+func snowflakeSchema(schema string) string {
+	if schema == strings.ToLower(schema) {
+		return strings.ToUpper(schema)
+	}
+
+	return schema
+}
+
+// snowflakeQuote quotes a snowflake identifier for the SHOW commands, which
+// can't take placeholders.
+func snowflakeQuote(ident string) string {
+	return `"` + strings.Replace(ident, `"`, `""`, -1) + `"`
+}
+
+// snowflakeTable is the quoted schema.table of a table name returned by
+// TableNames.
+func snowflakeTable(schema, tableName string) string {
+	return snowflakeQuote(snowflakeSchema(schema)) + "." + snowflakeQuote(tableName)
+}
+
+// TableNames connects to the snowflake database and
+// retrieves all table names from the information_schema where the
+// table schema is schema. It uses a whitelist and blacklist, which name the
+// tables as they are in the database, eg: USERS for an unquoted users.
+func (s *SnowflakeDriver) TableNames(schema string, whitelist, blacklist []string) ([]string, error) {
+	var names []string
+
+	query := `select table_name from information_schema.tables where table_schema = ? and table_type = 'BASE TABLE'`
+	args := []interface{}{snowflakeSchema(schema)}
+	if len(whitelist) > 0 {
+		query += fmt.Sprintf(" and table_name in (%s)", questionMarks(len(whitelist)))
+		for _, w := range whitelist {
+			args = append(args, w)
+		}
+	} else if len(blacklist) > 0 {
+		query += fmt.Sprintf(" and table_name not in (%s)", questionMarks(len(blacklist)))
+		for _, b := range blacklist {
+			args = append(args, b)
+		}
+	}
+
+	query += " order by table_name"
+
+	rows, err := s.conn().Query(query, args...)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			return nil, err
+		}
+		names = append(names, name)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return names, nil
+}
+
+// Columns takes a table name and attempts to retrieve the table information
+// from information_schema.columns. It retrieves the column names and column
+// types and returns those as a []Column after TranslateColumnType() converts
+// the SQL types to Go types, for example: "TEXT" to "string"
+func (s *SnowflakeDriver) Columns(schema, tableName string) ([]bdb.Column, error) {
+	var columns []bdb.Column
+
+	unique, err := s.uniqueColumns(schema, tableName)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := s.conn().Query(`
+	select
+		c.column_name,
+		c.data_type,
+		c.column_default,
+		c.is_nullable = 'YES',
+		c.is_identity = 'YES',
+		coalesce(c.numeric_precision, 0),
+		coalesce(c.numeric_scale, 0),
+		coalesce(c.comment, '')
+	from information_schema.columns as c
+	where c.table_schema = ? and c.table_name = ?
+	order by c.ordinal_position
+	`, snowflakeSchema(schema), tableName)
+
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var colName, colType, comment string
+		var nullable, identity bool
+		var precision, scale int
+		var defaultValue *string
+		if err := rows.Scan(&colName, &colType, &defaultValue, &nullable, &identity, &precision, &scale, &comment); err != nil {
+			return nil, errors.Wrapf(err, "unable to scan for table %s", tableName)
+		}
+
+		column := bdb.Column{
+			Name:             colName,
+			DBType:           colType,
+			FullDBType:       colType,
+			Nullable:         nullable,
+			Unique:           unique[colName],
+			IsAutoIncrement:  identity,
+			NumericPrecision: precision,
+			NumericScale:     scale,
+			Comment:          comment,
+		}
+
+		if colType == "NUMBER" {
+			column.FullDBType = fmt.Sprintf("NUMBER(%d,%d)", precision, scale)
+		}
+
+		if defaultValue != nil {
+			column.Default = *defaultValue
+		}
+
+		columns = append(columns, column)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, errors.Wrapf(err, "unable to read the columns of table %s", tableName)
+	}
+
+	return columns, nil
+}
+
+// show runs one of snowflake's SHOW commands, which return a fixed set of
+// columns that can't be selected, and returns each row by column name.
+// Snowflake doesn't have key_column_usage in its information_schema, the
+// columns of keys are only listed by these.
+func (s *SnowflakeDriver) show(query string) ([]map[string]string, error) {
+	rows, err := s.conn().Query(query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	names, err := rows.Columns()
+	if err != nil {
+		return nil, err
+	}
+
+	var results []map[string]string
+	for rows.Next() {
+		values := make([]sql.NullString, len(names))
+		dest := make([]interface{}, len(names))
+		for i := range values {
+			dest[i] = &values[i]
+		}
+
+		if err = rows.Scan(dest...); err != nil {
+			return nil, err
+		}
+
+		result := make(map[string]string, len(names))
+		for i, n := range names {
+			result[strings.ToLower(n)] = values[i].String
+		}
+		results = append(results, result)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return results, nil
+}
+
+// sortByKeySequence sorts the rows of a SHOW command by constraint and the
+// position of the column in it.
+func sortByKeySequence(rows []map[string]string, constraint string) {
+	sort.SliceStable(rows, func(i, j int) bool {
+		if rows[i][constraint] != rows[j][constraint] {
+			return rows[i][constraint] < rows[j][constraint]
+		}
+		a, _ := strconv.Atoi(rows[i]["key_sequence"])
+		b, _ := strconv.Atoi(rows[j]["key_sequence"])
+		return a < b
+	})
+}
+
+// uniqueColumns returns the columns that have a unique constraint of their
+// own, by their name.
+func (s *SnowflakeDriver) uniqueColumns(schema, tableName string) (map[string]bool, error) {
+	rows, err := s.show("show unique keys in table " + snowflakeTable(schema, tableName))
+	if err != nil {
+		return nil, err
+	}
+
+	counts := map[string]int{}
+	for _, r := range rows {
+		counts[r["constraint_name"]]++
+	}
+
+	unique := map[string]bool{}
+	for _, r := range rows {
+		if counts[r["constraint_name"]] == 1 {
+			unique[r["column_name"]] = true
+		}
+	}
+
+	return unique, nil
+}
+
+// PrimaryKeyInfo looks up the primary key for a table.
+func (s *SnowflakeDriver) PrimaryKeyInfo(schema, tableName string) (*bdb.PrimaryKey, error) {
+	rows, err := s.show("show primary keys in table " + snowflakeTable(schema, tableName))
+	if err != nil {
+		return nil, err
+	}
+	sortByKeySequence(rows, "constraint_name")
+
+	var pkey *bdb.PrimaryKey
+	for _, r := range rows {
+		if pkey == nil {
+			pkey = &bdb.PrimaryKey{Name: r["constraint_name"]}
+		}
+		pkey.Columns = append(pkey.Columns, r["column_name"])
+	}
+
+	return pkey, nil
+}
+
+// ForeignKeyInfo retrieves the foreign keys for a given table name.
+func (s *SnowflakeDriver) ForeignKeyInfo(schema, tableName string) ([]bdb.ForeignKey, error) {
+	var fkeys []bdb.ForeignKey
+
+	rows, err := s.show("show imported keys in table " + snowflakeTable(schema, tableName))
+	if err != nil {
+		return nil, err
+	}
+	sortByKeySequence(rows, "fk_name")
+
+	for _, r := range rows {
+		// Keys to tables in other schemas can't be generated
+		if r["pk_schema_name"] != snowflakeSchema(schema) {
+			continue
+		}
+
+		fkeys = append(fkeys, bdb.ForeignKey{
+			Schema:        schema,
+			Table:         tableName,
+			Name:          r["fk_name"],
+			Column:        r["fk_column_name"],
+			ForeignTable:  r["pk_table_name"],
+			ForeignColumn: r["pk_column_name"],
+			MatchType:     bdb.MatchSimple,
+		})
+	}
+
+	return fkeys, nil
+}
+
+// TranslateColumnType converts snowflake database types to Go types, for example
+// "TEXT" to "string" and "NUMBER(18,0)" to "int64". It returns this parsed
+// data as a Column object.
+//
+// NUMBER is NUMBER(38,0) unless it's given a precision, and INT and the other
+// integer types are its synonyms; only the integers of up to 18 digits fit in
+// an int64. Wider integers and numbers with a scale are types.Decimal so no
+// digits are lost.
+func (s *SnowflakeDriver) TranslateColumnType(c bdb.Column) bdb.Column {
+	isDecimal := c.NumericScale != 0 || c.NumericPrecision == 0 || c.NumericPrecision > 18
+
+	if c.Nullable {
+		switch c.DBType {
+		case "NUMBER", "DECIMAL", "NUMERIC", "INT", "INTEGER", "BIGINT", "SMALLINT", "TINYINT", "BYTEINT":
+			c.Type = "null.Int64"
+			if isDecimal {
+				c.Type = "types.NullDecimal"
+			}
+		case "FLOAT", "FLOAT4", "FLOAT8", "DOUBLE", "DOUBLE PRECISION", "REAL":
+			c.Type = "null.Float64"
+		case "BOOLEAN":
+			c.Type = "null.Bool"
+		case "DATE", "TIME", "DATETIME", "TIMESTAMP", "TIMESTAMP_NTZ", "TIMESTAMP_LTZ", "TIMESTAMP_TZ":
+			c.Type = "null.Time"
+		case "BINARY", "VARBINARY":
+			c.Type = "null.Bytes"
+		case "VARIANT", "ARRAY", "OBJECT":
+			c.Type = "null.JSON"
+		case "TEXT", "VARCHAR", "CHAR", "CHARACTER", "STRING", "GEOGRAPHY", "GEOMETRY":
+			c.Type = "null.String"
+		default:
+			c.Type = "null.String"
+			c.UnknownType = true
+		}
+	} else {
+		switch c.DBType {
+		case "NUMBER", "DECIMAL", "NUMERIC", "INT", "INTEGER", "BIGINT", "SMALLINT", "TINYINT", "BYTEINT":
+			c.Type = "int64"
+			if isDecimal {
+				c.Type = "types.Decimal"
+			}
+		case "FLOAT", "FLOAT4", "FLOAT8", "DOUBLE", "DOUBLE PRECISION", "REAL":
+			c.Type = "float64"
+		case "BOOLEAN":
+			c.Type = "bool"
+		case "DATE", "TIME", "DATETIME", "TIMESTAMP", "TIMESTAMP_NTZ", "TIMESTAMP_LTZ", "TIMESTAMP_TZ":
+			c.Type = "time.Time"
+		case "BINARY", "VARBINARY":
+			c.Type = "[]byte"
+		case "VARIANT", "ARRAY", "OBJECT":
+			c.Type = "types.JSON"
+		case "TEXT", "VARCHAR", "CHAR", "CHARACTER", "STRING", "GEOGRAPHY", "GEOMETRY":
+			c.Type = "string"
+		default:
+			c.Type = "string"
+			c.UnknownType = true
+		}
+	}
+
+	return c
+}
+
+// RightQuote is the quoting character for the right side of the identifier
+func (s *SnowflakeDriver) RightQuote() byte {
+	return '"'
+}
+
+// LeftQuote is the quoting character for the left side of the identifier
+func (s *SnowflakeDriver) LeftQuote() byte {
+	return '"'
+}
+
+// IndexPlaceholders returns false to indicate Snowflake doesnt support indexed placeholders
+func (s *SnowflakeDriver) IndexPlaceholders() bool {
+	return false
+}
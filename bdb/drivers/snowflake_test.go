@@ -0,0 +1,138 @@
+package drivers
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/volatiletech/sqlboiler/bdb"
+	"gopkg.in/DATA-DOG/go-sqlmock.v1"
+)
+
+func TestSnowflakeFoldGoName(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		Name string
+		Fold string
+	}{
+		{"USERS", "users"},
+		{"ORDER_ITEMS", "order_items"},
+		{"MixedCase", "MixedCase"},
+		{"V2", "v2"},
+	}
+
+	s := &SnowflakeDriver{}
+	for i, test := range tests {
+		if got := s.FoldGoName(test.Name); got != test.Fold {
+			t.Errorf("%d) want %s, got %s", i, test.Fold, got)
+		}
+	}
+
+	if got := snowflakeSchema("public"); got != "PUBLIC" {
+		t.Errorf("want the unquoted schema in uppercase, got %s", got)
+	}
+	if got := snowflakeSchema("Sales"); got != "Sales" {
+		t.Errorf("want a quoted schema as it is, got %s", got)
+	}
+}
+
+func TestSnowflakeTableNames(t *testing.T) {
+	t.Parallel()
+
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	mock.ExpectQuery(`from information_schema.tables where table_schema = \? and table_type = 'BASE TABLE' and table_name in \(\?, \?\)`).
+		WithArgs("PUBLIC", "USERS", "Orders").
+		WillReturnRows(sqlmock.NewRows([]string{"table_name"}).AddRow("Orders").AddRow("USERS"))
+
+	s := &SnowflakeDriver{dbConn: db}
+	names, err := s.TableNames("public", []string{"USERS", "Orders"}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"Orders", "USERS"}; !reflect.DeepEqual(names, want) {
+		t.Errorf("want the names as they are %v, got %v", want, names)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestSnowflakeColumnsAndKeys(t *testing.T) {
+	t.Parallel()
+
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	mock.ExpectQuery(`show unique keys in table "PUBLIC"."ORDERS"`).
+		WillReturnRows(sqlmock.NewRows([]string{"created_on", "column_name", "key_sequence", "constraint_name"}).
+			AddRow(nil, "REF", "1", "ORDERS_REF_KEY"))
+	mock.ExpectQuery(`from information_schema.columns`).
+		WithArgs("PUBLIC", "ORDERS").
+		WillReturnRows(sqlmock.NewRows([]string{"column_name", "data_type", "column_default", "is_nullable", "is_identity", "numeric_precision", "numeric_scale", "comment"}).
+			AddRow("ID", "NUMBER", nil, false, true, 38, 0, "").
+			AddRow("REF", "TEXT", nil, false, false, 0, 0, "").
+			AddRow("TOTAL", "NUMBER", nil, true, false, 10, 2, "").
+			AddRow("QUANTITY", "NUMBER", nil, false, false, 18, 0, "").
+			AddRow("Payload", "VARIANT", nil, true, false, 0, 0, ""))
+	mock.ExpectQuery(`show primary keys in table "PUBLIC"."ORDERS"`).
+		WillReturnRows(sqlmock.NewRows([]string{"created_on", "column_name", "key_sequence", "constraint_name"}).
+			AddRow(nil, "ID", "1", "ORDERS_PKEY"))
+	mock.ExpectQuery(`show imported keys in table "PUBLIC"."ORDERS"`).
+		WillReturnRows(sqlmock.NewRows([]string{"pk_schema_name", "pk_table_name", "pk_column_name", "fk_column_name", "key_sequence", "fk_name"}).
+			AddRow("PUBLIC", "USERS", "ID", "USER_ID", "1", "ORDERS_USER_FKEY").
+			AddRow("AUDIT", "EVENTS", "ID", "EVENT_ID", "1", "ORDERS_EVENT_FKEY"))
+
+	s := &SnowflakeDriver{dbConn: db}
+	columns, err := s.Columns("public", "ORDERS")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var names, types []string
+	for _, c := range columns {
+		c = s.TranslateColumnType(c)
+		names = append(names, c.Name)
+		types = append(types, c.Type)
+	}
+	if want := []string{"ID", "REF", "TOTAL", "QUANTITY", "Payload"}; !reflect.DeepEqual(names, want) {
+		t.Errorf("want names %v, got %v", want, names)
+	}
+	if want := []string{"types.Decimal", "string", "types.NullDecimal", "int64", "null.JSON"}; !reflect.DeepEqual(types, want) {
+		t.Errorf("want types %v, got %v", want, types)
+	}
+	if !columns[0].IsAutoIncrement || !columns[1].Unique || columns[2].FullDBType != "NUMBER(10,2)" {
+		t.Errorf("unexpected columns: %#v", columns)
+	}
+
+	pkey, err := s.PrimaryKeyInfo("public", "ORDERS")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := (&bdb.PrimaryKey{Name: "ORDERS_PKEY", Columns: []string{"ID"}}); !reflect.DeepEqual(pkey, want) {
+		t.Errorf("want %#v, got %#v", want, pkey)
+	}
+
+	fkeys, err := s.ForeignKeyInfo("public", "ORDERS")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(fkeys) != 1 {
+		t.Fatalf("want only the key in the schema, got %#v", fkeys)
+	}
+	if f := fkeys[0]; f.Name != "ORDERS_USER_FKEY" || f.Column != "USER_ID" || f.ForeignTable != "USERS" || f.ForeignColumn != "ID" {
+		t.Errorf("unexpected foreign key: %#v", f)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
@@ -0,0 +1,430 @@
+package drivers
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+
+	// Side-effect import sql driver
+	_ "github.com/googleapis/go-sql-spanner"
+	"github.com/pkg/errors"
+	"github.com/volatiletech/sqlboiler/bdb"
+)
+
+// SpannerDriver holds the database connection string and a handle
+// to the database connection.
+type SpannerDriver struct {
+	connStr string
+	dbConn  *sql.DB
+}
+
+// NewSpannerDriver takes the database connection details as parameters and
+// returns a pointer to a SpannerDriver object. Note that it is required to
+// call SpannerDriver.Open() and SpannerDriver.Close() to open and close
+// the database connection once an object has been obtained.
+func NewSpannerDriver(project, instance, dbname string) *SpannerDriver {
+	driver := SpannerDriver{
+		connStr: SpannerBuildQueryString(project, instance, dbname),
+	}
+
+	return &driver
+}
+
+// SpannerBuildQueryString builds a query string for Spanner.
+func SpannerBuildQueryString(project, instance, dbname string) string {
+	return fmt.Sprintf("projects/%s/instances/%s/databases/%s", project, instance, dbname)
+}
+
+// Open opens the database connection using the connection string
+func (s *SpannerDriver) Open() error {
+	var err error
+	s.dbConn, err = sql.Open("spanner", s.connStr)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// Close closes the database connection
+func (s *SpannerDriver) Close() {
+	s.dbConn.Close()
+}
+
+// conn returns the database connection with the StatementTimeout.
+func (s *SpannerDriver) conn() queryer {
+	return withStatementTimeout(s.dbConn)
+}
+
+// UseLastInsertID returns false for spanner
+func (s *SpannerDriver) UseLastInsertID() bool {
+	return false
+}
+
+// UseTopClause returns false to indicate Spanner doesnt support SQL TOP clause
+func (s *SpannerDriver) UseTopClause() bool {
+	return false
+}
+
+// TableNames connects to the spanner database and
+// retrieves all table names from the information_schema where the
+// table schema is schema. It uses a whitelist and blacklist.
+func (s *SpannerDriver) TableNames(schema string, whitelist, blacklist []string) ([]string, error) {
+	var names []string
+
+	query := `select table_name from information_schema.tables where table_schema = ? and table_type = 'BASE TABLE'`
+	args := []interface{}{schema}
+	if len(whitelist) > 0 {
+		query += fmt.Sprintf(" and table_name in (%s)", questionMarks(len(whitelist)))
+		for _, w := range whitelist {
+			args = append(args, w)
+		}
+	} else if len(blacklist) > 0 {
+		query += fmt.Sprintf(" and table_name not in (%s)", questionMarks(len(blacklist)))
+		for _, b := range blacklist {
+			args = append(args, b)
+		}
+	}
+
+	query += " order by table_name"
+
+	rows, err := s.conn().Query(query, args...)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			return nil, err
+		}
+		names = append(names, name)
+	}
+
+	return names, nil
+}
+
+// Columns takes a table name and attempts to retrieve the table information
+// from the database information_schema.columns. It retrieves the column names
+// and column types and returns those as a []Column after TranslateColumnType()
+// converts the SQL types to Go types, for example: "STRING(MAX)" to "string"
+func (s *SpannerDriver) Columns(schema, tableName string) ([]bdb.Column, error) {
+	var columns []bdb.Column
+
+	rows, err := s.conn().Query(`
+	select
+		c.column_name,
+		c.spanner_type,
+		c.column_default,
+		c.is_nullable = 'YES',
+		exists (
+			select 1
+			from information_schema.indexes i
+			inner join information_schema.index_columns ic
+				on ic.table_schema = i.table_schema and ic.table_name = i.table_name and ic.index_name = i.index_name
+			where i.table_schema = c.table_schema and i.table_name = c.table_name and i.is_unique and
+				ic.column_name = c.column_name and
+				(select count(*) from information_schema.index_columns where table_schema = i.table_schema and table_name = i.table_name and index_name = i.index_name) = 1
+		) as is_unique
+	from information_schema.columns as c
+	where c.table_name = ? and c.table_schema = ?
+	order by c.ordinal_position
+	`, tableName, schema)
+
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var colName, colFullType string
+		var nullable, unique bool
+		var defaultValue *string
+		if err := rows.Scan(&colName, &colFullType, &defaultValue, &nullable, &unique); err != nil {
+			return nil, errors.Wrapf(err, "unable to scan for table %s", tableName)
+		}
+
+		column := bdb.Column{
+			Name:       colName,
+			FullDBType: colFullType, // example: STRING(MAX) instead of STRING
+			DBType:     spannerBaseType(colFullType),
+			Nullable:   nullable,
+			Unique:     unique,
+		}
+
+		if column.DBType == "ARRAY" {
+			arrType := spannerBaseType(strings.TrimSuffix(strings.TrimPrefix(colFullType, "ARRAY<"), ">"))
+			column.ArrType = &arrType
+		}
+
+		if defaultValue != nil {
+			column.Default = *defaultValue
+		}
+
+		columns = append(columns, column)
+	}
+
+	return columns, nil
+}
+
+// spannerBaseType strips the length and element type from a spanner type,
+// eg: STRING(MAX) becomes STRING and ARRAY<INT64> becomes ARRAY
+func spannerBaseType(typ string) string {
+	if i := strings.IndexAny(typ, "(<"); i >= 0 {
+		typ = typ[:i]
+	}
+
+	return strings.ToUpper(strings.TrimSpace(typ))
+}
+
+// PrimaryKeyInfo looks up the primary key for a table.
+func (s *SpannerDriver) PrimaryKeyInfo(schema, tableName string) (*bdb.PrimaryKey, error) {
+	pkey := &bdb.PrimaryKey{}
+	var err error
+
+	query := `
+	select i.index_name
+	from information_schema.indexes as i
+	where i.table_name = ? and i.index_type = 'PRIMARY_KEY' and i.table_schema = ?`
+
+	row := s.conn().QueryRow(query, tableName, schema)
+	if err = row.Scan(&pkey.Name); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	queryColumns := `
+	select ic.column_name
+	from   information_schema.index_columns as ic
+	where  ic.table_name = ? and ic.index_name = ? and ic.table_schema = ?
+	order by ic.ordinal_position`
+
+	var rows sqlRows
+	if rows, err = s.conn().Query(queryColumns, tableName, pkey.Name, schema); err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var columns []string
+	for rows.Next() {
+		var column string
+
+		err = rows.Scan(&column)
+		if err != nil {
+			return nil, err
+		}
+
+		columns = append(columns, column)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	pkey.Columns = columns
+
+	return pkey, nil
+}
+
+// ForeignKeyInfo retrieves the foreign keys for a given table name. The
+// columns of a composite foreign key are paired with the ones they
+// reference by position_in_unique_constraint.
+//
+// Spanner databases created before foreign key support relate tables by
+// interleaving a child table in its parent instead. The leading primary key
+// columns of an interleaved table are the primary key of its parent, so these
+// are also returned as foreign keys to the parent table.
+func (s *SpannerDriver) ForeignKeyInfo(schema, tableName string) ([]bdb.ForeignKey, error) {
+	var fkeys []bdb.ForeignKey
+
+	query := `
+	select rc.constraint_name, kcu.table_name, kcu.column_name, fkcu.table_name, fkcu.column_name
+	from information_schema.referential_constraints rc
+	inner join information_schema.key_column_usage kcu
+		on kcu.constraint_schema = rc.constraint_schema and kcu.constraint_name = rc.constraint_name
+	inner join information_schema.key_column_usage fkcu
+		on fkcu.constraint_schema = rc.unique_constraint_schema and fkcu.constraint_name = rc.unique_constraint_name and
+			kcu.position_in_unique_constraint = fkcu.ordinal_position
+	where kcu.table_schema = ? and kcu.table_name = ?
+	order by rc.constraint_name, kcu.ordinal_position
+	`
+
+	var rows sqlRows
+	var err error
+	if rows, err = s.conn().Query(query, schema, tableName); err != nil {
+		return nil, err
+	}
+
+	for rows.Next() {
+		var fkey bdb.ForeignKey
+		var sourceTable string
+
+		fkey.Schema = schema
+		fkey.Table = tableName
+		err = rows.Scan(&fkey.Name, &sourceTable, &fkey.Column, &fkey.ForeignTable, &fkey.ForeignColumn)
+		if err != nil {
+			rows.Close()
+			return nil, err
+		}
+
+		fkeys = append(fkeys, fkey)
+	}
+
+	if err = rows.Err(); err != nil {
+		rows.Close()
+		return nil, err
+	}
+	rows.Close()
+
+	var parent sql.NullString
+	row := s.conn().QueryRow(`select parent_table_name from information_schema.tables where table_schema = ? and table_name = ?`, schema, tableName)
+	if err = row.Scan(&parent); err != nil {
+		return nil, err
+	}
+
+	if !parent.Valid || len(parent.String) == 0 {
+		return fkeys, nil
+	}
+
+	parentKey, err := s.PrimaryKeyInfo(schema, parent.String)
+	if err != nil {
+		return nil, err
+	}
+	if parentKey == nil {
+		return fkeys, nil
+	}
+
+	for _, col := range parentKey.Columns {
+		if spannerHasForeignKey(fkeys, col, parent.String) {
+			continue
+		}
+
+		fkeys = append(fkeys, bdb.ForeignKey{
+			Schema:        schema,
+			Table:         tableName,
+			Name:          fmt.Sprintf("%s_interleave_%s", tableName, parent.String),
+			Column:        col,
+			ForeignTable:  parent.String,
+			ForeignColumn: col,
+		})
+	}
+
+	return fkeys, nil
+}
+
+// spannerHasForeignKey checks if a real foreign key already covers
+// the relationship to an interleaved table's parent.
+func spannerHasForeignKey(fkeys []bdb.ForeignKey, column, foreignTable string) bool {
+	for _, f := range fkeys {
+		if f.Column == column && f.ForeignTable == foreignTable {
+			return true
+		}
+	}
+
+	return false
+}
+
+// TranslateColumnType converts spanner database types to Go types, for example
+// "STRING" to "string" and "INT64" to "int64". It returns this parsed data
+// as a Column object.
+func (s *SpannerDriver) TranslateColumnType(c bdb.Column) bdb.Column {
+	if c.Nullable {
+		switch c.DBType {
+		case "INT64":
+			c.Type = "null.Int64"
+		case "FLOAT64":
+			c.Type = "null.Float64"
+		case "BOOL":
+			c.Type = "null.Bool"
+		case "BYTES":
+			c.Type = "null.Bytes"
+		case "DATE", "TIMESTAMP":
+			c.Type = "null.Time"
+		case "JSON":
+			c.Type = "null.JSON"
+		case "ARRAY":
+			c.Type = getSpannerArrayType(&c)
+		case "STRING", "NUMERIC":
+			c.Type = "null.String"
+		default:
+			c.Type = "null.String"
+			c.UnknownType = true
+		}
+	} else {
+		switch c.DBType {
+		case "INT64":
+			c.Type = "int64"
+		case "FLOAT64":
+			c.Type = "float64"
+		case "BOOL":
+			c.Type = "bool"
+		case "BYTES":
+			c.Type = "[]byte"
+		case "DATE", "TIMESTAMP":
+			c.Type = "time.Time"
+		case "JSON":
+			c.Type = "types.JSON"
+		case "ARRAY":
+			c.Type = getSpannerArrayType(&c)
+		case "STRING", "NUMERIC":
+			c.Type = "string"
+		default:
+			c.Type = "string"
+			c.UnknownType = true
+		}
+	}
+
+	return c
+}
+
+// getSpannerArrayType returns the slice type go-sql-spanner scans each
+// spanner array into, the elements are null types since an array can hold
+// NULLs. Arrays of other types are flagged as unknown.
+func getSpannerArrayType(c *bdb.Column) string {
+	if c.ArrType == nil {
+		panic("unable to get spanner ARRAY underlying type")
+	}
+
+	switch *c.ArrType {
+	case "INT64":
+		return "[]spanner.NullInt64"
+	case "FLOAT64":
+		return "[]spanner.NullFloat64"
+	case "BOOL":
+		return "[]spanner.NullBool"
+	case "BYTES":
+		return "[][]byte"
+	case "STRING":
+		return "[]spanner.NullString"
+	case "NUMERIC":
+		return "[]spanner.NullNumeric"
+	case "JSON":
+		return "[]spanner.NullJSON"
+	case "DATE":
+		return "[]spanner.NullDate"
+	case "TIMESTAMP":
+		return "[]spanner.NullTime"
+	default:
+		c.UnknownType = true
+		return "[]spanner.NullString"
+	}
+}
+
+// RightQuote is the quoting character for the right side of the identifier
+func (s *SpannerDriver) RightQuote() byte {
+	return '`'
+}
+
+// LeftQuote is the quoting character for the left side of the identifier
+func (s *SpannerDriver) LeftQuote() byte {
+	return '`'
+}
+
+// IndexPlaceholders returns false to indicate Spanner doesnt support indexed placeholders
+func (s *SpannerDriver) IndexPlaceholders() bool {
+	return false
+}
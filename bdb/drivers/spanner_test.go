@@ -0,0 +1,140 @@
+package drivers
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/volatiletech/sqlboiler/bdb"
+	"gopkg.in/DATA-DOG/go-sqlmock.v1"
+)
+
+func TestSpannerColumns(t *testing.T) {
+	t.Parallel()
+
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	mock.ExpectQuery(`from information_schema.columns as c`).
+		WithArgs("albums", "").
+		WillReturnRows(sqlmock.NewRows([]string{"column_name", "spanner_type", "column_default", "is_nullable", "is_unique"}).
+			AddRow("id", "INT64", nil, false, true).
+			AddRow("title", "STRING(MAX)", nil, true, false).
+			AddRow("track_ids", "ARRAY<INT64>", nil, true, false).
+			AddRow("tags", "ARRAY<STRING(64)>", nil, false, false).
+			AddRow("covers", "ARRAY<BYTES(MAX)>", nil, false, false))
+
+	s := &SpannerDriver{dbConn: db}
+	columns, err := s.Columns("", "albums")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		DBType string
+		Type   string
+	}{
+		{"INT64", "int64"},
+		{"STRING", "null.String"},
+		{"ARRAY", "[]spanner.NullInt64"},
+		{"ARRAY", "[]spanner.NullString"},
+		{"ARRAY", "[][]byte"},
+	}
+
+	if len(columns) != len(tests) {
+		t.Fatalf("want %d columns, got: %#v", len(tests), columns)
+	}
+	for i, test := range tests {
+		c := s.TranslateColumnType(columns[i])
+		if c.DBType != test.DBType || c.Type != test.Type || c.UnknownType {
+			t.Errorf("%d) %s want %s %s, got: %#v", i, c.Name, test.DBType, test.Type, c)
+		}
+	}
+	if arr := columns[3].ArrType; arr == nil || *arr != "STRING" {
+		t.Errorf("want the element type without its length, got: %v", arr)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestSpannerForeignKeyInfo(t *testing.T) {
+	t.Parallel()
+
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	// The columns of a composite foreign key are paired up by position
+	mock.ExpectQuery(`(?s)inner join information_schema.key_column_usage fkcu.*kcu.position_in_unique_constraint = fkcu.ordinal_position`).
+		WithArgs("", "tracks").
+		WillReturnRows(sqlmock.NewRows([]string{"constraint_name", "table_name", "column_name", "table_name", "column_name"}).
+			AddRow("fk_tracks_albums", "tracks", "singer_id", "albums", "singer_id").
+			AddRow("fk_tracks_albums", "tracks", "album_id", "albums", "id"))
+	mock.ExpectQuery(`select parent_table_name from information_schema.tables`).
+		WithArgs("", "tracks").
+		WillReturnRows(sqlmock.NewRows([]string{"parent_table_name"}).AddRow(nil))
+
+	s := &SpannerDriver{dbConn: db}
+	fkeys, err := s.ForeignKeyInfo("", "tracks")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []bdb.ForeignKey{
+		{Name: "fk_tracks_albums", Table: "tracks", Column: "singer_id", ForeignTable: "albums", ForeignColumn: "singer_id"},
+		{Name: "fk_tracks_albums", Table: "tracks", Column: "album_id", ForeignTable: "albums", ForeignColumn: "id"},
+	}
+	if !reflect.DeepEqual(fkeys, want) {
+		t.Errorf("want %#v, got %#v", want, fkeys)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestSpannerForeignKeyInfoInterleaved(t *testing.T) {
+	t.Parallel()
+
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	mock.ExpectQuery(`from information_schema.referential_constraints rc`).
+		WithArgs("", "albums").
+		WillReturnRows(sqlmock.NewRows([]string{"constraint_name", "table_name", "column_name", "table_name", "column_name"}))
+	mock.ExpectQuery(`select parent_table_name from information_schema.tables`).
+		WithArgs("", "albums").
+		WillReturnRows(sqlmock.NewRows([]string{"parent_table_name"}).AddRow("singers"))
+	mock.ExpectQuery(`index_type = 'PRIMARY_KEY'`).
+		WithArgs("singers", "").
+		WillReturnRows(sqlmock.NewRows([]string{"index_name"}).AddRow("PRIMARY_KEY"))
+	mock.ExpectQuery(`from   information_schema.index_columns as ic`).
+		WithArgs("singers", "PRIMARY_KEY", "").
+		WillReturnRows(sqlmock.NewRows([]string{"column_name"}).AddRow("singer_id"))
+
+	s := &SpannerDriver{dbConn: db}
+	fkeys, err := s.ForeignKeyInfo("", "albums")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []bdb.ForeignKey{
+		{Name: "albums_interleave_singers", Table: "albums", Column: "singer_id", ForeignTable: "singers", ForeignColumn: "singer_id"},
+	}
+	if !reflect.DeepEqual(fkeys, want) {
+		t.Errorf("want %#v, got %#v", want, fkeys)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
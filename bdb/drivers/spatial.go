@@ -0,0 +1,76 @@
+package drivers
+
+import "github.com/volatiletech/sqlboiler/bdb"
+
+// SpatialType and NullSpatialType are globals for tools built on the
+// package. When they aren't empty they're the Go types of spatial columns
+// (mysql's geometry types, PostGIS' geometry and geography) instead of the
+// driver's default: []byte of mysql's internal format, which is the SRID
+// followed by the WKB, and []byte of the hex EWKB text for postgres.
+var SpatialType, NullSpatialType string
+
+// mysqlSpatialTypes are mysql's geometry types
+var mysqlSpatialTypes = map[string]struct{}{
+	"geometry": {}, "point": {}, "linestring": {}, "polygon": {}, "multipoint": {},
+	"multilinestring": {}, "multipolygon": {}, "geometrycollection": {}, "geomcollection": {},
+}
+
+// isPostGISType checks for the PostGIS types by their udt_name
+func isPostGISType(udtName string) bool {
+	return udtName == "geometry" || udtName == "geography"
+}
+
+// setSpatialType sets the Go type of a spatial column to the driver's
+// default, or to SpatialType when it's set.
+func setSpatialType(c *bdb.Column, typ, nullType string) {
+	if c.Nullable {
+		c.Type = nullType
+		if len(NullSpatialType) != 0 {
+			c.Type = NullSpatialType
+		}
+		return
+	}
+
+	c.Type = typ
+	if len(SpatialType) != 0 {
+		c.Type = SpatialType
+	}
+}
+
+// spatialColumns fills in the SRID and geometry type of the PostGIS columns
+// from the geometry_columns and geography_columns views. Only call it when
+// there are PostGIS columns, the views don't exist without the extension.
+func (p *PostgresDriver) spatialColumns(schema, tableName string, columns []bdb.Column) error {
+	query := `
+	select f_geometry_column, srid, type
+	from geometry_columns
+	where f_table_schema = $1 and f_table_name = $2
+	union all
+	select f_geography_column, srid, type
+	from geography_columns
+	where f_table_schema = $1 and f_table_name = $2
+	`
+
+	rows, err := p.conn().Query(query, schema, tableName)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var name, subtype string
+		var srid int
+		if err = rows.Scan(&name, &srid, &subtype); err != nil {
+			return err
+		}
+
+		for i := range columns {
+			if columns[i].Name == name {
+				columns[i].SpatialSRID = srid
+				columns[i].SpatialSubtype = subtype
+			}
+		}
+	}
+
+	return rows.Err()
+}
@@ -0,0 +1,90 @@
+package drivers
+
+import (
+	"testing"
+
+	"github.com/volatiletech/sqlboiler/bdb"
+	"gopkg.in/DATA-DOG/go-sqlmock.v1"
+)
+
+func TestSpatialTypes(t *testing.T) {
+	tests := []struct {
+		Driver   bdb.Interface
+		Col      bdb.Column
+		Type     string
+		NullType string
+		DBType   string
+	}{
+		{&MySQLDriver{}, bdb.Column{DBType: "point"}, "[]byte", "null.Bytes", "point"},
+		{&MySQLDriver{}, bdb.Column{DBType: "geometrycollection"}, "[]byte", "null.Bytes", "geometrycollection"},
+		{&PostgresDriver{}, bdb.Column{DBType: "USER-DEFINED", UDTName: "geometry"}, "[]byte", "null.Bytes", "geometry"},
+		{&PostgresDriver{}, bdb.Column{DBType: "USER-DEFINED", UDTName: "geography"}, "[]byte", "null.Bytes", "geography"},
+	}
+
+	for i, test := range tests {
+		c := test.Driver.TranslateColumnType(test.Col)
+		if c.Type != test.Type || c.DBType != test.DBType {
+			t.Errorf("%d) want %s (%s), got %s (%s)", i, test.Type, test.DBType, c.Type, c.DBType)
+		}
+
+		test.Col.Nullable = true
+		if c = test.Driver.TranslateColumnType(test.Col); c.Type != test.NullType {
+			t.Errorf("%d) want nullable %s, got %s", i, test.NullType, c.Type)
+		}
+	}
+
+	SpatialType, NullSpatialType = "geom.T", "geom.NullT"
+	defer func() { SpatialType, NullSpatialType = "", "" }()
+
+	for i, test := range tests {
+		if c := test.Driver.TranslateColumnType(test.Col); c.Type != "geom.T" {
+			t.Errorf("%d) want the spatial type, got %s", i, c.Type)
+		}
+
+		test.Col.Nullable = true
+		if c := test.Driver.TranslateColumnType(test.Col); c.Type != "geom.NullT" {
+			t.Errorf("%d) want the nullable spatial type, got %s", i, c.Type)
+		}
+	}
+}
+
+func TestPostgresSpatialColumns(t *testing.T) {
+	t.Parallel()
+
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	mock.ExpectQuery(`from geometry_columns`).
+		WithArgs("public", "stores").
+		WillReturnRows(sqlmock.NewRows([]string{"f_geometry_column", "srid", "type"}).
+			AddRow("location", 4326, "POINT").
+			AddRow("area", 0, "GEOMETRY"))
+
+	columns := []bdb.Column{
+		{Name: "id", DBType: "integer"},
+		{Name: "location", DBType: "USER-DEFINED", UDTName: "geography"},
+		{Name: "area", DBType: "USER-DEFINED", UDTName: "geometry"},
+	}
+
+	p := &PostgresDriver{dbConn: db}
+	if err := p.spatialColumns("public", "stores", columns); err != nil {
+		t.Fatal(err)
+	}
+
+	if c := columns[1]; c.SpatialSRID != 4326 || c.SpatialSubtype != "POINT" {
+		t.Errorf("location was wrong: %#v", c)
+	}
+	if c := columns[2]; c.SpatialSRID != 0 || c.SpatialSubtype != "GEOMETRY" {
+		t.Errorf("area was wrong: %#v", c)
+	}
+	if c := columns[0]; c.SpatialSRID != 0 || len(c.SpatialSubtype) != 0 {
+		t.Errorf("id should not be spatial: %#v", c)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
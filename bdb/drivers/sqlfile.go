@@ -0,0 +1,852 @@
+package drivers
+
+import (
+	"fmt"
+	"io/ioutil"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/volatiletech/sqlboiler/bdb"
+	"github.com/volatiletech/sqlboiler/strmangle"
+)
+
+// SQLFileDriver reads the schema from a file of postgres DDL, eg: the output
+// of pg_dump --schema-only, instead of a live database. It understands the
+// statements that make up tables: CREATE TABLE, ALTER TABLE ... ADD
+// CONSTRAINT, CREATE TYPE ... AS ENUM, CREATE UNIQUE INDEX and COMMENT ON
+// COLUMN. It's a best effort parser, other statements are skipped and listed
+// in Unsupported so they can be reported.
+type SQLFileDriver struct {
+	path string
+
+	tables map[string]*sqlFileTable
+	enums  map[string][]string
+
+	// Unsupported are the statements that were skipped because they aren't
+	// understood, shortened to their first line. It's filled in by Open.
+	Unsupported []string
+}
+
+// sqlFileTable is a table read from the file
+type sqlFileTable struct {
+	schema  string
+	name    string
+	columns []bdb.Column
+	pkey    *bdb.PrimaryKey
+	fkeys   []bdb.ForeignKey
+	uniques [][]string
+}
+
+// NewSQLFileDriver returns a pointer to a SQLFileDriver object that reads
+// the schema from the file at path. The file is only read by
+// SQLFileDriver.Open().
+func NewSQLFileDriver(path string) *SQLFileDriver {
+	driver := SQLFileDriver{
+		path: path,
+	}
+
+	return &driver
+}
+
+// Open reads and parses the schema file
+func (s *SQLFileDriver) Open() error {
+	src, err := ioutil.ReadFile(s.path)
+	if err != nil {
+		return err
+	}
+
+	return s.parse(string(src))
+}
+
+// Close does nothing, there is no connection to close
+func (s *SQLFileDriver) Close() {}
+
+// String returns the path of the schema file
+func (s *SQLFileDriver) String() string {
+	return s.path
+}
+
+// parse reads the statements of src into the driver's tables
+func (s *SQLFileDriver) parse(src string) error {
+	s.tables = map[string]*sqlFileTable{}
+	s.enums = map[string][]string{}
+	s.Unsupported = nil
+
+	statements, err := lexSQL(src)
+	if err != nil {
+		return errors.Wrapf(err, "unable to read %s", s.path)
+	}
+
+	for _, stmt := range statements {
+		if !s.statement(&sqlParser{toks: stmt}) {
+			s.unsupported(src[stmt[0].start:stmt[len(stmt)-1].end])
+		}
+	}
+
+	s.resolveForeignKeys()
+	return nil
+}
+
+// unsupported records a statement that was skipped by its first line
+func (s *SQLFileDriver) unsupported(stmt string) {
+	if i := strings.IndexByte(stmt, '\n'); i >= 0 {
+		stmt = strings.TrimSpace(stmt[:i]) + " ..."
+	}
+	s.Unsupported = append(s.Unsupported, stmt)
+}
+
+// statement applies a single statement, it returns false if the statement
+// isn't understood. Statements that don't change the tables, eg: SET, are
+// understood.
+func (s *SQLFileDriver) statement(p *sqlParser) bool {
+	switch {
+	case p.acceptWords("create"):
+		return s.create(p)
+	case p.acceptWords("alter", "table"):
+		return s.alterTable(p)
+	case p.acceptWords("comment", "on"):
+		return s.comment(p)
+	case p.isWord("set"), p.isWord("select"), p.isWord("grant"), p.isWord("revoke"),
+		p.isWord("begin"), p.isWord("commit"), p.isWord("alter", "sequence"):
+		return true
+	}
+
+	return false
+}
+
+// create handles the CREATE statements
+func (s *SQLFileDriver) create(p *sqlParser) bool {
+	p.acceptWords("or", "replace")
+	// GLOBAL and LOCAL are noise words, the persistence isn't kept
+	p.acceptAny("global", "local")
+	p.acceptAny("temporary", "temp", "unlogged")
+
+	switch {
+	case p.acceptWords("table"):
+		return s.createTable(p)
+	case p.acceptWords("type"):
+		return s.createType(p)
+	case p.acceptWords("unique", "index"):
+		return s.createUniqueIndex(p)
+	case p.isWord("index"), p.isWord("sequence"), p.isWord("extension"), p.isWord("schema"):
+		return true
+	}
+
+	return false
+}
+
+// createTable handles CREATE TABLE [IF NOT EXISTS] name ( elements ), any
+// options after the elements are ignored.
+func (s *SQLFileDriver) createTable(p *sqlParser) bool {
+	p.acceptWords("if", "not", "exists")
+	schema, name, ok := p.qualifiedName()
+	if !ok || !p.isSymbol("(") {
+		return false
+	}
+
+	t := &sqlFileTable{schema: schema, name: name}
+	s.tables[schema+"."+name] = t
+
+	supported := true
+	for _, element := range p.group() {
+		if !s.tableElement(t, &sqlParser{toks: element}) {
+			supported = false
+		}
+	}
+
+	return supported
+}
+
+// tableElement adds a column or table constraint of a CREATE TABLE
+func (s *SQLFileDriver) tableElement(t *sqlFileTable, p *sqlParser) bool {
+	switch {
+	case p.isWord("constraint"), p.isWord("primary", "key"), p.isWord("unique"),
+		p.isWord("foreign", "key"), p.isWord("check"), p.isWord("exclude"):
+		return s.tableConstraint(t, p)
+	case p.isWord("like"):
+		return false
+	}
+
+	return s.column(t, p)
+}
+
+// column adds a column definition: name type [constraints]
+func (s *SQLFileDriver) column(t *sqlFileTable, p *sqlParser) bool {
+	name, ok := p.ident()
+	if !ok {
+		return false
+	}
+
+	c, serial, ok := s.columnType(p)
+	if !ok {
+		return false
+	}
+	c.Name = name
+	c.Nullable = true
+	if serial {
+		s.setSequence(&c, fmt.Sprintf("%s_%s_seq", t.name, name))
+	}
+
+	var constraint string
+	for !p.done() {
+		switch {
+		case p.acceptWords("constraint"):
+			constraint, _ = p.ident()
+			continue
+		case p.acceptWords("not", "null"):
+			c.Nullable = false
+		case p.acceptWords("null"):
+			c.Nullable = true
+		case p.acceptWords("default"):
+			s.setDefault(&c, p.expression())
+		case p.acceptWords("primary", "key"):
+			c.Nullable = false
+			t.pkey = &bdb.PrimaryKey{Name: nameOr(constraint, t.name+"_pkey"), Columns: []string{name}}
+		case p.acceptWords("unique"):
+			t.uniques = append(t.uniques, []string{name})
+		case p.acceptWords("references"):
+			fkey, ok := s.references(t, p, nameOr(constraint, fmt.Sprintf("%s_%s_fkey", t.name, name)), []string{name})
+			if !ok {
+				return false
+			}
+			t.fkeys = append(t.fkeys, fkey...)
+		case p.acceptWords("check"):
+			p.group()
+		case p.acceptWords("generated"):
+			if !s.generated(t, &c, p) {
+				return false
+			}
+		case p.acceptWords("collate"):
+			p.ident()
+		case p.acceptWords("deferrable"), p.acceptWords("not", "deferrable"):
+		case p.acceptWords("initially"):
+			p.next()
+		default:
+			return false
+		}
+		constraint = ""
+	}
+
+	t.columns = append(t.columns, c)
+	return true
+}
+
+// generated handles GENERATED ... AS IDENTITY and GENERATED ALWAYS AS
+// (expression) STORED, after the GENERATED.
+func (s *SQLFileDriver) generated(t *sqlFileTable, c *bdb.Column, p *sqlParser) bool {
+	identity := "ALWAYS"
+	if !p.acceptWords("always") {
+		if !p.acceptWords("by", "default") {
+			return false
+		}
+		identity = "BY DEFAULT"
+	}
+	if !p.acceptWords("as") {
+		return false
+	}
+
+	if p.acceptWords("identity") {
+		// The sequence options don't matter
+		if p.isSymbol("(") {
+			p.group()
+		}
+		// Identity columns are NOT NULL and have no default, the way
+		// postgres reports them
+		c.Identity = identity
+		c.SequenceName = fmt.Sprintf("%s.%s_%s_seq", t.schema, t.name, c.Name)
+		c.IsAutoIncrement = true
+		c.AutoGenerated = identity == "ALWAYS"
+		c.Nullable = false
+		return true
+	}
+
+	if !p.isSymbol("(") {
+		return false
+	}
+	start := p.pos
+	p.group()
+	c.GenerationExpression = joinSQL(p.toks[start:p.pos])
+	p.acceptWords("stored")
+	c.AutoGenerated = true
+	return true
+}
+
+// setSequence makes the column take its value from a sequence, the way
+// postgres reports serial columns.
+func (s *SQLFileDriver) setSequence(c *bdb.Column, sequence string) {
+	c.Default = fmt.Sprintf("nextval('%s'::regclass)", sequence)
+	c.SequenceName = sequence
+	c.IsAutoIncrement = true
+}
+
+// setDefault sets a column default given in the file
+func (s *SQLFileDriver) setDefault(c *bdb.Column, def string) {
+	if strings.EqualFold(def, "null") {
+		c.Default = ""
+		return
+	}
+
+	c.Default = def
+	c.SequenceName = postgresSequenceName(def)
+	c.IsAutoIncrement = len(c.SequenceName) != 0
+}
+
+// tableConstraint adds a table constraint, CHECK and EXCLUDE constraints
+// are understood but not kept.
+func (s *SQLFileDriver) tableConstraint(t *sqlFileTable, p *sqlParser) bool {
+	var name string
+	if p.acceptWords("constraint") {
+		name, _ = p.ident()
+	}
+
+	switch {
+	case p.acceptWords("primary", "key"):
+		columns, ok := p.identList()
+		if !ok {
+			return false
+		}
+		t.pkey = &bdb.PrimaryKey{Name: nameOr(name, t.name+"_pkey"), Columns: columns}
+		for i, c := range t.columns {
+			if strmangle.SetInclude(c.Name, columns) {
+				t.columns[i].Nullable = false
+			}
+		}
+	case p.acceptWords("unique"):
+		columns, ok := p.identList()
+		if !ok {
+			return false
+		}
+		t.uniques = append(t.uniques, columns)
+	case p.acceptWords("foreign", "key"):
+		columns, ok := p.identList()
+		if !ok || !p.acceptWords("references") {
+			return false
+		}
+		fkeys, ok := s.references(t, p, nameOr(name, fmt.Sprintf("%s_%s_fkey", t.name, columns[0])), columns)
+		if !ok {
+			return false
+		}
+		t.fkeys = append(t.fkeys, fkeys...)
+	case p.acceptWords("check"), p.acceptWords("exclude"):
+		return true
+	default:
+		return false
+	}
+
+	return true
+}
+
+// references reads REFERENCES table [(columns)] [MATCH type] [actions],
+// after the REFERENCES. Without the columns the foreign key is to the
+// primary key, which is resolved once the whole file is read.
+func (s *SQLFileDriver) references(t *sqlFileTable, p *sqlParser, name string, columns []string) ([]bdb.ForeignKey, bool) {
+	_, foreignTable, ok := p.qualifiedName()
+	if !ok {
+		return nil, false
+	}
+
+	var foreignColumns []string
+	if p.isSymbol("(") {
+		if foreignColumns, ok = p.identList(); !ok || len(foreignColumns) != len(columns) {
+			return nil, false
+		}
+	}
+
+	match, notValid := bdb.MatchSimple, false
+	for !p.done() {
+		switch {
+		case p.acceptWords("match"):
+			match = matchType(p.next().val)
+		case p.acceptWords("on", "delete"), p.acceptWords("on", "update"):
+			if !p.acceptWords("no", "action") && !p.acceptWords("set", "null") && !p.acceptWords("set", "default") {
+				p.next()
+			}
+		case p.acceptWords("deferrable"), p.acceptWords("not", "deferrable"):
+		case p.acceptWords("initially"):
+			p.next()
+		case p.acceptWords("not", "valid"):
+			notValid = true
+		default:
+			// The rest belongs to the column definition
+			return s.foreignKeys(t, name, columns, foreignTable, foreignColumns, match, notValid), true
+		}
+	}
+
+	return s.foreignKeys(t, name, columns, foreignTable, foreignColumns, match, notValid), true
+}
+
+// foreignKeys makes a foreign key for each of the columns
+func (s *SQLFileDriver) foreignKeys(t *sqlFileTable, name string, columns []string, foreignTable string, foreignColumns []string, match string, notValid bool) []bdb.ForeignKey {
+	fkeys := make([]bdb.ForeignKey, len(columns))
+	for i, c := range columns {
+		fkeys[i] = bdb.ForeignKey{
+			Schema:       t.schema,
+			Table:        t.name,
+			Name:         name,
+			Column:       c,
+			ForeignTable: foreignTable,
+			MatchType:    match,
+			NotValid:     notValid,
+		}
+		if foreignColumns != nil {
+			fkeys[i].ForeignColumn = foreignColumns[i]
+		}
+	}
+
+	return fkeys
+}
+
+// resolveForeignKeys points the foreign keys that didn't name the foreign
+// columns at the primary key of the foreign table.
+func (s *SQLFileDriver) resolveForeignKeys() {
+	for _, t := range s.tables {
+		for i, fkey := range t.fkeys {
+			if len(fkey.ForeignColumn) != 0 {
+				continue
+			}
+
+			foreign, ok := s.tables[t.schema+"."+fkey.ForeignTable]
+			if !ok || foreign.pkey == nil {
+				s.Unsupported = append(s.Unsupported, fmt.Sprintf("foreign key %s: %s has no primary key", fkey.Name, fkey.ForeignTable))
+				continue
+			}
+
+			// The columns of a composite key are in the same order as the
+			// primary key, the first is always at index 0 of the constraint
+			n := 0
+			for j := i - 1; j >= 0 && t.fkeys[j].Name == fkey.Name; j-- {
+				n++
+			}
+			if n < len(foreign.pkey.Columns) {
+				t.fkeys[i].ForeignColumn = foreign.pkey.Columns[n]
+			}
+		}
+	}
+}
+
+// alterTable handles ALTER TABLE [IF EXISTS] [ONLY] name actions, the
+// actions that add constraints or columns and change defaults are
+// understood. OWNER TO is understood but not kept.
+func (s *SQLFileDriver) alterTable(p *sqlParser) bool {
+	p.acceptWords("if", "exists")
+	p.acceptWords("only")
+	schema, name, ok := p.qualifiedName()
+	if !ok {
+		return false
+	}
+
+	t, ok := s.tables[schema+"."+name]
+	if !ok {
+		return false
+	}
+
+	supported := true
+	for _, action := range splitSQL(p.rest(), ",") {
+		if !s.alterAction(t, &sqlParser{toks: action}) {
+			supported = false
+		}
+	}
+
+	return supported
+}
+
+// alterAction applies a single action of an ALTER TABLE
+func (s *SQLFileDriver) alterAction(t *sqlFileTable, p *sqlParser) bool {
+	switch {
+	case p.isWord("owner", "to"):
+		return true
+	case p.isWord("add", "constraint"), p.isWord("add", "primary"), p.isWord("add", "unique"), p.isWord("add", "foreign"),
+		p.isWord("add", "check"), p.isWord("add", "exclude"):
+		p.next()
+		return s.tableConstraint(t, p)
+	case p.acceptWords("add"):
+		p.acceptWords("column")
+		p.acceptWords("if", "not", "exists")
+		return s.column(t, p)
+	case p.acceptWords("alter"):
+		p.acceptWords("column")
+		name, ok := p.ident()
+		if !ok {
+			return false
+		}
+
+		c := findSQLFileColumn(t, name)
+		if c == nil {
+			return false
+		}
+
+		switch {
+		case p.acceptWords("set", "default"):
+			s.setDefault(c, p.expression())
+		case p.acceptWords("drop", "default"):
+			s.setDefault(c, "null")
+		case p.acceptWords("set", "not", "null"):
+			c.Nullable = false
+		case p.acceptWords("drop", "not", "null"):
+			c.Nullable = true
+		case p.acceptWords("add", "generated"):
+			return s.generated(t, c, p)
+		default:
+			return false
+		}
+
+		return true
+	}
+
+	return false
+}
+
+// createType handles CREATE TYPE name AS ENUM ( labels ), other types
+// aren't understood.
+func (s *SQLFileDriver) createType(p *sqlParser) bool {
+	_, name, ok := p.qualifiedName()
+	if !ok || !p.acceptWords("as", "enum") {
+		return false
+	}
+
+	var labels []string
+	for _, label := range p.group() {
+		if len(label) != 1 || label[0].kind != sqlTokenString {
+			return false
+		}
+		labels = append(labels, label[0].val)
+	}
+
+	s.enums[name] = labels
+	return true
+}
+
+// createUniqueIndex handles CREATE UNIQUE INDEX, a unique index of a single
+// column without a predicate makes the column unique like it does in
+// postgres.
+func (s *SQLFileDriver) createUniqueIndex(p *sqlParser) bool {
+	p.acceptWords("concurrently")
+	p.acceptWords("if", "not", "exists")
+	if !p.isWord("on") {
+		p.ident()
+	}
+	if !p.acceptWords("on") {
+		return false
+	}
+	p.acceptWords("only")
+
+	schema, name, ok := p.qualifiedName()
+	if !ok {
+		return false
+	}
+	t, ok := s.tables[schema+"."+name]
+	if !ok {
+		return false
+	}
+
+	if p.acceptWords("using") {
+		p.ident()
+	}
+	columns, ok := p.identList()
+	if ok && p.done() {
+		t.uniques = append(t.uniques, columns)
+	}
+
+	// Expression and partial indexes are fine, they don't make a column unique
+	return true
+}
+
+// comment handles COMMENT ON COLUMN [schema.]table.column IS 'comment',
+// comments on anything else are understood but not kept.
+func (s *SQLFileDriver) comment(p *sqlParser) bool {
+	if !p.acceptWords("column") {
+		return true
+	}
+
+	var names []string
+	for {
+		name, ok := p.ident()
+		if !ok {
+			return false
+		}
+		names = append(names, name)
+		if !p.acceptSymbol(".") {
+			break
+		}
+	}
+	if len(names) < 2 || !p.acceptWords("is") {
+		return false
+	}
+
+	schema := "public"
+	if len(names) == 3 {
+		schema = names[0]
+	}
+	t, ok := s.tables[schema+"."+names[len(names)-2]]
+	if !ok {
+		return false
+	}
+	c := findSQLFileColumn(t, names[len(names)-1])
+	if c == nil {
+		return false
+	}
+
+	if comment := p.next(); comment.kind == sqlTokenString {
+		c.Comment = comment.val
+	}
+	return true
+}
+
+// findSQLFileColumn returns the column of t or nil if it doesn't have it
+func findSQLFileColumn(t *sqlFileTable, name string) *bdb.Column {
+	for i := range t.columns {
+		if t.columns[i].Name == name {
+			return &t.columns[i]
+		}
+	}
+
+	return nil
+}
+
+// nameOr returns name, or def if name is empty
+func nameOr(name, def string) string {
+	if len(name) != 0 {
+		return name
+	}
+	return def
+}
+
+// sqlFileTypes maps the postgres type names and aliases to the names
+// information_schema.columns uses, which PostgresDriver.TranslateColumnType
+// expects. The serial types are the integers they're made from.
+var sqlFileTypes = map[string]string{
+	"smallint": "smallint", "int2": "smallint", "smallserial": "smallint", "serial2": "smallint",
+	"integer": "integer", "int": "integer", "int4": "integer", "serial": "integer", "serial4": "integer",
+	"bigint": "bigint", "int8": "bigint", "bigserial": "bigint", "serial8": "bigint",
+	"numeric": "numeric", "decimal": "numeric", "money": "money",
+	"real": "real", "float4": "real", "double precision": "double precision", "float8": "double precision", "float": "double precision",
+	"character varying": "character varying", "varchar": "character varying",
+	"character": "character", "char": "character", "bpchar": "character",
+	"text": "text", "bytea": "bytea", "boolean": "boolean", "bool": "boolean",
+	"date": "date", "time": "time", "time without time zone": "time", "interval": "interval",
+	"timestamp": "timestamp without time zone", "timestamp without time zone": "timestamp without time zone",
+	"timestamptz": "timestamp with time zone", "timestamp with time zone": "timestamp with time zone",
+	"bit": "bit", "bit varying": "bit varying", "varbit": "bit varying",
+	"uuid": "uuid", "json": "json", "jsonb": "jsonb", "xml": "xml",
+	"cidr": "cidr", "inet": "inet", "macaddr": "macaddr", "macaddr8": "macaddr8",
+	"tsvector": "tsvector", "tsquery": "tsquery", "pg_lsn": "pg_lsn", "jsonpath": "jsonpath",
+	"point": "point", "line": "line", "lseg": "lseg", "box": "box", "path": "path", "polygon": "polygon", "circle": "circle",
+	"int4range": "int4range", "int8range": "int8range", "numrange": "numrange",
+	"tsrange": "tsrange", "tstzrange": "tstzrange", "daterange": "daterange",
+}
+
+// sqlFileTypeStop are the words that end the type of a column definition
+var sqlFileTypeStop = map[string]struct{}{
+	"not": {}, "null": {}, "default": {}, "primary": {}, "unique": {}, "references": {},
+	"check": {}, "constraint": {}, "generated": {}, "collate": {},
+}
+
+// columnType reads the type of a column definition into a Column the way
+// the postgres driver's Columns would return it, serial is true for the
+// serial types.
+func (s *SQLFileDriver) columnType(p *sqlParser) (c bdb.Column, serial bool, ok bool) {
+	var words []string
+	var mods []int
+	var array bool
+
+	for !p.done() {
+		tok := p.peek()
+		switch {
+		case tok.kind == sqlTokenWord && isSQLFileTypeStop(tok.val):
+			return s.makeColumnType(words, mods, array)
+		case tok.kind == sqlTokenSymbol && tok.val == "(":
+			for _, mod := range p.group() {
+				if n, err := strconv.Atoi(mod[0].val); err == nil {
+					mods = append(mods, n)
+				}
+			}
+		case tok.kind == sqlTokenSymbol && tok.val == "[":
+			for !p.done() && p.next().val != "]" {
+			}
+			array = true
+		case tok.kind == sqlTokenSymbol && tok.val == "." && len(words) != 0:
+			// A schema qualified type, the schema isn't kept
+			p.next()
+			words[len(words)-1], _ = p.ident()
+		case tok.kind == sqlTokenWord && tok.val == "array":
+			p.next()
+			array = true
+		case tok.kind == sqlTokenWord:
+			words = append(words, p.next().val)
+		case tok.kind == sqlTokenQuoted:
+			// "char" is a type of its own, other quoted names are user types
+			if p.next().val == "char" {
+				words = append(words, `"char"`)
+			} else {
+				words = append(words, tok.val)
+			}
+		default:
+			return c, false, false
+		}
+	}
+
+	return s.makeColumnType(words, mods, array)
+}
+
+// isSQLFileTypeStop checks if a word ends a column's type
+func isSQLFileTypeStop(word string) bool {
+	_, ok := sqlFileTypeStop[word]
+	return ok
+}
+
+// makeColumnType makes the column of a type read by columnType
+func (s *SQLFileDriver) makeColumnType(words []string, mods []int, array bool) (bdb.Column, bool, bool) {
+	if len(words) == 0 {
+		return bdb.Column{}, false, false
+	}
+
+	name := strings.Join(words, " ")
+	// The fields of an interval, eg: interval day to second, don't change it
+	if words[0] == "interval" {
+		name = "interval"
+	}
+	serial := strings.Contains(name, "serial")
+
+	var c bdb.Column
+	dbType, known := sqlFileTypes[name]
+	switch {
+	case name == `"char"`:
+		dbType = name
+	case name == "float" && len(mods) != 0 && mods[0] <= 24:
+		dbType = "real"
+	case known:
+	case s.enums[name] != nil:
+		dbType = fmt.Sprintf("enum.%s('%s')", name, strings.Join(s.enums[name], "','"))
+		c.UDTName = name
+	default:
+		dbType = "USER-DEFINED"
+		c.UDTName = name
+	}
+
+	if dbType == "numeric" && len(mods) != 0 {
+		c.NumericPrecision = mods[0]
+		if len(mods) > 1 {
+			c.NumericScale = mods[1]
+		}
+	}
+
+	if array {
+		c.ArrType = &dbType
+		c.DBType = "ARRAY"
+		return c, false, true
+	}
+
+	c.DBType = dbType
+	return c, serial, true
+}
+
+// TableNames returns the tables of the schema in the file, it uses a
+// whitelist and blacklist.
+func (s *SQLFileDriver) TableNames(schema string, whitelist, blacklist []string) ([]string, error) {
+	var names []string
+	for _, t := range s.tables {
+		if t.schema != schema {
+			continue
+		}
+		if len(whitelist) > 0 && !strmangle.SetInclude(t.name, whitelist) {
+			continue
+		}
+		if len(whitelist) == 0 && strmangle.SetInclude(t.name, blacklist) {
+			continue
+		}
+		names = append(names, t.name)
+	}
+
+	sort.Strings(names)
+	return names, nil
+}
+
+// table returns the table from the file or an error if it isn't there
+func (s *SQLFileDriver) table(schema, tableName string) (*sqlFileTable, error) {
+	t, ok := s.tables[schema+"."+tableName]
+	if !ok {
+		return nil, errors.Errorf("table %s.%s is not in %s", schema, tableName, s.path)
+	}
+
+	return t, nil
+}
+
+// Columns returns the columns of a table as they were read from the file, a
+// column is unique if it has a unique constraint or index of its own.
+func (s *SQLFileDriver) Columns(schema, tableName string) ([]bdb.Column, error) {
+	t, err := s.table(schema, tableName)
+	if err != nil {
+		return nil, err
+	}
+
+	unique := map[string]bool{}
+	for _, u := range t.uniques {
+		if len(u) == 1 {
+			unique[u[0]] = true
+		}
+	}
+	if t.pkey != nil && len(t.pkey.Columns) == 1 {
+		unique[t.pkey.Columns[0]] = true
+	}
+
+	columns := make([]bdb.Column, len(t.columns))
+	for i, c := range t.columns {
+		c.Unique = unique[c.Name]
+		columns[i] = c
+	}
+
+	return columns, nil
+}
+
+// PrimaryKeyInfo returns the primary key of a table
+func (s *SQLFileDriver) PrimaryKeyInfo(schema, tableName string) (*bdb.PrimaryKey, error) {
+	t, err := s.table(schema, tableName)
+	if err != nil || t.pkey == nil {
+		return nil, err
+	}
+
+	pkey := *t.pkey
+	return &pkey, nil
+}
+
+// ForeignKeyInfo returns the foreign keys of a table
+func (s *SQLFileDriver) ForeignKeyInfo(schema, tableName string) ([]bdb.ForeignKey, error) {
+	t, err := s.table(schema, tableName)
+	if err != nil {
+		return nil, err
+	}
+
+	return append([]bdb.ForeignKey(nil), t.fkeys...), nil
+}
+
+// TranslateColumnType converts the types like the postgres driver does
+func (s *SQLFileDriver) TranslateColumnType(c bdb.Column) bdb.Column {
+	return (&PostgresDriver{}).TranslateColumnType(c)
+}
+
+// UseLastInsertID returns false for postgres
+func (s *SQLFileDriver) UseLastInsertID() bool {
+	return false
+}
+
+// UseTopClause returns false for postgres
+func (s *SQLFileDriver) UseTopClause() bool {
+	return false
+}
+
+// RightQuote is the quoting character for the right side of the identifier
+func (s *SQLFileDriver) RightQuote() byte {
+	return '"'
+}
+
+// LeftQuote is the quoting character for the left side of the identifier
+func (s *SQLFileDriver) LeftQuote() byte {
+	return '"'
+}
+
+// IndexPlaceholders returns true for postgres
+func (s *SQLFileDriver) IndexPlaceholders() bool {
+	return true
+}
@@ -0,0 +1,394 @@
+package drivers
+
+import (
+	"bytes"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// The kinds of sqlToken
+const (
+	sqlTokenWord   = iota // an unquoted identifier or keyword, lowercased
+	sqlTokenQuoted        // a "quoted identifier"
+	sqlTokenString        // a 'string' or $$dollar quoted$$ string
+	sqlTokenNumber
+	sqlTokenSymbol
+)
+
+// sqlToken is a token of a SQL statement. val is the value, without quotes
+// for strings and identifiers, and raw is the text it was read from.
+type sqlToken struct {
+	kind  int
+	val   string
+	raw   string
+	space bool
+
+	start, end int
+}
+
+// lexSQL splits src into statements of tokens, comments are left out
+func lexSQL(src string) ([][]sqlToken, error) {
+	var statements [][]sqlToken
+	var stmt []sqlToken
+
+	space := false
+	for i := 0; i < len(src); {
+		ch := src[i]
+		start := i
+
+		switch {
+		case ch == ' ' || ch == '\t' || ch == '\n' || ch == '\r' || ch == '\f':
+			i++
+			space = true
+			continue
+		case strings.HasPrefix(src[i:], "--"):
+			for i < len(src) && src[i] != '\n' {
+				i++
+			}
+			space = true
+			continue
+		case strings.HasPrefix(src[i:], "/*"):
+			end := strings.Index(src[i+2:], "*/")
+			if end < 0 {
+				return nil, errors.New("unterminated comment")
+			}
+			i += end + 4
+			space = true
+			continue
+		case ch == ';':
+			i++
+			if len(stmt) != 0 {
+				statements = append(statements, stmt)
+				stmt = nil
+			}
+			space = true
+			continue
+		}
+
+		tok := sqlToken{space: space}
+		switch {
+		case ch == '\'' || ((ch == 'e' || ch == 'E') && i+1 < len(src) && src[i+1] == '\''):
+			escapes := ch != '\''
+			if escapes {
+				i++
+			}
+			val, n, ok := lexQuoted(src[i:], '\'', escapes)
+			if !ok {
+				return nil, errors.New("unterminated string")
+			}
+			tok.kind, tok.val = sqlTokenString, val
+			i += n
+		case ch == '"':
+			val, n, ok := lexQuoted(src[i:], '"', false)
+			if !ok {
+				return nil, errors.New("unterminated quoted identifier")
+			}
+			tok.kind, tok.val = sqlTokenQuoted, val
+			i += n
+		case ch == '$' && dollarTag(src[i:]) != "":
+			tag := dollarTag(src[i:])
+			end := strings.Index(src[i+len(tag):], tag)
+			if end < 0 {
+				return nil, errors.New("unterminated dollar quoted string")
+			}
+			tok.kind, tok.val = sqlTokenString, src[i+len(tag):i+len(tag)+end]
+			i += len(tag)*2 + end
+		case isSQLDigit(ch):
+			for i < len(src) && (isSQLDigit(src[i]) || src[i] == '.') {
+				i++
+			}
+			tok.kind, tok.val = sqlTokenNumber, src[start:i]
+		case isSQLLetter(ch):
+			for i < len(src) && (isSQLLetter(src[i]) || isSQLDigit(src[i]) || src[i] == '$') {
+				i++
+			}
+			tok.kind, tok.val = sqlTokenWord, strings.ToLower(src[start:i])
+		case strings.HasPrefix(src[i:], "::"):
+			i += 2
+			tok.kind, tok.val = sqlTokenSymbol, "::"
+		default:
+			i++
+			tok.kind, tok.val = sqlTokenSymbol, src[start:i]
+		}
+
+		tok.raw, tok.start, tok.end = src[start:i], start, i
+		stmt = append(stmt, tok)
+		space = false
+	}
+
+	if len(stmt) != 0 {
+		statements = append(statements, stmt)
+	}
+
+	return statements, nil
+}
+
+// lexQuoted reads a string quoted with q from the start of src, a doubled
+// quote is a quote. It returns the value and the length that was read.
+func lexQuoted(src string, q byte, escapes bool) (string, int, bool) {
+	var val []byte
+	for i := 1; i < len(src); i++ {
+		switch {
+		case escapes && src[i] == '\\' && i+1 < len(src):
+			i++
+			val = append(val, src[i])
+		case src[i] != q:
+			val = append(val, src[i])
+		case i+1 < len(src) && src[i+1] == q:
+			i++
+			val = append(val, q)
+		default:
+			return string(val), i + 1, true
+		}
+	}
+
+	return "", 0, false
+}
+
+// dollarTag returns the $tag$ that starts src, or an empty string if it
+// doesn't start with one.
+func dollarTag(src string) string {
+	for i := 1; i < len(src); i++ {
+		switch {
+		case src[i] == '$':
+			return src[:i+1]
+		case !isSQLLetter(src[i]) && !(i > 1 && isSQLDigit(src[i])):
+			return ""
+		}
+	}
+
+	return ""
+}
+
+func isSQLLetter(ch byte) bool {
+	return ch == '_' || (ch >= 'a' && ch <= 'z') || (ch >= 'A' && ch <= 'Z') || ch >= 0x80
+}
+
+func isSQLDigit(ch byte) bool {
+	return ch >= '0' && ch <= '9'
+}
+
+// splitSQL splits tokens on the symbol sep where it isn't in parentheses
+func splitSQL(toks []sqlToken, sep string) [][]sqlToken {
+	var parts [][]sqlToken
+
+	depth, start := 0, 0
+	for i, tok := range toks {
+		if tok.kind != sqlTokenSymbol {
+			continue
+		}
+		switch tok.val {
+		case "(", "[":
+			depth++
+		case ")", "]":
+			depth--
+		case sep:
+			if depth == 0 {
+				parts = append(parts, toks[start:i])
+				start = i + 1
+			}
+		}
+	}
+	if start < len(toks) {
+		parts = append(parts, toks[start:])
+	}
+
+	return parts
+}
+
+// sqlParser walks through the tokens of a statement
+type sqlParser struct {
+	toks []sqlToken
+	pos  int
+}
+
+// done is true when all the tokens were read
+func (p *sqlParser) done() bool {
+	return p.pos >= len(p.toks)
+}
+
+// peek returns the next token without reading it, the zero token at the end
+func (p *sqlParser) peek() sqlToken {
+	if p.done() {
+		return sqlToken{kind: -1}
+	}
+	return p.toks[p.pos]
+}
+
+// next reads the next token, the zero token at the end
+func (p *sqlParser) next() sqlToken {
+	tok := p.peek()
+	if !p.done() {
+		p.pos++
+	}
+	return tok
+}
+
+// rest reads the remaining tokens
+func (p *sqlParser) rest() []sqlToken {
+	toks := p.toks[p.pos:]
+	p.pos = len(p.toks)
+	return toks
+}
+
+// isWord checks if the next tokens are the unquoted words
+func (p *sqlParser) isWord(words ...string) bool {
+	if p.pos+len(words) > len(p.toks) {
+		return false
+	}
+	for i, w := range words {
+		if tok := p.toks[p.pos+i]; tok.kind != sqlTokenWord || tok.val != w {
+			return false
+		}
+	}
+
+	return true
+}
+
+// acceptWords reads the words if they are next
+func (p *sqlParser) acceptWords(words ...string) bool {
+	if !p.isWord(words...) {
+		return false
+	}
+	p.pos += len(words)
+	return true
+}
+
+// acceptAny reads one of the words if it is next
+func (p *sqlParser) acceptAny(words ...string) bool {
+	for _, w := range words {
+		if p.acceptWords(w) {
+			return true
+		}
+	}
+	return false
+}
+
+// isSymbol checks if the next token is the symbol
+func (p *sqlParser) isSymbol(symbol string) bool {
+	tok := p.peek()
+	return tok.kind == sqlTokenSymbol && tok.val == symbol
+}
+
+// acceptSymbol reads the symbol if it is next
+func (p *sqlParser) acceptSymbol(symbol string) bool {
+	if !p.isSymbol(symbol) {
+		return false
+	}
+	p.pos++
+	return true
+}
+
+// ident reads an identifier, quoted or not
+func (p *sqlParser) ident() (string, bool) {
+	tok := p.peek()
+	if tok.kind != sqlTokenWord && tok.kind != sqlTokenQuoted {
+		return "", false
+	}
+	p.pos++
+	return tok.val, true
+}
+
+// qualifiedName reads a [schema.]name, the schema is public when it's left
+// out like it is with postgres' default search_path.
+func (p *sqlParser) qualifiedName() (schema, name string, ok bool) {
+	if name, ok = p.ident(); !ok {
+		return "", "", false
+	}
+	if !p.acceptSymbol(".") {
+		return "public", name, true
+	}
+
+	schema = name
+	name, ok = p.ident()
+	return schema, name, ok
+}
+
+// group reads a parenthesized group and returns its elements, split on the
+// commas. It reads nothing if the next token isn't an opening parenthesis.
+func (p *sqlParser) group() [][]sqlToken {
+	if !p.isSymbol("(") {
+		return nil
+	}
+
+	start, depth := p.pos+1, 0
+	for !p.done() {
+		tok := p.next()
+		if tok.kind != sqlTokenSymbol {
+			continue
+		}
+		switch tok.val {
+		case "(":
+			depth++
+		case ")":
+			depth--
+			if depth == 0 {
+				return splitSQL(p.toks[start:p.pos-1], ",")
+			}
+		}
+	}
+
+	return splitSQL(p.toks[start:], ",")
+}
+
+// identList reads a parenthesized list of identifiers, eg: (id, "Name")
+func (p *sqlParser) identList() ([]string, bool) {
+	if !p.isSymbol("(") {
+		return nil, false
+	}
+
+	var names []string
+	for _, element := range p.group() {
+		if len(element) != 1 || (element[0].kind != sqlTokenWord && element[0].kind != sqlTokenQuoted) {
+			return nil, false
+		}
+		names = append(names, element[0].val)
+	}
+
+	return names, len(names) != 0
+}
+
+// joinSQL writes tokens back out as text, with a space between the ones
+// that were apart.
+func joinSQL(toks []sqlToken) string {
+	var b bytes.Buffer
+	for _, tok := range toks {
+		if tok.space && b.Len() != 0 {
+			b.WriteByte(' ')
+		}
+		b.WriteString(tok.raw)
+	}
+
+	return b.String()
+}
+
+// expression reads an expression up to the next column constraint and
+// returns its text, it's used for defaults.
+func (p *sqlParser) expression() string {
+	var b bytes.Buffer
+
+	depth := 0
+	for !p.done() {
+		tok := p.peek()
+		if depth == 0 && b.Len() != 0 && tok.kind == sqlTokenWord && isSQLFileTypeStop(tok.val) {
+			break
+		}
+		if tok.kind == sqlTokenSymbol {
+			switch tok.val {
+			case "(":
+				depth++
+			case ")":
+				depth--
+			}
+		}
+
+		if tok.space && b.Len() != 0 {
+			b.WriteByte(' ')
+		}
+		b.WriteString(tok.raw)
+		p.pos++
+	}
+
+	return b.String()
+}
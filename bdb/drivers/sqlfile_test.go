@@ -0,0 +1,209 @@
+package drivers
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/volatiletech/sqlboiler/bdb"
+)
+
+const testSQLFile = `
+-- Dumped from database version 14.2
+SET statement_timeout = 0;
+SELECT pg_catalog.set_config('search_path', '', false);
+
+CREATE TYPE public.mood AS ENUM ('happy', 'sad');
+
+CREATE TABLE public.users (
+    id integer NOT NULL,
+    email character varying(255) NOT NULL,
+    "Nickname" text DEFAULT 'Mc''Boiler'::text,
+    mood public.mood,
+    balance numeric(10,2) DEFAULT 0 NOT NULL,
+    tags text[],
+    created_at timestamp with time zone DEFAULT now() NOT NULL
+);
+
+ALTER TABLE public.users OWNER TO postgres;
+
+CREATE SEQUENCE public.users_id_seq AS integer START WITH 1 INCREMENT BY 1;
+ALTER SEQUENCE public.users_id_seq OWNED BY public.users.id;
+ALTER TABLE ONLY public.users ALTER COLUMN id SET DEFAULT nextval('public.users_id_seq'::regclass);
+
+create table videos (
+	id bigserial primary key,
+	user_id int references users,
+	code uuid not null unique,
+	external_id bigint generated always as identity,
+	/* the title is shown everywhere */
+	title varchar(100) not null check (length(title) > 0),
+	constraint videos_code_user unique (user_id, code)
+);
+
+ALTER TABLE ONLY public.users
+    ADD CONSTRAINT users_pkey PRIMARY KEY (id);
+
+CREATE UNIQUE INDEX users_email_idx ON public.users USING btree (email);
+CREATE INDEX videos_title_idx ON public.videos USING btree (title);
+COMMENT ON COLUMN public.users.balance IS '@gotype:decimal.Decimal';
+
+CREATE VIEW public.user_emails AS SELECT email FROM public.users;
+CREATE FUNCTION public.touch() RETURNS trigger LANGUAGE plpgsql AS $$
+BEGIN
+	NEW.updated_at = now(); -- not a statement end
+	RETURN NEW;
+END;
+$$;
+`
+
+func TestSQLFileDriver(t *testing.T) {
+	t.Parallel()
+
+	s := NewSQLFileDriver("schema.sql")
+	if err := s.parse(testSQLFile); err != nil {
+		t.Fatal(err)
+	}
+
+	names, err := s.TableNames("public", nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(names, []string{"users", "videos"}) {
+		t.Errorf("table names were wrong: %v", names)
+	}
+
+	wantUnsupported := []string{
+		"CREATE VIEW public.user_emails AS SELECT email FROM public.users",
+		"CREATE FUNCTION public.touch() RETURNS trigger LANGUAGE plpgsql AS $$ ...",
+	}
+	if !reflect.DeepEqual(s.Unsupported, wantUnsupported) {
+		t.Errorf("unsupported statements were wrong: %#v", s.Unsupported)
+	}
+
+	users, err := s.Columns("public", "users")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	textType := "text"
+	wantUsers := []bdb.Column{
+		{Name: "id", DBType: "integer", Default: "nextval('public.users_id_seq'::regclass)", SequenceName: "public.users_id_seq", IsAutoIncrement: true, Unique: true},
+		{Name: "email", DBType: "character varying", Unique: true},
+		{Name: "Nickname", DBType: "text", Default: "'Mc''Boiler'::text", Nullable: true},
+		{Name: "mood", DBType: "enum.mood('happy','sad')", UDTName: "mood", Nullable: true},
+		{Name: "balance", DBType: "numeric", Default: "0", NumericPrecision: 10, NumericScale: 2, Comment: "@gotype:decimal.Decimal"},
+		{Name: "tags", DBType: "ARRAY", ArrType: &textType, Nullable: true},
+		{Name: "created_at", DBType: "timestamp with time zone", Default: "now()"},
+	}
+	if !reflect.DeepEqual(users, wantUsers) {
+		t.Errorf("users columns were wrong:\nwant: %#v\ngot:  %#v", wantUsers, users)
+	}
+
+	pkey, err := s.PrimaryKeyInfo("public", "users")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if pkey == nil || pkey.Name != "users_pkey" || !reflect.DeepEqual(pkey.Columns, []string{"id"}) {
+		t.Errorf("users primary key was wrong: %#v", pkey)
+	}
+
+	videos, err := s.Columns("public", "videos")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	wantVideos := []bdb.Column{
+		{Name: "id", DBType: "bigint", Default: "nextval('videos_id_seq'::regclass)", SequenceName: "videos_id_seq", IsAutoIncrement: true, Unique: true},
+		{Name: "user_id", DBType: "integer", Nullable: true},
+		{Name: "code", DBType: "uuid", Unique: true},
+		{Name: "external_id", DBType: "bigint", Identity: "ALWAYS", SequenceName: "public.videos_external_id_seq", IsAutoIncrement: true, AutoGenerated: true},
+		{Name: "title", DBType: "character varying"},
+	}
+	if !reflect.DeepEqual(videos, wantVideos) {
+		t.Errorf("videos columns were wrong:\nwant: %#v\ngot:  %#v", wantVideos, videos)
+	}
+
+	fkeys, err := s.ForeignKeyInfo("public", "videos")
+	if err != nil {
+		t.Fatal(err)
+	}
+	wantFKeys := []bdb.ForeignKey{
+		{Schema: "public", Table: "videos", Name: "videos_user_id_fkey", Column: "user_id", ForeignTable: "users", ForeignColumn: "id", MatchType: bdb.MatchSimple},
+	}
+	if !reflect.DeepEqual(fkeys, wantFKeys) {
+		t.Errorf("videos foreign keys were wrong: %#v", fkeys)
+	}
+
+	if c := s.TranslateColumnType(users[3]); c.Type != "null.String" || c.UnknownType {
+		t.Errorf("enum was translated wrong: %#v", c)
+	}
+}
+
+func TestSQLFileNotValidForeignKey(t *testing.T) {
+	t.Parallel()
+
+	s := NewSQLFileDriver("schema.sql")
+	err := s.parse(`
+create table users (id int primary key);
+create table videos (id int primary key, user_id int);
+ALTER TABLE ONLY public.videos
+    ADD CONSTRAINT videos_user_id_fkey FOREIGN KEY (user_id) REFERENCES public.users(id) NOT VALID;
+`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	fkeys, err := s.ForeignKeyInfo("public", "videos")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(fkeys) != 1 || !fkeys[0].NotValid {
+		t.Errorf("the foreign key should not be validated: %#v", fkeys)
+	}
+}
+
+func TestSQLFileGeneratedColumn(t *testing.T) {
+	t.Parallel()
+
+	s := NewSQLFileDriver("schema.sql")
+	err := s.parse(`create table lines (qty int, price numeric, total numeric GENERATED ALWAYS AS (qty*price) STORED);`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	columns, err := s.Columns("public", "lines")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(columns) != 3 {
+		t.Fatalf("want 3 columns, got: %#v", columns)
+	}
+	if c := columns[2]; !c.AutoGenerated || c.GenerationExpression != "(qty*price)" {
+		t.Errorf("total was wrong: %#v", c)
+	}
+}
+
+func TestLexSQL(t *testing.T) {
+	t.Parallel()
+
+	statements, err := lexSQL(`select 'a;b', "c;d", $x$e;f$x$, E'g\';h'; select 1`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(statements) != 2 {
+		t.Fatalf("want 2 statements, got: %#v", statements)
+	}
+
+	var vals []string
+	for _, tok := range statements[0] {
+		vals = append(vals, tok.val)
+	}
+	want := []string{"select", "a;b", ",", "c;d", ",", "e;f", ",", "g';h"}
+	if !reflect.DeepEqual(vals, want) {
+		t.Errorf("want %#v, got %#v", want, vals)
+	}
+
+	if _, err := lexSQL(`select 'unterminated`); err == nil {
+		t.Error("expected an error for an unterminated string")
+	}
+}
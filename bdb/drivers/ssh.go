@@ -0,0 +1,101 @@
+package drivers
+
+import (
+	"context"
+	"database/sql/driver"
+	"io/ioutil"
+	"net"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/lib/pq"
+	"github.com/pkg/errors"
+	"golang.org/x/crypto/ssh"
+	"golang.org/x/crypto/ssh/knownhosts"
+)
+
+// SSHTunnel is a bastion host to connect to the database through, for
+// databases that can't be reached directly.
+type SSHTunnel struct {
+	// Host is the address of the SSH server, the port is 22 unless it's
+	// given, eg: bastion.example.com:2222
+	Host string
+	User string
+	// KeyFile is the private key to log in with, it can't have a passphrase.
+	KeyFile string
+	// KnownHostsFile has the keys the server's host key is checked against,
+	// it's ~/.ssh/known_hosts when empty.
+	KnownHostsFile string
+}
+
+// dial logs in to the SSH server
+func (s SSHTunnel) dial() (*ssh.Client, error) {
+	key, err := ioutil.ReadFile(s.KeyFile)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to read the ssh key")
+	}
+	signer, err := ssh.ParsePrivateKey(key)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to parse the ssh key")
+	}
+
+	knownHostsFile := s.KnownHostsFile
+	if len(knownHostsFile) == 0 {
+		knownHostsFile = filepath.Join(os.Getenv("HOME"), ".ssh", "known_hosts")
+	}
+	hostKeys, err := knownhosts.New(knownHostsFile)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to read the ssh known hosts")
+	}
+
+	host := s.Host
+	if _, _, err := net.SplitHostPort(host); err != nil {
+		host = net.JoinHostPort(host, "22")
+	}
+
+	config := &ssh.ClientConfig{
+		User:            s.User,
+		Auth:            []ssh.AuthMethod{ssh.PublicKeys(signer)},
+		HostKeyCallback: hostKeys,
+	}
+
+	client, err := ssh.Dial("tcp", host, config)
+	if err != nil {
+		return nil, errors.Wrapf(err, "unable to connect to the ssh server (%s)", host)
+	}
+
+	return client, nil
+}
+
+// sshDialer dials the database from the SSH server
+type sshDialer struct {
+	client *ssh.Client
+}
+
+// Dial for pq.Dialer
+func (s sshDialer) Dial(network, address string) (net.Conn, error) {
+	return s.client.Dial(network, address)
+}
+
+// DialTimeout for pq.Dialer, the ssh connection has no timeout for opening
+// a channel so it's ignored.
+func (s sshDialer) DialTimeout(network, address string, timeout time.Duration) (net.Conn, error) {
+	return s.client.Dial(network, address)
+}
+
+// pqDialConnector opens lib/pq connections with a dialer, for sql.OpenDB
+type pqDialConnector struct {
+	dialer pq.Dialer
+	dsn    string
+}
+
+// Connect for driver.Connector
+func (c pqDialConnector) Connect(ctx context.Context) (driver.Conn, error) {
+	return pq.DialOpen(c.dialer, c.dsn)
+}
+
+// Driver for driver.Connector
+func (c pqDialConnector) Driver() driver.Driver {
+	return &pq.Driver{}
+}
@@ -0,0 +1,29 @@
+package drivers
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSSHTunnelMissingKey(t *testing.T) {
+	t.Parallel()
+
+	tunnel := SSHTunnel{Host: "bastion.example.com", User: "deploy", KeyFile: "testdata/does_not_exist"}
+	if _, err := tunnel.dial(); err == nil || !strings.Contains(err.Error(), "unable to read the ssh key") {
+		t.Errorf("want an error reading the key, got: %v", err)
+	}
+}
+
+func TestPostgresOpenSSHOnlyPQ(t *testing.T) {
+	PostgresSSHTunnel = SSHTunnel{Host: "bastion.example.com"}
+	UsePgx = true
+	defer func() {
+		PostgresSSHTunnel = SSHTunnel{}
+		UsePgx = false
+	}()
+
+	p := NewPostgresDriver("user", "", "db", "localhost", 5432, "disable")
+	if err := p.Open(); err == nil || !strings.Contains(err.Error(), "lib/pq") {
+		t.Errorf("want an error for pgx, got: %v", err)
+	}
+}
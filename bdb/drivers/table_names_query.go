@@ -0,0 +1,85 @@
+package drivers
+
+import (
+	"database/sql"
+
+	"github.com/pkg/errors"
+)
+
+// queryTableNames runs a user's query for the table names, it must return
+// a single column without nulls.
+func queryTableNames(q queryer, query string) ([]string, error) {
+	rows, err := q.Query(query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	cols, err := rows.Columns()
+	if err != nil {
+		return nil, err
+	}
+	if len(cols) != 1 {
+		return nil, errors.Errorf("table names query must return a single column, got %d", len(cols))
+	}
+
+	var names []string
+	for rows.Next() {
+		var name sql.NullString
+		if err = rows.Scan(&name); err != nil {
+			return nil, errors.Wrap(err, "table names query must return text")
+		}
+		if !name.Valid {
+			return nil, errors.New("table names query returned a null table name")
+		}
+		names = append(names, name.String)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return names, nil
+}
+
+// TableNamesFromQuery gets the table names from query instead of the
+// default query.
+func (p *PostgresDriver) TableNamesFromQuery(query string) ([]string, error) {
+	return queryTableNames(p.conn(), query)
+}
+
+// TableNamesFromQuery gets the table names from query instead of the
+// default query.
+func (m *MySQLDriver) TableNamesFromQuery(query string) ([]string, error) {
+	return queryTableNames(m.conn(), query)
+}
+
+// TableNamesFromQuery gets the table names from query instead of the
+// default query.
+func (m *MSSQLDriver) TableNamesFromQuery(query string) ([]string, error) {
+	return queryTableNames(m.conn(), query)
+}
+
+// TableNamesFromQuery gets the table names from query instead of the
+// default query.
+func (g *GenericSQLDriver) TableNamesFromQuery(query string) ([]string, error) {
+	return queryTableNames(g.conn(), query)
+}
+
+// TableNamesFromQuery gets the table names from query instead of the
+// default query.
+func (s *SpannerDriver) TableNamesFromQuery(query string) ([]string, error) {
+	return queryTableNames(s.conn(), query)
+}
+
+// TableNamesFromQuery gets the table names from query instead of the
+// default query.
+func (c *ClickHouseDriver) TableNamesFromQuery(query string) ([]string, error) {
+	return queryTableNames(c.conn(), query)
+}
+
+// TableNamesFromQuery gets the table names from query instead of the
+// default query.
+func (v *VerticaDriver) TableNamesFromQuery(query string) ([]string, error) {
+	return queryTableNames(v.conn(), query)
+}
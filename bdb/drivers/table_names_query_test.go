@@ -0,0 +1,39 @@
+package drivers
+
+import (
+	"testing"
+
+	"gopkg.in/DATA-DOG/go-sqlmock.v1"
+)
+
+func TestTableNamesFromQuery(t *testing.T) {
+	t.Parallel()
+
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	mock.ExpectQuery(`select name from generated_tables`).
+		WillReturnRows(sqlmock.NewRows([]string{"name"}).AddRow("users").AddRow("videos"))
+	mock.ExpectQuery(`select name, owner from generated_tables`).
+		WillReturnRows(sqlmock.NewRows([]string{"name", "owner"}).AddRow("users", "bob"))
+
+	p := &PostgresDriver{dbConn: db}
+	names, err := p.TableNamesFromQuery("select name from generated_tables")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(names) != 2 || names[0] != "users" || names[1] != "videos" {
+		t.Errorf("names were wrong: %#v", names)
+	}
+
+	if _, err := p.TableNamesFromQuery("select name, owner from generated_tables"); err == nil {
+		t.Error("expected an error for a query with two columns")
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
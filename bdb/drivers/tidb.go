@@ -0,0 +1,76 @@
+package drivers
+
+import (
+	"database/sql"
+
+	"github.com/volatiletech/sqlboiler/bdb"
+)
+
+// TiDBDriver reads the schema of a TiDB database. TiDB speaks the mysql
+// protocol and has the same information_schema, so it's the MySQLDriver
+// with the TiDB only parts added.
+type TiDBDriver struct {
+	*MySQLDriver
+}
+
+// NewTiDBDriver takes the database connection details as parameters and
+// returns a pointer to a TiDBDriver object. The port defaults to TiDB's
+// 4000. Note that it is required to call TiDBDriver.Open() and
+// TiDBDriver.Close() to open and close the database connection once an
+// object has been obtained.
+func NewTiDBDriver(user, pass, dbname, host string, port int, sslmode string) *TiDBDriver {
+	if port == 0 {
+		port = 4000
+	}
+
+	driver := TiDBDriver{
+		MySQLDriver: NewMySQLDriver(user, pass, dbname, host, port, sslmode),
+	}
+
+	return &driver
+}
+
+// Columns retrieves the columns like the MySQLDriver does, a primary key
+// with AUTO_RANDOM is generated by the database like an auto_increment
+// even though its extra doesn't say so.
+func (t *TiDBDriver) Columns(schema, tableName string) ([]bdb.Column, error) {
+	columns, err := t.MySQLDriver.Columns(schema, tableName)
+	if err != nil {
+		return nil, err
+	}
+
+	autoRandom, err := t.autoRandomColumn(schema, tableName)
+	if err != nil {
+		return nil, err
+	}
+
+	for i, c := range columns {
+		if c.Name == autoRandom {
+			columns[i].Default = "auto_random"
+			columns[i].IsAutoIncrement = true
+		}
+	}
+
+	return columns, nil
+}
+
+// autoRandomColumn returns the primary key column of a table that has
+// AUTO_RANDOM, or an empty string if it doesn't. TiDB only allows it on a
+// single column primary key and says so in tidb_row_id_sharding_info.
+func (t *TiDBDriver) autoRandomColumn(schema, tableName string) (string, error) {
+	query := `
+	select kcu.column_name
+	from information_schema.tables t
+		inner join information_schema.key_column_usage kcu
+			on kcu.table_schema = t.table_schema and kcu.table_name = t.table_name and kcu.constraint_name = 'PRIMARY'
+	where t.table_schema = ? and t.table_name = ? and t.tidb_row_id_sharding_info like 'PK_AUTO_RANDOM_BITS%'
+	`
+
+	var column string
+	err := t.conn().QueryRow(query, schema, tableName).Scan(&column)
+	if err == sql.ErrNoRows {
+		return "", nil
+	}
+
+	return column, err
+}
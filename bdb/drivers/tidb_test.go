@@ -0,0 +1,43 @@
+package drivers
+
+import (
+	"testing"
+
+	"gopkg.in/DATA-DOG/go-sqlmock.v1"
+)
+
+func TestTiDBColumnsAutoRandom(t *testing.T) {
+	t.Parallel()
+
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	mock.ExpectQuery(`from information_schema.columns`).
+		WithArgs("users", "app").
+		WillReturnRows(sqlmock.NewRows([]string{"column_name", "column_type", "data_type", "column_default", "column_comment", "is_nullable", "is_unique"}).
+			AddRow("id", "bigint(20)", "bigint", nil, "", false, true).
+			AddRow("name", "varchar(255)", "varchar", nil, "", false, false))
+	mock.ExpectQuery(`tidb_row_id_sharding_info`).
+		WithArgs("app", "users").
+		WillReturnRows(sqlmock.NewRows([]string{"column_name"}).AddRow("id"))
+
+	d := &TiDBDriver{MySQLDriver: &MySQLDriver{dbConn: db}}
+	columns, err := d.Columns("app", "users")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if c := columns[0]; !c.IsAutoIncrement || c.Default != "auto_random" {
+		t.Errorf("id should be auto random: %#v", c)
+	}
+	if c := columns[1]; c.IsAutoIncrement || c.HasDefault() {
+		t.Errorf("name should not be generated: %#v", c)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
@@ -0,0 +1,122 @@
+package drivers
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// StatementTimeout is a global that is set from main.go if a user specifies
+// this flag when generating. When it isn't 0 each introspection query has
+// this long to finish, so one slow query over a huge catalog fails by itself
+// instead of using up the whole run.
+var StatementTimeout time.Duration
+
+// contextQueryer is implemented by both *sql.DB and *sql.Tx
+type contextQueryer interface {
+	Query(query string, args ...interface{}) (*sql.Rows, error)
+	QueryRow(query string, args ...interface{}) *sql.Row
+	QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row
+}
+
+// withStatementTimeout returns a queryer that runs each of q's queries with
+// StatementTimeout, or without a timeout when there is none.
+func withStatementTimeout(q contextQueryer) queryer {
+	if StatementTimeout <= 0 {
+		return dbQueryer{q: q}
+	}
+
+	return timeoutQueryer{q: q, timeout: StatementTimeout}
+}
+
+// dbQueryer runs the queries as they are.
+type dbQueryer struct {
+	q contextQueryer
+}
+
+// Query runs the query.
+func (d dbQueryer) Query(query string, args ...interface{}) (sqlRows, error) {
+	rows, err := d.q.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	return rows, nil
+}
+
+// QueryRow runs the query, an error from it is returned by the row's Scan.
+func (d dbQueryer) QueryRow(query string, args ...interface{}) sqlRow {
+	return d.q.QueryRow(query, args...)
+}
+
+// timeoutQueryer gives every query its own context with a timeout. The
+// context is cancelled once the result has been read.
+type timeoutQueryer struct {
+	q       contextQueryer
+	timeout time.Duration
+}
+
+func (t timeoutQueryer) context() (context.Context, context.CancelFunc) {
+	return context.WithTimeout(context.Background(), t.timeout)
+}
+
+// Query runs the query with the timeout.
+func (t timeoutQueryer) Query(query string, args ...interface{}) (sqlRows, error) {
+	ctx, cancel := t.context()
+	rows, err := t.q.QueryContext(ctx, query, args...)
+	if err != nil {
+		cancel()
+		if ctx.Err() == context.DeadlineExceeded {
+			return nil, errors.Wrapf(err, "query timed out after %s", t.timeout)
+		}
+		return nil, err
+	}
+
+	return cancelRows{Rows: rows, cancel: cancel}, nil
+}
+
+// QueryRow runs the query with the timeout, an error from it is returned by
+// the row's Scan.
+func (t timeoutQueryer) QueryRow(query string, args ...interface{}) sqlRow {
+	ctx, cancel := t.context()
+	return cancelRow{row: t.q.QueryRowContext(ctx, query, args...), cancel: cancel}
+}
+
+// cancelRows cancels the query's context once the last row was read or the
+// rows are closed.
+type cancelRows struct {
+	*sql.Rows
+	cancel context.CancelFunc
+}
+
+// Next prepares the next row, cancelling the context when there is none.
+func (c cancelRows) Next() bool {
+	if c.Rows.Next() {
+		return true
+	}
+
+	c.cancel()
+	return false
+}
+
+// Close closes the rows and cancels the context.
+func (c cancelRows) Close() error {
+	err := c.Rows.Close()
+	c.cancel()
+	return err
+}
+
+// cancelRow cancels the query's context once the row is scanned.
+type cancelRow struct {
+	row    *sql.Row
+	cancel context.CancelFunc
+}
+
+// Scan copies the row into dest and cancels the context.
+func (c cancelRow) Scan(dest ...interface{}) error {
+	defer c.cancel()
+	return c.row.Scan(dest...)
+}
@@ -0,0 +1,94 @@
+package drivers
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+	"testing"
+	"time"
+
+	"gopkg.in/DATA-DOG/go-sqlmock.v1"
+)
+
+// blockingQueryer never answers a query before its context is done
+type blockingQueryer struct {
+	*sql.DB
+}
+
+func (b *blockingQueryer) QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error) {
+	<-ctx.Done()
+	return nil, ctx.Err()
+}
+
+func TestStatementTimeout(t *testing.T) {
+	q := &blockingQueryer{}
+	if _, ok := withStatementTimeout(q).(dbQueryer); !ok {
+		t.Error("without a timeout the queries should run as they are")
+	}
+
+	StatementTimeout = 10 * time.Millisecond
+	defer func() { StatementTimeout = 0 }()
+
+	_, err := withStatementTimeout(q).Query("select 1")
+	if err == nil || !strings.Contains(err.Error(), "query timed out after 10ms") {
+		t.Errorf("want a timeout, got: %v", err)
+	}
+}
+
+// contextRecorder keeps the context of the last query
+type contextRecorder struct {
+	*sql.DB
+	ctx context.Context
+}
+
+func (c *contextRecorder) QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error) {
+	c.ctx = ctx
+	return c.DB.QueryContext(ctx, query, args...)
+}
+
+func (c *contextRecorder) QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row {
+	c.ctx = ctx
+	return c.DB.QueryRowContext(ctx, query, args...)
+}
+
+func TestStatementTimeoutCancel(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	mock.ExpectQuery("select 1").WillReturnRows(sqlmock.NewRows([]string{"n"}).AddRow(1))
+	mock.ExpectQuery("select 2").WillReturnRows(sqlmock.NewRows([]string{"n"}).AddRow(2))
+
+	StatementTimeout = time.Minute
+	defer func() { StatementTimeout = 0 }()
+
+	q := &contextRecorder{DB: db}
+	rows, err := withStatementTimeout(q).Query("select 1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for rows.Next() {
+		if q.ctx.Err() != nil {
+			t.Error("the context should last while the rows are read")
+		}
+	}
+	if err = rows.Err(); err != nil {
+		t.Error(err)
+	}
+	if q.ctx.Err() != context.Canceled {
+		t.Error("the context should be cancelled after the last row")
+	}
+
+	var n int
+	if err = withStatementTimeout(q).QueryRow("select 2").Scan(&n); err != nil {
+		t.Fatal(err)
+	}
+	if n != 2 {
+		t.Errorf("want 2, got %d", n)
+	}
+	if q.ctx.Err() != context.Canceled {
+		t.Error("the context should be cancelled after the row is scanned")
+	}
+}
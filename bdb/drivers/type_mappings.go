@@ -0,0 +1,224 @@
+package drivers
+
+import "github.com/volatiletech/sqlboiler/bdb"
+
+// arrayOf makes a column of an array type for the type lists
+func arrayOf(dbType, elemType string) bdb.Column {
+	return bdb.Column{DBType: dbType, ArrType: &elemType}
+}
+
+// typeColumns makes a column for each of the database types
+func typeColumns(dbTypes ...string) []bdb.Column {
+	cols := make([]bdb.Column, len(dbTypes))
+	for i, t := range dbTypes {
+		cols[i] = bdb.Column{DBType: t}
+	}
+
+	return cols
+}
+
+// postgresTypes are the types PostgresDriver.TranslateColumnType knows
+var postgresTypes = append(typeColumns(
+	"bigint", "bigserial", "integer", "serial", "smallint", "smallserial",
+	"decimal", "numeric", "double precision", "real",
+	"bit", "interval", "bit varying", "character", "money", "character varying",
+	"cidr", "inet", "macaddr", "text", "uuid", "xml", "tsvector", "tsquery",
+	`"char"`, "name", "oid", "regclass", "regcollation", "regconfig", "regdictionary", "regnamespace",
+	"regoper", "regoperator", "regproc", "regprocedure", "regrole", "regtype", "bytea", "json", "jsonb", "boolean",
+	"date", "time", "timestamp without time zone", "timestamp with time zone",
+	"pg_lsn", "txid_snapshot", "pg_snapshot", "point", "line", "lseg", "box", "path", "polygon", "circle",
+	"int4range", "int8range", "numrange", "tsrange", "tstzrange", "daterange", "macaddr8", "jsonpath",
+	"xid", "cid", "tid",
+),
+	bdb.Column{DBType: "numeric", FullDBType: "numeric(10,2)", NumericPrecision: 10, NumericScale: 2},
+	bdb.Column{DBType: "USER-DEFINED", UDTName: "geometry", FullDBType: "geometry"},
+	bdb.Column{DBType: "USER-DEFINED", UDTName: "geography", FullDBType: "geography"},
+	arrayOf("ARRAY", "bigint"),
+	arrayOf("ARRAY", "integer"),
+	arrayOf("ARRAY", "smallint"),
+	arrayOf("ARRAY", "bytea"),
+	arrayOf("ARRAY", "text"),
+	arrayOf("ARRAY", "name"),
+	arrayOf("ARRAY", "oid"),
+	arrayOf("ARRAY", "boolean"),
+	arrayOf("ARRAY", "numeric"),
+	arrayOf("ARRAY", "double precision"),
+)
+
+// mysqlTypes are the types MySQLDriver.TranslateColumnType knows, the
+// unsigned variants and tinyint(1) depend on the full type.
+var mysqlTypes = []bdb.Column{
+	{DBType: "tinyint", FullDBType: "tinyint(4)"},
+	{DBType: "tinyint", FullDBType: "tinyint(3) unsigned"},
+	{DBType: "tinyint", FullDBType: "tinyint(1)"},
+	{DBType: "smallint", FullDBType: "smallint(6)"},
+	{DBType: "smallint", FullDBType: "smallint(5) unsigned"},
+	{DBType: "mediumint", FullDBType: "mediumint(9)"},
+	{DBType: "mediumint", FullDBType: "mediumint(8) unsigned"},
+	{DBType: "int", FullDBType: "int(11)"},
+	{DBType: "int", FullDBType: "int(10) unsigned"},
+	{DBType: "bigint", FullDBType: "bigint(20)"},
+	{DBType: "bigint", FullDBType: "bigint(20) unsigned"},
+	{DBType: "float", FullDBType: "float"},
+	{DBType: "double", FullDBType: "double"},
+	{DBType: "boolean", FullDBType: "boolean"},
+	{DBType: "date", FullDBType: "date"},
+	{DBType: "datetime", FullDBType: "datetime"},
+	{DBType: "timestamp", FullDBType: "timestamp"},
+	{DBType: "time", FullDBType: "time"},
+	{DBType: "binary", FullDBType: "binary(16)"},
+	{DBType: "varbinary", FullDBType: "varbinary(255)"},
+	{DBType: "blob", FullDBType: "blob"},
+	{DBType: "json", FullDBType: "json"},
+	{DBType: "decimal", FullDBType: "decimal(10,2)"},
+	{DBType: "varchar", FullDBType: "varchar(255)"},
+	{DBType: "text", FullDBType: "text"},
+	{DBType: "geometry", FullDBType: "geometry"},
+	{DBType: "point", FullDBType: "point"},
+	{DBType: "polygon", FullDBType: "polygon"},
+}
+
+// mssqlTypes are the types MSSQLDriver.TranslateColumnType knows
+var mssqlTypes = typeColumns(
+	"tinyint", "smallint", "mediumint", "int", "bigint", "real", "float",
+	"boolean", "bit", "date", "datetime", "datetime2", "smalldatetime", "time",
+	"binary", "varbinary", "timestamp", "rowversion", "xml", "uniqueidentifier",
+	"decimal", "nvarchar", "varchar",
+)
+
+// spannerTypes are the types SpannerDriver.TranslateColumnType knows
+var spannerTypes = append(typeColumns(
+	"INT64", "FLOAT64", "BOOL", "BYTES", "DATE", "TIMESTAMP", "JSON", "STRING", "NUMERIC",
+),
+	arrayOf("ARRAY", "INT64"),
+	arrayOf("ARRAY", "FLOAT64"),
+	arrayOf("ARRAY", "BOOL"),
+	arrayOf("ARRAY", "BYTES"),
+	arrayOf("ARRAY", "STRING"),
+	arrayOf("ARRAY", "NUMERIC"),
+	arrayOf("ARRAY", "JSON"),
+	arrayOf("ARRAY", "DATE"),
+	arrayOf("ARRAY", "TIMESTAMP"),
+)
+
+// clickhouseTypes are the types ClickHouseDriver.TranslateColumnType knows
+var clickhouseTypes = append(typeColumns(
+	"Int8", "Int16", "Int32", "Int64", "UInt8", "UInt16", "UInt32", "UInt64",
+	"Float32", "Float64", "Bool", "Date", "Date32", "DateTime", "DateTime64",
+	"String", "FixedString", "UUID", "Decimal",
+),
+	arrayOf("Array", "Int64"),
+	arrayOf("Array", "Float64"),
+	arrayOf("Array", "Bool"),
+	arrayOf("Array", "String"),
+)
+
+// verticaTypes are the types VerticaDriver.TranslateColumnType knows
+var verticaTypes = typeColumns(
+	"int", "integer", "bigint", "smallint", "tinyint", "int8",
+	"float", "float8", "real", "double precision", "numeric", "decimal", "number", "money",
+	"boolean", "date", "time", "timetz", "timestamp", "timestamptz", "datetime", "smalldatetime",
+	"binary", "varbinary", "long varbinary", "bytea", "raw",
+	"char", "varchar", "long varchar", "uuid", "interval",
+)
+
+// snowflakeTypes are the types SnowflakeDriver.TranslateColumnType knows
+var snowflakeTypes = append(typeColumns(
+	"NUMBER", "DECIMAL", "NUMERIC", "INT", "INTEGER", "BIGINT", "SMALLINT", "TINYINT", "BYTEINT",
+	"FLOAT", "FLOAT4", "FLOAT8", "DOUBLE", "DOUBLE PRECISION", "REAL", "BOOLEAN",
+	"DATE", "TIME", "DATETIME", "TIMESTAMP", "TIMESTAMP_NTZ", "TIMESTAMP_LTZ", "TIMESTAMP_TZ",
+	"BINARY", "VARBINARY", "VARIANT", "ARRAY", "OBJECT",
+	"TEXT", "VARCHAR", "CHAR", "CHARACTER", "STRING", "GEOGRAPHY", "GEOMETRY",
+),
+	bdb.Column{DBType: "NUMBER", FullDBType: "NUMBER(10,2)", NumericPrecision: 10, NumericScale: 2},
+)
+
+// genericTypes are the types GenericSQLDriver.TranslateColumnType knows
+var genericTypes = typeColumns(
+	"smallint", "integer", "int", "bigint", "real", "decimal", "numeric", "double precision", "float",
+	"boolean", "date", "time", "timestamp", "time with time zone", "time without time zone",
+	"timestamp with time zone", "timestamp without time zone",
+	"binary", "binary varying", "varbinary", "binary large object", "blob",
+	"character", "character varying", "character large object",
+)
+
+// TypeMappings returns the Go types of the postgres types the driver knows.
+func (p *PostgresDriver) TypeMappings() []bdb.TypeMapping {
+	return bdb.TypeMappings(p, postgresTypes)
+}
+
+// TypeMappings returns the Go types of the mysql types the driver knows,
+// with the current TinyintAsBool setting.
+func (m *MySQLDriver) TypeMappings() []bdb.TypeMapping {
+	return bdb.TypeMappings(m, mysqlTypes)
+}
+
+// TypeMappings returns the Go types of the mssql types the driver knows.
+func (m *MSSQLDriver) TypeMappings() []bdb.TypeMapping {
+	return bdb.TypeMappings(m, mssqlTypes)
+}
+
+// TypeMappings returns the Go types of the spanner types the driver knows.
+func (s *SpannerDriver) TypeMappings() []bdb.TypeMapping {
+	return bdb.TypeMappings(s, spannerTypes)
+}
+
+// TypeMappings returns the Go types of the clickhouse types the driver knows.
+func (c *ClickHouseDriver) TypeMappings() []bdb.TypeMapping {
+	return bdb.TypeMappings(c, clickhouseTypes)
+}
+
+// TypeMappings returns the Go types of the vertica types the driver knows.
+func (v *VerticaDriver) TypeMappings() []bdb.TypeMapping {
+	return bdb.TypeMappings(v, verticaTypes)
+}
+
+// TypeMappings returns the Go types of the snowflake types the driver knows.
+func (s *SnowflakeDriver) TypeMappings() []bdb.TypeMapping {
+	return bdb.TypeMappings(s, snowflakeTypes)
+}
+
+// TypeMappings returns the Go types of the ANSI types the driver knows.
+func (g *GenericSQLDriver) TypeMappings() []bdb.TypeMapping {
+	return bdb.TypeMappings(g, genericTypes)
+}
+
+// KnownTypes returns the names of the postgres types the driver knows.
+func (p *PostgresDriver) KnownTypes() []string {
+	return bdb.KnownTypes(postgresTypes)
+}
+
+// KnownTypes returns the names of the mysql types the driver knows.
+func (m *MySQLDriver) KnownTypes() []string {
+	return bdb.KnownTypes(mysqlTypes)
+}
+
+// KnownTypes returns the names of the mssql types the driver knows.
+func (m *MSSQLDriver) KnownTypes() []string {
+	return bdb.KnownTypes(mssqlTypes)
+}
+
+// KnownTypes returns the names of the spanner types the driver knows.
+func (s *SpannerDriver) KnownTypes() []string {
+	return bdb.KnownTypes(spannerTypes)
+}
+
+// KnownTypes returns the names of the clickhouse types the driver knows.
+func (c *ClickHouseDriver) KnownTypes() []string {
+	return bdb.KnownTypes(clickhouseTypes)
+}
+
+// KnownTypes returns the names of the vertica types the driver knows.
+func (v *VerticaDriver) KnownTypes() []string {
+	return bdb.KnownTypes(verticaTypes)
+}
+
+// KnownTypes returns the names of the snowflake types the driver knows.
+func (s *SnowflakeDriver) KnownTypes() []string {
+	return bdb.KnownTypes(snowflakeTypes)
+}
+
+// KnownTypes returns the names of the ANSI types the driver knows.
+func (g *GenericSQLDriver) KnownTypes() []string {
+	return bdb.KnownTypes(genericTypes)
+}
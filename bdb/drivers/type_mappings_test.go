@@ -0,0 +1,88 @@
+package drivers
+
+import (
+	"testing"
+
+	"github.com/volatiletech/sqlboiler/bdb"
+	"github.com/volatiletech/sqlboiler/strmangle"
+)
+
+func TestTypeListsAreKnown(t *testing.T) {
+	t.Parallel()
+
+	lists := []struct {
+		db    bdb.Interface
+		types []bdb.Column
+	}{
+		{&PostgresDriver{}, postgresTypes},
+		{&MySQLDriver{}, mysqlTypes},
+		{&MSSQLDriver{}, mssqlTypes},
+		{&SpannerDriver{}, spannerTypes},
+		{&ClickHouseDriver{}, clickhouseTypes},
+		{&VerticaDriver{}, verticaTypes},
+		{&SnowflakeDriver{}, snowflakeTypes},
+		{&GenericSQLDriver{}, genericTypes},
+	}
+
+	for _, l := range lists {
+		for _, c := range l.types {
+			for _, nullable := range []bool{false, true} {
+				c.Nullable = nullable
+				if got := l.db.TranslateColumnType(c); got.UnknownType {
+					t.Errorf("%T: %s (nullable %t) should be known", l.db, c.DBType, nullable)
+				}
+			}
+		}
+	}
+}
+
+func TestKnownTypesAreTranslated(t *testing.T) {
+	t.Parallel()
+
+	drivers := []bdb.TypeLister{
+		&PostgresDriver{}, &MySQLDriver{}, &MSSQLDriver{}, &SpannerDriver{},
+		&ClickHouseDriver{}, &VerticaDriver{}, &SnowflakeDriver{}, &GenericSQLDriver{},
+	}
+
+	for _, d := range drivers {
+		known := d.KnownTypes()
+		if len(known) == 0 {
+			t.Errorf("%T should know some types", d)
+		}
+		for i := 1; i < len(known); i++ {
+			if known[i-1] >= known[i] {
+				t.Errorf("%T: types should be sorted and unique, got %s before %s", d, known[i-1], known[i])
+			}
+		}
+	}
+
+	if known := (&PostgresDriver{}).KnownTypes(); !strmangle.SetInclude("oid", known) || !strmangle.SetInclude("text[]", known) {
+		t.Errorf("postgres types were wrong: %v", known)
+	}
+}
+
+func TestPostgresTranslateUnknownType(t *testing.T) {
+	t.Parallel()
+
+	p := &PostgresDriver{}
+	tests := []struct {
+		c       bdb.Column
+		unknown bool
+	}{
+		{bdb.Column{DBType: "text"}, false},
+		{bdb.Column{DBType: "enum.workday('monday','tuesday')"}, false},
+		{bdb.Column{DBType: "hyperloglog"}, true},
+		{bdb.Column{DBType: "USER-DEFINED", UDTName: "hstore"}, false},
+		{bdb.Column{DBType: "USER-DEFINED", UDTName: "ltree"}, true},
+		{bdb.Column{DBType: "composite", UDTName: "address"}, true},
+	}
+
+	for i, test := range tests {
+		for _, nullable := range []bool{false, true} {
+			test.c.Nullable = nullable
+			if got := p.TranslateColumnType(test.c); got.UnknownType != test.unknown {
+				t.Errorf("%d) %s (nullable %t) want unknown %t, got %#v", i, test.c.DBType, nullable, test.unknown, got)
+			}
+		}
+	}
+}
@@ -0,0 +1,328 @@
+package drivers
+
+import (
+	"database/sql"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+	// Side-effect import sql driver
+	_ "github.com/vertica/vertica-sql-go"
+	"github.com/volatiletech/sqlboiler/bdb"
+)
+
+// VerticaDriver holds the database connection string and a handle
+// to the database connection.
+type VerticaDriver struct {
+	connStr string
+	dbConn  *sql.DB
+}
+
+// NewVerticaDriver takes the database connection details as parameters and
+// returns a pointer to a VerticaDriver object. Note that it is required to
+// call VerticaDriver.Open() and VerticaDriver.Close() to open and close
+// the database connection once an object has been obtained.
+func NewVerticaDriver(user, pass, dbname, host string, port int, sslmode string) *VerticaDriver {
+	driver := VerticaDriver{
+		connStr: VerticaBuildQueryString(user, pass, dbname, host, port, sslmode),
+	}
+
+	return &driver
+}
+
+// VerticaBuildQueryString builds a query string for Vertica.
+func VerticaBuildQueryString(user, pass, dbname, host string, port int, sslmode string) string {
+	query := url.Values{}
+	if len(sslmode) != 0 {
+		query.Add("tlsmode", sslmode)
+	}
+
+	if port == 0 {
+		port = 5433
+	}
+
+	u := &url.URL{
+		Scheme:   "vertica",
+		User:     url.UserPassword(user, pass),
+		Host:     host + ":" + strconv.Itoa(port),
+		Path:     "/" + dbname,
+		RawQuery: query.Encode(),
+	}
+
+	return u.String()
+}
+
+// Open opens the database connection using the connection string
+func (v *VerticaDriver) Open() error {
+	var err error
+	v.dbConn, err = sql.Open("vertica", v.connStr)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// Close closes the database connection
+func (v *VerticaDriver) Close() {
+	v.dbConn.Close()
+}
+
+// conn returns the database connection with the StatementTimeout.
+func (v *VerticaDriver) conn() queryer {
+	return withStatementTimeout(v.dbConn)
+}
+
+// UseLastInsertID returns false for vertica
+func (v *VerticaDriver) UseLastInsertID() bool {
+	return false
+}
+
+// UseTopClause returns false to indicate Vertica doesnt support SQL TOP clause
+func (v *VerticaDriver) UseTopClause() bool {
+	return false
+}
+
+// TableNames connects to the vertica database and
+// retrieves all table names from v_catalog.tables where the
+// table schema is schema. It uses a whitelist and blacklist.
+func (v *VerticaDriver) TableNames(schema string, whitelist, blacklist []string) ([]string, error) {
+	var names []string
+
+	query := `select table_name from v_catalog.tables where table_schema = ? and not is_temp_table`
+	args := []interface{}{schema}
+	if len(whitelist) > 0 {
+		query += fmt.Sprintf(" and table_name in (%s)", questionMarks(len(whitelist)))
+		for _, w := range whitelist {
+			args = append(args, w)
+		}
+	} else if len(blacklist) > 0 {
+		query += fmt.Sprintf(" and table_name not in (%s)", questionMarks(len(blacklist)))
+		for _, b := range blacklist {
+			args = append(args, b)
+		}
+	}
+
+	query += " order by table_name"
+
+	rows, err := v.conn().Query(query, args...)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			return nil, err
+		}
+		names = append(names, name)
+	}
+
+	return names, nil
+}
+
+// Columns takes a table name and attempts to retrieve the table information
+// from v_catalog.columns. It retrieves the column names and column types and
+// returns those as a []Column after TranslateColumnType() converts the SQL
+// types to Go types, for example: "varchar" to "string"
+func (v *VerticaDriver) Columns(schema, tableName string) ([]bdb.Column, error) {
+	var columns []bdb.Column
+
+	rows, err := v.conn().Query(`
+	select
+		c.column_name,
+		c.data_type,
+		c.column_default,
+		c.is_nullable,
+		c.is_identity,
+		exists (
+			select 1
+			from v_catalog.constraint_columns cc
+			where cc.table_schema = c.table_schema and cc.table_name = c.table_name and
+				cc.column_name = c.column_name and cc.constraint_type = 'u' and
+				(select count(*) from v_catalog.constraint_columns where constraint_id = cc.constraint_id) = 1
+		) as is_unique
+	from v_catalog.columns as c
+	where c.table_schema = ? and c.table_name = ?
+	order by c.ordinal_position
+	`, schema, tableName)
+
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var colName, colFullType string
+		var nullable, identity, unique bool
+		var defaultValue *string
+		if err := rows.Scan(&colName, &colFullType, &defaultValue, &nullable, &identity, &unique); err != nil {
+			return nil, errors.Wrapf(err, "unable to scan for table %s", tableName)
+		}
+
+		column := bdb.Column{
+			Name:            colName,
+			FullDBType:      colFullType, // example: varchar(80) instead of varchar
+			DBType:          verticaBaseType(colFullType),
+			Nullable:        nullable,
+			Unique:          unique,
+			IsAutoIncrement: identity,
+		}
+
+		if defaultValue != nil {
+			column.Default = *defaultValue
+		}
+
+		columns = append(columns, column)
+	}
+
+	return columns, nil
+}
+
+// verticaBaseType strips the length or precision from a vertica type,
+// eg: varchar(80) becomes varchar and numeric(10,2) becomes numeric
+func verticaBaseType(typ string) string {
+	if i := strings.IndexByte(typ, '('); i >= 0 {
+		typ = typ[:i]
+	}
+
+	return strings.ToLower(strings.TrimSpace(typ))
+}
+
+// PrimaryKeyInfo looks up the primary key for a table.
+func (v *VerticaDriver) PrimaryKeyInfo(schema, tableName string) (*bdb.PrimaryKey, error) {
+	query := `
+	select constraint_name, column_name
+	from v_catalog.primary_keys
+	where table_schema = ? and table_name = ?
+	order by ordinal_position`
+
+	rows, err := v.conn().Query(query, schema, tableName)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var pkey *bdb.PrimaryKey
+	for rows.Next() {
+		var name, column string
+
+		err = rows.Scan(&name, &column)
+		if err != nil {
+			return nil, err
+		}
+
+		if pkey == nil {
+			pkey = &bdb.PrimaryKey{Name: name}
+		}
+		pkey.Columns = append(pkey.Columns, column)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return pkey, nil
+}
+
+// ForeignKeyInfo retrieves the foreign keys for a given table name.
+func (v *VerticaDriver) ForeignKeyInfo(schema, tableName string) ([]bdb.ForeignKey, error) {
+	var fkeys []bdb.ForeignKey
+
+	query := `
+	select constraint_name, column_name, reference_table_name, reference_column_name
+	from v_catalog.foreign_keys
+	where table_schema = ? and reference_table_schema = ? and table_name = ?
+	order by constraint_name, ordinal_position
+	`
+
+	var rows sqlRows
+	var err error
+	if rows, err = v.conn().Query(query, schema, schema, tableName); err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var fkey bdb.ForeignKey
+
+		fkey.Schema = schema
+		fkey.Table = tableName
+		err = rows.Scan(&fkey.Name, &fkey.Column, &fkey.ForeignTable, &fkey.ForeignColumn)
+		if err != nil {
+			return nil, err
+		}
+
+		fkeys = append(fkeys, fkey)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return fkeys, nil
+}
+
+// TranslateColumnType converts vertica database types to Go types, for example
+// "varchar" to "string" and "int" to "int64". It returns this parsed data
+// as a Column object.
+func (v *VerticaDriver) TranslateColumnType(c bdb.Column) bdb.Column {
+	if c.Nullable {
+		switch c.DBType {
+		case "int", "integer", "bigint", "smallint", "tinyint", "int8":
+			c.Type = "null.Int64"
+		case "float", "float8", "real", "double precision", "numeric", "decimal", "number", "money":
+			c.Type = "null.Float64"
+		case "boolean":
+			c.Type = "null.Bool"
+		case "date", "time", "timetz", "timestamp", "timestamptz", "datetime", "smalldatetime":
+			c.Type = "null.Time"
+		case "binary", "varbinary", "long varbinary", "bytea", "raw":
+			c.Type = "null.Bytes"
+		case "char", "varchar", "long varchar", "uuid", "interval":
+			c.Type = "null.String"
+		default:
+			c.Type = "null.String"
+			c.UnknownType = true
+		}
+	} else {
+		switch c.DBType {
+		case "int", "integer", "bigint", "smallint", "tinyint", "int8":
+			c.Type = "int64"
+		case "float", "float8", "real", "double precision", "numeric", "decimal", "number", "money":
+			c.Type = "float64"
+		case "boolean":
+			c.Type = "bool"
+		case "date", "time", "timetz", "timestamp", "timestamptz", "datetime", "smalldatetime":
+			c.Type = "time.Time"
+		case "binary", "varbinary", "long varbinary", "bytea", "raw":
+			c.Type = "[]byte"
+		case "char", "varchar", "long varchar", "uuid", "interval":
+			c.Type = "string"
+		default:
+			c.Type = "string"
+			c.UnknownType = true
+		}
+	}
+
+	return c
+}
+
+// RightQuote is the quoting character for the right side of the identifier
+func (v *VerticaDriver) RightQuote() byte {
+	return '"'
+}
+
+// LeftQuote is the quoting character for the left side of the identifier
+func (v *VerticaDriver) LeftQuote() byte {
+	return '"'
+}
+
+// IndexPlaceholders returns false to indicate Vertica doesnt support indexed placeholders
+func (v *VerticaDriver) IndexPlaceholders() bool {
+	return false
+}
@@ -2,7 +2,13 @@
 package bdb
 
 import (
+	"context"
+	"fmt"
+	"regexp"
 	"sort"
+	"strings"
+	"time"
+	"unicode"
 
 	"github.com/pkg/errors"
 	"github.com/volatiletech/sqlboiler/strmangle"
@@ -40,58 +46,574 @@ type Interface interface {
 	IndexPlaceholders() bool
 }
 
+// IndexInfoer is an optional interface a driver can implement to
+// describe the indexes on a table.
+type IndexInfoer interface {
+	IndexInfo(schema, tableName string) ([]Index, error)
+}
+
+// CheckInfoer is an optional interface a driver can implement to
+// describe the check constraints on a table.
+type CheckInfoer interface {
+	CheckInfo(schema, tableName string) ([]Check, error)
+}
+
+// ExclusionInfoer is an optional interface a driver can implement to
+// describe the exclusion constraints on a table.
+type ExclusionInfoer interface {
+	ExclusionInfo(schema, tableName string) ([]Exclusion, error)
+}
+
+// TriggerInfoer is an optional interface a driver can implement to
+// describe the triggers on a table.
+type TriggerInfoer interface {
+	TriggerInfo(schema, tableName string) ([]Trigger, error)
+}
+
+// PolicyInfoer is an optional interface a driver can implement to
+// describe the row level security policies on a table.
+type PolicyInfoer interface {
+	PolicyInfo(schema, tableName string) ([]Policy, error)
+}
+
+// SequenceValuer is an optional interface a driver can implement to read
+// the current value of a sequence. The value is nil when the sequence
+// hasn't been used yet.
+type SequenceValuer interface {
+	SequenceLastValue(sequence string) (*int64, error)
+}
+
+// SequenceInfoer is an optional interface a driver can implement to
+// describe a sequence, eg: its data type and whether it cycles.
+type SequenceInfoer interface {
+	SequenceInfo(sequence string) (*Sequence, error)
+}
+
+// TableNamesQueryer is an optional interface a driver can implement to
+// find the tables with a query given by the user.
+type TableNamesQueryer interface {
+	TableNamesFromQuery(query string) ([]string, error)
+}
+
+// ReservedWorder is an optional interface a driver can implement to tell
+// which words are reserved by the database and must always be quoted.
+type ReservedWorder interface {
+	IsReserved(word string) bool
+}
+
+// GoNameFolder is an optional interface a driver can implement when the
+// names it returns don't read well in Go, eg: snowflake's uppercase
+// unquoted identifiers. The Go names are made from the folded names, the
+// names themselves are left as they are for the SQL.
+type GoNameFolder interface {
+	FoldGoName(name string) string
+}
+
+// HealthChecker is an optional interface a driver can implement to check
+// that the database is reachable on its open connection without reading
+// any metadata. It can be called any number of times between Open and
+// Close.
+type HealthChecker interface {
+	HealthCheck(ctx context.Context) error
+}
+
+// TypeLister is an optional interface a driver can implement to list the
+// names of the database types it knows how to translate, so tools can warn
+// about the ones it will fall back on a string for.
+type TypeLister interface {
+	KnownTypes() []string
+}
+
+// DatabaseInfoer is an optional interface a driver can implement to
+// describe the database, eg: its encoding and default collation.
+type DatabaseInfoer interface {
+	DatabaseInfo() (*DatabaseInfo, error)
+}
+
+// TableSavepointer is an optional interface a driver can implement when a
+// failed query spoils the ones after it, eg: inside a postgres transaction.
+// With Options.SkipTableErrors each table is read by fn inside a savepoint
+// that is rolled back when fn fails, so the next table can still be read.
+type TableSavepointer interface {
+	WithSavepoint(fn func() error) error
+}
+
+// TableDetailer is an optional interface a driver can implement to fill
+// in table level metadata that isn't covered by the Interface methods,
+// for example a Postgres table's persistence.
+type TableDetailer interface {
+	TableDetails(schema string, t *Table) error
+}
+
+// Name normalizations for Options.NormalizeNames
+const (
+	NormalizeNone  = ""
+	NormalizeLower = "lower"
+	NormalizeSnake = "snake"
+)
+
+// ModifiedTableNamer is an optional interface a driver can implement when
+// the database records when a table was last changed.
+type ModifiedTableNamer interface {
+	ModifiedTableNames(schema string, since time.Time) ([]string, error)
+}
+
+// Options change how Tables reads the metadata, the zero value gives
+// the default behaviour.
+type Options struct {
+	// ExcludeColumnTypes leaves out columns whose database type is one of
+	// these (case insensitive), eg: bytea or blob. Primary and foreign key
+	// columns are never excluded.
+	ExcludeColumnTypes []string
+
+	// NormalizeNames is one of the Normalize constants. When set the table
+	// and column names (including the ones in keys and indexes) are
+	// lowercased or snake_cased, the original names are kept in DBName and
+	// must be used when writing SQL.
+	NormalizeNames string
+
+	// NameMapper turns the (normalized) column names and singular and
+	// plural table names into the Go identifiers in Column.GoName,
+	// Table.GoName and Table.GoNamePlural. It's strmangle.TitleCase when
+	// nil, which writes acronyms like id and url in uppercase, more can be
+	// added with strmangle.AddUppercaseWords.
+	NameMapper func(name string) string
+
+	// TableAliases replaces the model name (GoName) of the tables in it, eg:
+	// tbl_usr to User, by their names in the database. The plural is made
+	// from the alias. A name that isn't in it is looked up lowercased as
+	// well, since the config file's keys are. The other tables are still
+	// named by the NameMapper, and the columns always are.
+	TableAliases map[string]string
+
+	// ModifiedSince only returns the tables changed after this time when it
+	// isn't zero. Drivers that don't implement ModifiedTableNamer can't
+	// tell, so all tables are returned. Foreign keys to tables that are
+	// left out are removed as they would be by a whitelist.
+	ModifiedSince time.Time
+
+	// LooseJoinTables also treats tables that have columns besides the two
+	// foreign keys of their primary key as join tables (eg: a created_at).
+	// Those columns can't be read or set through the relationship, so they
+	// need a default value.
+	LooseJoinTables bool
+
+	// Triggers reads the triggers of each table into Table.Triggers, for
+	// drivers that implement TriggerInfoer.
+	Triggers bool
+
+	// Policies reads the row level security policies of each table into
+	// Table.Policies, for drivers that implement PolicyInfoer.
+	Policies bool
+
+	// SequenceValues reads the current value of the sequence of each column
+	// that has one into Column.SequenceLastValue, for drivers that implement
+	// SequenceValuer. It's meant for tools that reset sequences after loading
+	// data, the values are out of date as soon as they're read. Drivers that
+	// implement SequenceInfoer also fill in Column.Sequence.
+	SequenceValues bool
+
+	// TableNamesQuery replaces the query used to find the tables of the
+	// schema, it must return a single column of table names. The whitelist
+	// and blacklist still apply to its result. Drivers that don't implement
+	// TableNamesQueryer return an error.
+	TableNamesQuery string
+
+	// StrictTypes fails with the list of columns whose database type the
+	// driver doesn't know instead of generating them with its fallback
+	// type. Columns with a type hint in their comment are allowed.
+	StrictTypes bool
+
+	// DefaultType and DefaultNullableType replace the string and null.String
+	// the drivers fall back to for database types they don't know, eg: with
+	// []byte and null.Bytes so nothing is lost to a text conversion. Either
+	// can be left empty to keep the driver's. Columns are still flagged
+	// with UnknownType.
+	DefaultType         string
+	DefaultNullableType string
+
+	// NotNullChecks reads nullable columns with a check that they aren't
+	// null, eg: CHECK (email IS NOT NULL), as if they were NOT NULL. Only
+	// drivers that implement CheckInfoer read checks.
+	NotNullChecks bool
+
+	// ForceNullable gives every column a null type, for databases whose NOT
+	// NULL constraints can't be trusted. Column.Nullable is still what the
+	// database says, so relationships don't change. Primary key columns are
+	// left as they are.
+	ForceNullable bool
+
+	// ColumnType is called with each column before the driver translates
+	// it, when it returns ok the column gets goType and the driver's
+	// translation is skipped, eg: to map the same jsonb to a struct in one
+	// table and leave it raw in another. The table has its name, primary
+	// key and untranslated columns. A type hint in the column's comment
+	// still wins.
+	ColumnType func(t Table, c Column) (goType string, ok bool)
+
+	// SkipTableErrors carries on when a table can't be read (eg: for lack of
+	// permissions) instead of failing. The tables that were read are
+	// returned with a TableErrors of the ones that weren't, foreign keys to
+	// those are removed. TablesChan sends the TableErrors once it's done,
+	// the tables it sent before that can still have foreign keys to them.
+	SkipTableErrors bool
+
+	// Stats is filled in with counts and timings of each phase if not nil.
+	Stats *Stats
+}
+
 // Tables returns the metadata for all tables, minus the tables
 // specified in the blacklist.
 func Tables(db Interface, schema string, whitelist, blacklist []string) ([]Table, error) {
-	var err error
+	return TablesWithOptions(db, schema, whitelist, blacklist, Options{})
+}
+
+// TablesWithOptions is like Tables but allows changing how the metadata is
+// read with opts.
+func TablesWithOptions(db Interface, schema string, whitelist, blacklist []string, opts Options) ([]Table, error) {
+	stats := opts.Stats
+	if stats == nil {
+		stats = &Stats{}
+	}
+	begin := time.Now()
+
+	names, whitelist, err := tableNames(db, schema, whitelist, blacklist, opts, stats)
+	if err != nil {
+		return nil, err
+	}
+
+	var tables []Table
+	failed := TableErrors{}
+	for _, name := range names {
+		t, err := readSkippableTable(db, schema, name, whitelist, blacklist, opts, stats)
+		if err != nil {
+			if !opts.SkipTableErrors {
+				return nil, err
+			}
+			failed[name] = err
+			continue
+		}
+
+		tables = append(tables, t)
+	}
+	if len(failed) != 0 {
+		removeFailedTables(tables, failed, opts.LooseJoinTables)
+	}
+
+	// Relationships have a dependency on foreign key nullability.
+	for i := range tables {
+		tbl := &tables[i]
+		setForeignKeyConstraints(tbl, tables)
+	}
+	for i := range tables {
+		tbl := &tables[i]
+		setRelationships(tbl, tables)
+	}
+
+	stats.Total += time.Since(begin)
+
+	if len(failed) != 0 {
+		return tables, failed
+	}
+
+	return tables, nil
+}
+
+// TablesChan is like TablesWithOptions but sends each table on the returned
+// channel as soon as it's read, so very large schemas don't have to be held
+// in memory. Since that's before the other tables are read, the foreign key
+// constraint fields (eg: ForeignColumnNullable) and the relationships aren't
+// set, and foreign keys to a table that fails later on with
+// Options.SkipTableErrors aren't removed. The table channel is closed when
+// all tables were sent, reading failed or ctx is done, after which the error
+// channel has the error, if there was one. A consumer that stops reading
+// early must cancel ctx.
+func TablesChan(ctx context.Context, db Interface, schema string, whitelist, blacklist []string, opts Options) (<-chan Table, <-chan error) {
+	tables := make(chan Table)
+	errs := make(chan error, 1)
+
+	go func() {
+		defer close(errs)
+		defer close(tables)
+
+		stats := opts.Stats
+		if stats == nil {
+			stats = &Stats{}
+		}
+		begin := time.Now()
+
+		names, whitelist, err := tableNames(db, schema, whitelist, blacklist, opts, stats)
+		if err != nil {
+			errs <- err
+			return
+		}
+
+		failed := TableErrors{}
+		for _, name := range names {
+			t, err := readSkippableTable(db, schema, name, whitelist, blacklist, opts, stats)
+			if err != nil {
+				if !opts.SkipTableErrors {
+					errs <- err
+					return
+				}
+				failed[name] = err
+				continue
+			}
+
+			select {
+			case tables <- t:
+			case <-ctx.Done():
+				errs <- ctx.Err()
+				return
+			}
+		}
+
+		stats.Total += time.Since(begin)
+		if len(failed) != 0 {
+			errs <- failed
+		}
+	}()
+
+	return tables, errs
+}
 
-	names, err := db.TableNames(schema, whitelist, blacklist)
+// tableNames gets the sorted names of the tables to read, along with the
+// whitelist the foreign keys should be filtered with.
+func tableNames(db Interface, schema string, whitelist, blacklist []string, opts Options, stats *Stats) ([]string, []string, error) {
+	start := time.Now()
+	var names []string
+	var err error
+	if len(opts.TableNamesQuery) != 0 {
+		names, err = queryTableNames(db, opts.TableNamesQuery, whitelist, blacklist)
+	} else {
+		names, err = db.TableNames(schema, whitelist, blacklist)
+	}
 	if err != nil {
-		return nil, errors.Wrap(err, "unable to get table names")
+		return nil, nil, errors.Wrap(err, "unable to get table names")
 	}
+	if namer, ok := db.(ModifiedTableNamer); ok && !opts.ModifiedSince.IsZero() {
+		modified, err := namer.ModifiedTableNames(schema, opts.ModifiedSince)
+		if err != nil {
+			return nil, nil, errors.Wrap(err, "unable to get modified table names")
+		}
+
+		var keep []string
+		for _, name := range names {
+			if strmangle.SetInclude(name, modified) {
+				keep = append(keep, name)
+			}
+		}
+		names = keep
+		whitelist = names
+	}
+	stats.TableNamesTime += time.Since(start)
 
 	sort.Strings(names)
 
-	var tables []Table
+	return names, whitelist, nil
+}
+
+// queryTableNames gets the table names from the user's query and filters
+// them with the whitelist and blacklist.
+func queryTableNames(db Interface, query string, whitelist, blacklist []string) ([]string, error) {
+	queryer, ok := db.(TableNamesQueryer)
+	if !ok {
+		return nil, errors.New("the driver does not support a table names query")
+	}
+
+	names, err := queryer.TableNamesFromQuery(query)
+	if err != nil {
+		return nil, err
+	}
+
+	var keep []string
 	for _, name := range names {
-		t := Table{
-			Name: name,
+		if len(whitelist) > 0 && !strmangle.SetInclude(name, whitelist) {
+			continue
 		}
+		if len(whitelist) == 0 && strmangle.SetInclude(name, blacklist) {
+			continue
+		}
+		keep = append(keep, name)
+	}
+
+	return keep, nil
+}
+
+// readSkippableTable reads the table with readTable, inside a savepoint when
+// the driver needs one to carry on after a table fails.
+func readSkippableTable(db Interface, schema, name string, whitelist, blacklist []string, opts Options, stats *Stats) (Table, error) {
+	savepointer, ok := db.(TableSavepointer)
+	if !ok || !opts.SkipTableErrors {
+		return readTable(db, schema, name, whitelist, blacklist, opts, stats)
+	}
+
+	var t Table
+	err := savepointer.WithSavepoint(func() error {
+		var err error
+		t, err = readTable(db, schema, name, whitelist, blacklist, opts, stats)
+		return err
+	})
+
+	return t, err
+}
+
+// readTable reads the metadata of a single table, the parts that depend on
+// the other tables (foreign key constraints and relationships) are left out.
+func readTable(db Interface, schema, name string, whitelist, blacklist []string, opts Options, stats *Stats) (Table, error) {
+	var err error
 
-		if t.Columns, err = db.Columns(schema, name); err != nil {
-			return nil, errors.Wrapf(err, "unable to fetch table column info (%s)", name)
+	t := Table{
+		Name:        name,
+		DBName:      name,
+		Persistence: PersistencePermanent,
+		IsUpdatable: true,
+	}
+
+	start := time.Now()
+	if t.Columns, err = db.Columns(schema, name); err != nil {
+		return Table{}, errors.Wrapf(err, "unable to fetch table column info (%s)", name)
+	}
+	stats.ColumnsTime += time.Since(start)
+
+	// The primary key is read first so ForceNullable can leave its columns
+	// alone, they can never be NULL.
+	start = time.Now()
+	if t.PKey, err = db.PrimaryKeyInfo(schema, name); err != nil {
+		return Table{}, errors.Wrapf(err, "unable to fetch table pkey info (%s)", name)
+	}
+	stats.PrimaryKeysTime += time.Since(start)
+
+	// Checks are read before the columns are translated for NotNullChecks
+	if checker, ok := db.(CheckInfoer); ok {
+		if t.Checks, err = checker.CheckInfo(schema, name); err != nil {
+			return Table{}, errors.Wrapf(err, "unable to fetch table check info (%s)", name)
 		}
+	}
 
-		for i, c := range t.Columns {
+	for i, c := range t.Columns {
+		if opts.NotNullChecks && c.Nullable && hasNotNullCheck(t.Checks, c.Name) {
+			c.Nullable = false
+		}
+		// The column is translated as if it were nullable to get a null
+		// type, Nullable stays as the database has it
+		forceNull := opts.ForceNullable && !c.Nullable && (t.PKey == nil || !strmangle.SetInclude(c.Name, t.PKey.Columns))
+		if forceNull {
+			c.Nullable = true
+		}
+		goType, ok := "", false
+		if opts.ColumnType != nil {
+			goType, ok = opts.ColumnType(t, c)
+		}
+		if ok {
+			c.Type = goType
+			t.Columns[i] = c
+		} else {
 			t.Columns[i] = db.TranslateColumnType(c)
 		}
+		t.Columns[i].DBName = c.Name
+		if t.Columns[i].UnknownType {
+			setDefaultType(&t.Columns[i], opts)
+		}
+		if forceNull {
+			t.Columns[i].Nullable = false
+		}
+		if err = setTypeHint(&t.Columns[i]); err != nil {
+			return Table{}, errors.Wrapf(err, "invalid column type hint (%s)", name)
+		}
+		t.Columns[i].DefaultKind = classifyDefault(t.Columns[i])
+		setOptionalOnInsert(&t.Columns[i])
+	}
+
+	start = time.Now()
+	if t.FKeys, err = db.ForeignKeyInfo(schema, name); err != nil {
+		return Table{}, errors.Wrapf(err, "unable to fetch table fkey info (%s)", name)
+	}
+	stats.ForeignKeysTime += time.Since(start)
 
-		if t.PKey, err = db.PrimaryKeyInfo(schema, name); err != nil {
-			return nil, errors.Wrapf(err, "unable to fetch table pkey info (%s)", name)
+	excludeColumnsByType(&t, opts.ExcludeColumnTypes)
+	if opts.StrictTypes {
+		if err = checkUnknownTypes(t); err != nil {
+			return Table{}, err
 		}
+	}
 
-		if t.FKeys, err = db.ForeignKeyInfo(schema, name); err != nil {
-			return nil, errors.Wrapf(err, "unable to fetch table fkey info (%s)", name)
+	if indexer, ok := db.(IndexInfoer); ok {
+		if t.Indexes, err = indexer.IndexInfo(schema, name); err != nil {
+			return Table{}, errors.Wrapf(err, "unable to fetch table index info (%s)", name)
 		}
+	}
 
-		filterForeignKeys(&t, whitelist, blacklist)
+	if excluder, ok := db.(ExclusionInfoer); ok {
+		if t.Exclusions, err = excluder.ExclusionInfo(schema, name); err != nil {
+			return Table{}, errors.Wrapf(err, "unable to fetch table exclusion info (%s)", name)
+		}
+	}
 
-		setIsJoinTable(&t)
+	if triggerer, ok := db.(TriggerInfoer); ok && opts.Triggers {
+		if t.Triggers, err = triggerer.TriggerInfo(schema, name); err != nil {
+			return Table{}, errors.Wrapf(err, "unable to fetch table trigger info (%s)", name)
+		}
+	}
 
-		tables = append(tables, t)
+	if policier, ok := db.(PolicyInfoer); ok && opts.Policies {
+		if t.Policies, err = policier.PolicyInfo(schema, name); err != nil {
+			return Table{}, errors.Wrapf(err, "unable to fetch table policy info (%s)", name)
+		}
 	}
 
-	// Relationships have a dependency on foreign key nullability.
-	for i := range tables {
-		tbl := &tables[i]
-		setForeignKeyConstraints(tbl, tables)
+	if valuer, ok := db.(SequenceValuer); ok && opts.SequenceValues {
+		for i, c := range t.Columns {
+			if len(c.SequenceName) == 0 {
+				continue
+			}
+			if t.Columns[i].SequenceLastValue, err = valuer.SequenceLastValue(c.SequenceName); err != nil {
+				return Table{}, errors.Wrapf(err, "unable to fetch sequence value (%s.%s)", name, c.Name)
+			}
+		}
 	}
-	for i := range tables {
-		tbl := &tables[i]
-		setRelationships(tbl, tables)
+
+	if infoer, ok := db.(SequenceInfoer); ok && opts.SequenceValues {
+		for i, c := range t.Columns {
+			if len(c.SequenceName) == 0 {
+				continue
+			}
+			if t.Columns[i].Sequence, err = infoer.SequenceInfo(c.SequenceName); err != nil {
+				return Table{}, errors.Wrapf(err, "unable to fetch sequence info (%s.%s)", name, c.Name)
+			}
+		}
 	}
 
-	return tables, nil
+	sortKeys(&t)
+	setUniqueKeys(&t)
+	setColumnChecks(&t)
+	setGeneratedFrom(&t)
+
+	if detailer, ok := db.(TableDetailer); ok {
+		if err = detailer.TableDetails(schema, &t); err != nil {
+			return Table{}, errors.Wrapf(err, "unable to fetch table details (%s)", name)
+		}
+	}
+
+	filterForeignKeys(&t, whitelist, blacklist)
+
+	if err = normalizeNames(&t, opts.NormalizeNames); err != nil {
+		return Table{}, err
+	}
+	var fold func(string) string
+	if folder, ok := db.(GoNameFolder); ok {
+		fold = folder.FoldGoName
+	}
+	setGoNames(&t, fold, opts.NameMapper, opts.TableAliases)
+
+	setIsJoinTable(&t, opts.LooseJoinTables)
+	setColumnForeignKeys(&t)
+
+	stats.Tables++
+	stats.Columns += len(t.Columns)
+	stats.ForeignKeys += len(t.FKeys)
+
+	return t, nil
 }
 
 // filterForeignKeys filter FK whose ForeignTable is not in whitelist or in blacklist
@@ -106,11 +628,305 @@ func filterForeignKeys(t *Table, whitelist, blacklist []string) {
 	t.FKeys = fkeys
 }
 
+// setGoNames sets the Go names of the table and its columns with mapper, or
+// strmangle.TitleCase if it's nil, from the names folded by fold when it
+// isn't nil. The table's alias is used as its model name instead when it
+// has one.
+func setGoNames(t *Table, fold, mapper func(string) string, aliases map[string]string) {
+	if fold == nil {
+		fold = func(name string) string { return name }
+	}
+	if mapper == nil {
+		mapper = strmangle.TitleCase
+	}
+
+	// Tables qualified with their schema, eg: postgres catalog tables, get it
+	// in their GoName. Only the table's own name is made singular or plural.
+	var prefix string
+	name := t.Name
+	if i := strings.LastIndexByte(name, '.'); i >= 0 {
+		prefix = fold(strings.Replace(name[:i], ".", "_", -1)) + "_"
+		name = name[i+1:]
+	}
+	name = fold(name)
+	t.GoName = mapper(prefix + strmangle.Singular(name))
+	t.GoNamePlural = mapper(prefix + strmangle.Plural(name))
+	alias, ok := aliases[t.DBName]
+	if !ok {
+		alias, ok = aliases[strings.ToLower(t.DBName)]
+	}
+	if ok {
+		t.GoName = alias
+		t.GoNamePlural = strmangle.Plural(alias)
+	}
+	t.VarName = unexported(t.GoName)
+	t.VarNamePlural = unexported(t.GoNamePlural)
+
+	for i := range t.Columns {
+		t.Columns[i].GoName = mapper(fold(t.Columns[i].Name))
+	}
+}
+
+// unexported lowercases the capitals a Go name starts with, eg: User becomes
+// user and URLPart becomes urlPart.
+func unexported(name string) string {
+	r := []rune(name)
+	for i := 0; i < len(r) && unicode.IsUpper(r[i]); i++ {
+		if i != 0 && i+1 < len(r) && unicode.IsLower(r[i+1]) {
+			break
+		}
+		r[i] = unicode.ToLower(r[i])
+	}
+
+	return string(r)
+}
+
+// normalizeNames renames everything in the table with the given normalization
+func normalizeNames(t *Table, normalization string) error {
+	var normalize func(string) string
+	switch normalization {
+	case NormalizeNone:
+		return nil
+	case NormalizeLower:
+		normalize = strings.ToLower
+	case NormalizeSnake:
+		normalize = strmangle.SnakeCase
+	default:
+		return errors.Errorf("unknown name normalization: %s", normalization)
+	}
+
+	normalizeAll := func(names []string) {
+		for i, n := range names {
+			names[i] = normalize(n)
+		}
+	}
+
+	t.Name = normalize(t.Name)
+	for i := range t.Columns {
+		t.Columns[i].Name = normalize(t.Columns[i].Name)
+		normalizeAll(t.Columns[i].GeneratedFrom)
+	}
+	if t.PKey != nil {
+		normalizeAll(t.PKey.Columns)
+	}
+	for i := range t.FKeys {
+		f := &t.FKeys[i]
+		f.Table = normalize(f.Table)
+		f.Column = normalize(f.Column)
+		f.ForeignTable = normalize(f.ForeignTable)
+		f.ForeignColumn = normalize(f.ForeignColumn)
+	}
+	for i := range t.UKeys {
+		normalizeAll(t.UKeys[i].Columns)
+	}
+	for i := range t.Checks {
+		normalizeAll(t.Checks[i].Columns)
+	}
+	for i := range t.Exclusions {
+		for j := range t.Exclusions[i].Elements {
+			e := &t.Exclusions[i].Elements[j]
+			e.Column = normalize(e.Column)
+		}
+	}
+	for i := range t.Indexes {
+		for j := range t.Indexes[i].Columns {
+			if c := &t.Indexes[i].Columns[j]; len(c.Expression) == 0 {
+				c.Name = normalize(c.Name)
+			}
+		}
+		normalizeAll(t.Indexes[i].Include)
+	}
+	normalizeAll(t.PartitionKey)
+
+	return nil
+}
+
+// excludeColumnsByType removes the columns whose DBType is in types, unless
+// they are part of the primary key or a foreign key.
+func excludeColumnsByType(t *Table, types []string) {
+	if len(types) == 0 {
+		return
+	}
+
+	var keyCols []string
+	if t.PKey != nil {
+		keyCols = append(keyCols, t.PKey.Columns...)
+	}
+	for _, f := range t.FKeys {
+		keyCols = append(keyCols, f.Column)
+	}
+
+	var cols []Column
+	for _, c := range t.Columns {
+		if strmangle.SetInclude(c.Name, keyCols) || !columnTypeIn(c, types) {
+			cols = append(cols, c)
+		}
+	}
+	t.Columns = cols
+}
+
+// setTypeHint gives the column the type of its @gotype: hint, if it has one.
+// The hinted type has no null type to go with it, so a nullable column
+// can't have one.
+func setTypeHint(c *Column) error {
+	goType, importPath := c.TypeHint()
+	if len(goType) == 0 {
+		return nil
+	}
+	if c.Nullable {
+		return errors.Errorf("column %s is nullable, a @gotype hint can only be given to a NOT NULL column", c.Name)
+	}
+
+	c.Type = goType
+	c.TypeImport = importPath
+	c.UnknownType = false
+
+	return nil
+}
+
+// setDefaultType replaces the fallback type of a column the driver doesn't
+// know with the one from opts.
+func setDefaultType(c *Column, opts Options) {
+	switch {
+	case c.Nullable && len(opts.DefaultNullableType) != 0:
+		c.Type = opts.DefaultNullableType
+	case !c.Nullable && len(opts.DefaultType) != 0:
+		c.Type = opts.DefaultType
+	}
+}
+
+// checkUnknownTypes returns an error listing the columns of t whose type
+// the driver doesn't know.
+func checkUnknownTypes(t Table) error {
+	var unknown []string
+	for _, c := range t.Columns {
+		if c.UnknownType {
+			unknown = append(unknown, fmt.Sprintf("%s (%s)", c.Name, c.DBType))
+		}
+	}
+
+	if len(unknown) != 0 {
+		return errors.Errorf("unknown column types in table %s: %s", t.Name, strings.Join(unknown, ", "))
+	}
+
+	return nil
+}
+
+// columnTypeIn checks the column's database types against types
+func columnTypeIn(c Column, types []string) bool {
+	for _, typ := range types {
+		if strings.EqualFold(c.DBType, typ) || strings.EqualFold(c.UDTName, typ) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// sortKeys orders the foreign keys and indexes by name so the output doesn't
+// change from run to run, the columns of each key keep their order.
+func sortKeys(t *Table) {
+	sort.SliceStable(t.FKeys, func(i, j int) bool { return t.FKeys[i].Name < t.FKeys[j].Name })
+	sort.SliceStable(t.Indexes, func(i, j int) bool { return t.Indexes[i].Name < t.Indexes[j].Name })
+}
+
+// setUniqueKeys creates the unique keys from the unique indexes, an index
+// on an expression, eg: lower(email), isn't a unique key of its columns.
+func setUniqueKeys(t *Table) {
+	t.UKeys = nil
+	for _, idx := range t.Indexes {
+		if !idx.Unique || idx.HasExpression() {
+			continue
+		}
+
+		ukey := UniqueKey{
+			Name:      idx.Name,
+			Columns:   idx.IndexColumnNames(),
+			Predicate: idx.Predicate,
+		}
+		ukey.IsAlternateKey = t.PKey == nil || !strmangle.SetEqual(ukey.Columns, t.PKey.Columns)
+
+		t.UKeys = append(t.UKeys, ukey)
+	}
+}
+
+// rgxNotNullCheck matches a check that a column isn't null, eg:
+// (email IS NOT NULL) or `email` is not null
+var rgxNotNullCheck = regexp.MustCompile(`(?i)^[\s(]*[\w"` + "`" + `\[\]]+\s+is\s+not\s+null[\s)]*$`)
+
+// hasNotNullCheck returns true if one of the checks only makes sure that
+// column isn't null. A NOT VALID check doesn't count, existing rows can
+// still be null.
+func hasNotNullCheck(checks []Check, column string) bool {
+	for _, check := range checks {
+		if check.NotValid {
+			continue
+		}
+		if len(check.Columns) == 1 && check.Columns[0] == column && rgxNotNullCheck.MatchString(check.Expression) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// setColumnChecks gives each column the checks that only use that column
+func setColumnChecks(t *Table) {
+	for _, check := range t.Checks {
+		if len(check.Columns) != 1 {
+			continue
+		}
+
+		for i := range t.Columns {
+			if t.Columns[i].Name == check.Columns[0] {
+				t.Columns[i].Checks = append(t.Columns[i].Checks, check.Expression)
+			}
+		}
+	}
+}
+
+// setGeneratedFrom finds the columns each generated column is computed from
+func setGeneratedFrom(t *Table) {
+	for i, c := range t.Columns {
+		if len(c.GenerationExpression) == 0 {
+			continue
+		}
+
+		var others []Column
+		for _, o := range t.Columns {
+			if o.Name != c.Name {
+				others = append(others, o)
+			}
+		}
+		t.Columns[i].GeneratedFrom = expressionColumns(c.GenerationExpression, others)
+	}
+}
+
+// setColumnForeignKeys points each foreign key column at its entry in
+// FKeys, so it must be called once they won't be appended to or filtered.
+func setColumnForeignKeys(t *Table) {
+	for i := range t.Columns {
+		t.Columns[i].ForeignKey = nil
+		for j := range t.FKeys {
+			if t.FKeys[j].Column == t.Columns[i].Name {
+				t.Columns[i].ForeignKey = &t.FKeys[j]
+				break
+			}
+		}
+	}
+}
+
+// setOptionalOnInsert marks columns that can be left out of an insert
+func setOptionalOnInsert(c *Column) {
+	c.OptionalOnInsert = c.HasDefault() || c.AutoGenerated
+}
+
 // setIsJoinTable if there are:
 // A composite primary key involving two columns
 // Both primary key columns are also foreign keys
-func setIsJoinTable(t *Table) {
-	if t.PKey == nil || len(t.PKey.Columns) != 2 || len(t.FKeys) < 2 || len(t.Columns) > 2 {
+// No other columns, unless loose is true
+func setIsJoinTable(t *Table, loose bool) {
+	if t.PKey == nil || len(t.PKey.Columns) != 2 || len(t.FKeys) < 2 || (!loose && len(t.Columns) > 2) {
 		return
 	}
 
@@ -140,7 +956,26 @@ func setForeignKeyConstraints(t *Table, tables []Table) {
 		t.FKeys[i].Unique = localColumn.Unique
 		t.FKeys[i].ForeignColumnNullable = foreignColumn.Nullable
 		t.FKeys[i].ForeignColumnUnique = foreignColumn.Unique
+		t.FKeys[i].ForeignIsPrimary = foreignTable.PKey != nil &&
+			strmangle.SetEqual(foreignKeyColumns(t.FKeys, fkey), foreignTable.PKey.Columns)
+	}
+}
+
+// foreignKeyColumns returns the foreign columns of every part of the
+// constraint fkey belongs to.
+func foreignKeyColumns(fkeys []ForeignKey, fkey ForeignKey) []string {
+	if len(fkey.Name) == 0 {
+		return []string{fkey.ForeignColumn}
 	}
+
+	var cols []string
+	for _, f := range fkeys {
+		if f.Schema == fkey.Schema && f.Table == fkey.Table && f.Name == fkey.Name {
+			cols = append(cols, f.ForeignColumn)
+		}
+	}
+
+	return cols
 }
 
 func setRelationships(t *Table, tables []Table) {
@@ -1,7 +1,12 @@
 package bdb
 
 import (
+	"context"
+	"strings"
 	"testing"
+	"time"
+
+	"github.com/pkg/errors"
 
 	"github.com/volatiletech/sqlboiler/strmangle"
 )
@@ -249,13 +254,33 @@ func TestSetIsJoinTable(t *testing.T) {
 			table.FKeys = append(table.FKeys, ForeignKey{Column: k})
 		}
 
-		setIsJoinTable(&table)
+		setIsJoinTable(&table, false)
 		if is := table.IsJoinTable; is != test.Should {
 			t.Errorf("%d) want: %t, got: %t\nTest: %#v", i, test.Should, is, test)
 		}
 	}
 }
 
+func TestSetIsJoinTableLoose(t *testing.T) {
+	t.Parallel()
+
+	table := Table{
+		Columns: []Column{{Name: "pilot_id"}, {Name: "language_id"}, {Name: "created_at"}},
+		PKey:    &PrimaryKey{Columns: []string{"pilot_id", "language_id"}},
+		FKeys:   []ForeignKey{{Column: "pilot_id"}, {Column: "language_id"}},
+	}
+
+	setIsJoinTable(&table, false)
+	if table.IsJoinTable {
+		t.Error("a table with extra columns should not be a strict join table")
+	}
+
+	setIsJoinTable(&table, true)
+	if !table.IsJoinTable {
+		t.Error("a table with extra columns should be a loose join table")
+	}
+}
+
 func TestSetForeignKeyConstraints(t *testing.T) {
 	t.Parallel()
 
@@ -354,3 +379,831 @@ func TestSetRelationships(t *testing.T) {
 		t.Error("should not be a join table")
 	}
 }
+
+type testDetailerDriver struct {
+	testMockDriver
+}
+
+func (m testDetailerDriver) TableDetails(schema string, t *Table) error {
+	if t.Name == "hangars" {
+		t.Persistence = PersistenceUnlogged
+	}
+	return nil
+}
+
+func (m testDetailerDriver) IndexInfo(schema, tableName string) ([]Index, error) {
+	if tableName != "jets" {
+		return nil, nil
+	}
+
+	return []Index{
+		{
+			Name:    "jets_name_idx",
+			Columns: []IndexColumn{{Name: "name"}, {Name: "color", Descending: true}},
+			Include: []string{"uuid"},
+		},
+	}, nil
+}
+
+func (m testDetailerDriver) TableNamesFromQuery(query string) ([]string, error) {
+	return []string{"jets", "pilots", "airports"}, nil
+}
+
+func (m testDetailerDriver) ModifiedTableNames(schema string, since time.Time) ([]string, error) {
+	return []string{"jets", "pilots"}, nil
+}
+
+func TestTablesDetails(t *testing.T) {
+	t.Parallel()
+
+	tables, err := Tables(testMockDriver{}, "public", nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, tbl := range tables {
+		if tbl.Persistence != PersistencePermanent {
+			t.Errorf("%s: want permanent persistence, got: %q", tbl.Name, tbl.Persistence)
+		}
+	}
+
+	tables, err = Tables(testDetailerDriver{}, "public", nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if p := GetTable(tables, "hangars").Persistence; p != PersistenceUnlogged {
+		t.Errorf("want unlogged hangars, got: %q", p)
+	}
+	if p := GetTable(tables, "pilots").Persistence; p != PersistencePermanent {
+		t.Errorf("want permanent pilots, got: %q", p)
+	}
+
+	jets := GetTable(tables, "jets")
+	if len(jets.Indexes) != 1 {
+		t.Fatalf("want 1 index on jets, got: %d", len(jets.Indexes))
+	}
+	if idx := jets.Indexes[0]; len(idx.Columns) != 2 || !idx.Columns[1].Descending || len(idx.Include) != 1 {
+		t.Errorf("index was wrong: %#v", idx)
+	}
+}
+
+func TestSetOptionalOnInsert(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		Col  Column
+		Want bool
+	}{
+		{Column{Name: "plain"}, false},
+		{Column{Name: "nullable", Nullable: true}, false},
+		{Column{Name: "default", Default: "now()"}, true},
+		{Column{Name: "serial", Default: "nextval('seq')", IsAutoIncrement: true}, true},
+		{Column{Name: "identity", IsAutoIncrement: true}, true},
+		{Column{Name: "rowversion", AutoGenerated: true}, true},
+	}
+
+	for i, test := range tests {
+		setOptionalOnInsert(&test.Col)
+		if test.Col.OptionalOnInsert != test.Want {
+			t.Errorf("%d) %s: want %t, got %t", i, test.Col.Name, test.Want, test.Col.OptionalOnInsert)
+		}
+	}
+}
+
+func TestExcludeColumnsByType(t *testing.T) {
+	t.Parallel()
+
+	table := Table{
+		Columns: []Column{
+			{Name: "id", DBType: "bytea"},
+			{Name: "name", DBType: "character"},
+			{Name: "cargo", DBType: "bytea"},
+			{Name: "manifest", DBType: "BLOB"},
+			{Name: "pilot_id", DBType: "bytea"},
+			{Name: "shape", DBType: "USER-DEFINED", UDTName: "geometry"},
+		},
+		PKey:  &PrimaryKey{Columns: []string{"id"}},
+		FKeys: []ForeignKey{{Column: "pilot_id"}},
+	}
+
+	excludeColumnsByType(&table, []string{"bytea", "blob", "geometry"})
+
+	got := ColumnNames(table.Columns)
+	want := []string{"id", "name", "pilot_id"}
+	if len(got) != len(want) {
+		t.Fatalf("want %v, got %v", want, got)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("want %v, got %v", want, got)
+		}
+	}
+}
+
+func TestSetUniqueKeys(t *testing.T) {
+	t.Parallel()
+
+	table := Table{
+		PKey: &PrimaryKey{Columns: []string{"id"}},
+		Indexes: []Index{
+			{Name: "pkey", Unique: true, Columns: []IndexColumn{{Name: "id"}}},
+			{Name: "email_key", Unique: true, Columns: []IndexColumn{{Name: "email"}}, Predicate: "(deleted_at IS NULL)"},
+			{Name: "name_idx", Columns: []IndexColumn{{Name: "name"}}},
+			{Name: "id_org_key", Unique: true, Columns: []IndexColumn{{Name: "org_id"}, {Name: "id"}}},
+			// Not unique on org_id alone
+			{Name: "org_lower_name_key", Unique: true, Columns: []IndexColumn{{Name: "org_id"}, {Expression: "lower(name)"}}},
+		},
+	}
+
+	setUniqueKeys(&table)
+
+	if len(table.UKeys) != 3 {
+		t.Fatalf("want 3 unique keys, got: %#v", table.UKeys)
+	}
+	if table.UKeys[0].Name != "pkey" || table.UKeys[0].IsAlternateKey {
+		t.Errorf("primary key index should not be an alternate key: %#v", table.UKeys[0])
+	}
+	if table.UKeys[1].Name != "email_key" || !table.UKeys[1].IsAlternateKey {
+		t.Errorf("email should be an alternate key: %#v", table.UKeys[1])
+	}
+	if table.UKeys[1].Predicate != "(deleted_at IS NULL)" || table.UKeys[0].Predicate != "" {
+		t.Errorf("the predicate of the partial index should be kept: %#v", table.UKeys)
+	}
+	if table.UKeys[2].Name != "id_org_key" || !table.UKeys[2].IsAlternateKey {
+		t.Errorf("id, org_id should be an alternate key: %#v", table.UKeys[2])
+	}
+}
+
+func TestTablesStats(t *testing.T) {
+	t.Parallel()
+
+	var stats Stats
+	_, err := TablesWithOptions(testMockDriver{}, "public", nil, nil, Options{Stats: &stats})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if stats.Tables != 7 {
+		t.Errorf("want 7 tables, got: %d", stats.Tables)
+	}
+	if stats.Columns != 22 {
+		t.Errorf("want 22 columns, got: %d", stats.Columns)
+	}
+	if stats.ForeignKeys != 6 {
+		t.Errorf("want 6 foreign keys, got: %d", stats.ForeignKeys)
+	}
+	if stats.Total < stats.ColumnsTime {
+		t.Errorf("total time should include the columns time: %s", stats)
+	}
+}
+
+func TestNormalizeNames(t *testing.T) {
+	t.Parallel()
+
+	table := Table{
+		Name:    "PilotLicenses",
+		Columns: []Column{{Name: "LicenseID"}, {Name: "PilotID"}},
+		PKey:    &PrimaryKey{Columns: []string{"LicenseID"}},
+		FKeys: []ForeignKey{
+			{Table: "PilotLicenses", Column: "PilotID", ForeignTable: "Pilots", ForeignColumn: "ID"},
+		},
+		Indexes: []Index{{Columns: []IndexColumn{{Name: "PilotID"}}, Include: []string{"LicenseID"}}},
+	}
+
+	if err := normalizeNames(&table, NormalizeSnake); err != nil {
+		t.Fatal(err)
+	}
+
+	if table.Name != "pilot_licenses" {
+		t.Errorf("wrong table name: %s", table.Name)
+	}
+	if got := ColumnNames(table.Columns); got[0] != "license_id" || got[1] != "pilot_id" {
+		t.Errorf("wrong column names: %v", got)
+	}
+	if table.PKey.Columns[0] != "license_id" {
+		t.Errorf("wrong pkey column: %v", table.PKey.Columns)
+	}
+	if f := table.FKeys[0]; f.Table != "pilot_licenses" || f.Column != "pilot_id" || f.ForeignTable != "pilots" || f.ForeignColumn != "id" {
+		t.Errorf("wrong fkey: %#v", f)
+	}
+	if idx := table.Indexes[0]; idx.Columns[0].Name != "pilot_id" || idx.Include[0] != "license_id" {
+		t.Errorf("wrong index: %#v", idx)
+	}
+
+	if err := normalizeNames(&table, "upper"); err == nil {
+		t.Error("expected an error for an unknown normalization")
+	}
+}
+
+func TestTablesNormalizeNames(t *testing.T) {
+	t.Parallel()
+
+	tables, err := TablesWithOptions(testMockDriver{}, "public", nil, nil, Options{NormalizeNames: NormalizeLower})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, tbl := range tables {
+		if tbl.DBName == "" {
+			t.Errorf("%s: the database name should be kept", tbl.Name)
+		}
+		for _, c := range tbl.Columns {
+			if c.DBName == "" {
+				t.Errorf("%s.%s: the database name should be kept", tbl.Name, c.Name)
+			}
+		}
+	}
+}
+
+func TestTablesModifiedSince(t *testing.T) {
+	t.Parallel()
+
+	since := time.Date(2017, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	tables, err := TablesWithOptions(testMockDriver{}, "public", nil, nil, Options{ModifiedSince: since})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(tables) != 7 {
+		t.Errorf("drivers that can't tell should return all tables, got: %d", len(tables))
+	}
+
+	tables, err = TablesWithOptions(testDetailerDriver{}, "public", nil, nil, Options{ModifiedSince: since})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(tables) != 2 {
+		t.Fatalf("want 2 tables, got: %d", len(tables))
+	}
+
+	jets := GetTable(tables, "jets")
+	if len(jets.FKeys) != 1 || jets.FKeys[0].ForeignTable != "pilots" {
+		t.Errorf("foreign keys to unmodified tables should be removed: %#v", jets.FKeys)
+	}
+}
+
+func TestSortKeys(t *testing.T) {
+	t.Parallel()
+
+	table := Table{
+		FKeys: []ForeignKey{
+			{Name: "b_fk", Column: "b1"},
+			{Name: "a_fk", Column: "a2"},
+			{Name: "b_fk", Column: "b2"},
+			{Name: "a_fk", Column: "a1"},
+		},
+		Indexes: []Index{{Name: "z_idx"}, {Name: "m_idx"}, {Name: "a_idx"}},
+	}
+
+	sortKeys(&table)
+
+	wantCols := []string{"a2", "a1", "b1", "b2"}
+	for i, c := range wantCols {
+		if table.FKeys[i].Column != c {
+			t.Errorf("%d) want fkey column %s, got: %s", i, c, table.FKeys[i].Column)
+		}
+	}
+
+	wantIdx := []string{"a_idx", "m_idx", "z_idx"}
+	for i, n := range wantIdx {
+		if table.Indexes[i].Name != n {
+			t.Errorf("%d) want index %s, got: %s", i, n, table.Indexes[i].Name)
+		}
+	}
+}
+
+func TestTablesSorted(t *testing.T) {
+	t.Parallel()
+
+	tables, err := Tables(testMockDriver{}, "public", nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for i := 1; i < len(tables); i++ {
+		if tables[i-1].Name > tables[i].Name {
+			t.Errorf("tables are not sorted: %s before %s", tables[i-1].Name, tables[i].Name)
+		}
+	}
+
+	for _, tbl := range tables {
+		for i := 1; i < len(tbl.FKeys); i++ {
+			if tbl.FKeys[i-1].Name > tbl.FKeys[i].Name {
+				t.Errorf("%s: fkeys are not sorted: %s before %s", tbl.Name, tbl.FKeys[i-1].Name, tbl.FKeys[i].Name)
+			}
+		}
+	}
+}
+
+func TestSetColumnChecks(t *testing.T) {
+	t.Parallel()
+
+	table := Table{
+		Columns: []Column{{Name: "price"}, {Name: "discount"}},
+		Checks: []Check{
+			{Name: "price_positive", Expression: "(price > 0)", Columns: []string{"price"}},
+			{Name: "discount_less", Expression: "(discount < price)", Columns: []string{"price", "discount"}},
+			{Name: "always", Expression: "(true)"},
+		},
+	}
+
+	setColumnChecks(&table)
+
+	if c := table.Columns[0].Checks; len(c) != 1 || c[0] != "(price > 0)" {
+		t.Errorf("price checks were wrong: %v", c)
+	}
+	if c := table.Columns[1].Checks; len(c) != 0 {
+		t.Errorf("multi column checks should stay on the table: %v", c)
+	}
+}
+
+func TestSetGeneratedFrom(t *testing.T) {
+	t.Parallel()
+
+	table := Table{
+		Columns: []Column{
+			{Name: "qty"},
+			{Name: "price"},
+			{Name: "total", GenerationExpression: "(qty * price)"},
+		},
+	}
+
+	setGeneratedFrom(&table)
+
+	if c := table.Columns[2].GeneratedFrom; strings.Join(c, ",") != "qty,price" {
+		t.Errorf("total should be generated from qty and price: %v", c)
+	}
+	if c := table.Columns[0].GeneratedFrom; len(c) != 0 {
+		t.Errorf("qty isn't generated: %v", c)
+	}
+}
+
+func TestSetForeignKeyConstraintsForeignIsPrimary(t *testing.T) {
+	t.Parallel()
+
+	tables := []Table{
+		{
+			Name:    "flights",
+			Columns: []Column{{Name: "airline"}, {Name: "number"}, {Name: "code", Unique: true}},
+			PKey:    &PrimaryKey{Columns: []string{"airline", "number"}},
+		},
+		{
+			Name:    "tickets",
+			Columns: []Column{{Name: "airline"}, {Name: "number"}, {Name: "code"}},
+			FKeys: []ForeignKey{
+				{Table: "tickets", Name: "tickets_flight_fk", Column: "airline", ForeignTable: "flights", ForeignColumn: "airline"},
+				{Table: "tickets", Name: "tickets_flight_fk", Column: "number", ForeignTable: "flights", ForeignColumn: "number"},
+				{Table: "tickets", Name: "tickets_code_fk", Column: "code", ForeignTable: "flights", ForeignColumn: "code"},
+			},
+		},
+	}
+
+	setForeignKeyConstraints(&tables[1], tables)
+
+	fkeys := tables[1].FKeys
+	if !fkeys[0].ForeignIsPrimary || !fkeys[1].ForeignIsPrimary {
+		t.Errorf("composite key to the primary key should be primary: %#v", fkeys[:2])
+	}
+	if fkeys[2].ForeignIsPrimary {
+		t.Errorf("key to a unique column should not be primary: %#v", fkeys[2])
+	}
+}
+
+func TestTablesChan(t *testing.T) {
+	t.Parallel()
+
+	want, err := Tables(testMockDriver{}, "public", nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tables, errs := TablesChan(context.Background(), testMockDriver{}, "public", nil, nil, Options{})
+
+	var got []Table
+	for tbl := range tables {
+		got = append(got, tbl)
+	}
+	if err := <-errs; err != nil {
+		t.Fatal(err)
+	}
+
+	if len(got) != len(want) {
+		t.Fatalf("want %d tables, got %d", len(want), len(got))
+	}
+	for i := range want {
+		if got[i].Name != want[i].Name || len(got[i].Columns) != len(want[i].Columns) {
+			t.Errorf("%d) want table %s, got %s", i, want[i].Name, got[i].Name)
+		}
+		if len(got[i].ToManyRelationships) != 0 {
+			t.Errorf("%s: relationships should not be set", got[i].Name)
+		}
+	}
+}
+
+func TestTablesChanCancel(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	tables, errs := TablesChan(ctx, testMockDriver{}, "public", nil, nil, Options{})
+
+	<-tables
+	cancel()
+
+	// Nothing reads the next table, so the producer has to stop
+	if err := <-errs; err != context.Canceled {
+		t.Errorf("want context.Canceled, got: %v", err)
+	}
+}
+
+func TestTablesTableNamesQuery(t *testing.T) {
+	t.Parallel()
+
+	opts := Options{TableNamesQuery: "select name from generated_tables"}
+	if _, err := TablesWithOptions(testMockDriver{}, "public", nil, nil, opts); err == nil {
+		t.Error("expected an error for a driver without table names queries")
+	}
+
+	tables, err := TablesWithOptions(testDetailerDriver{}, "public", nil, []string{"airports"}, opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(tables) != 2 || tables[0].Name != "jets" || tables[1].Name != "pilots" {
+		t.Errorf("tables were wrong: %#v", tables)
+	}
+}
+
+// testNullTypeDriver gives nullable columns a null type
+type testNullTypeDriver struct {
+	testMockDriver
+}
+
+func (m testNullTypeDriver) TranslateColumnType(c Column) Column {
+	if c.Nullable {
+		c.Type = "null.String"
+	} else {
+		c.Type = "string"
+	}
+	return c
+}
+
+func TestTablesForceNullable(t *testing.T) {
+	t.Parallel()
+
+	tables, err := TablesWithOptions(testNullTypeDriver{}, "public", []string{"jets", "pilot_languages"}, nil, Options{ForceNullable: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want, err := TablesWithOptions(testNullTypeDriver{}, "public", []string{"jets", "pilot_languages"}, nil, Options{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for i, table := range tables {
+		for j, c := range table.Columns {
+			if c.Nullable != want[i].Columns[j].Nullable {
+				t.Errorf("%s.%s nullable should be left as it is", table.Name, c.Name)
+			}
+			if pkey := strmangle.SetInclude(c.Name, table.PKey.Columns); c.NullType() == pkey {
+				t.Errorf("%s.%s want a null type %t, got %s", table.Name, c.Name, !pkey, c.Type)
+			}
+		}
+	}
+}
+
+func TestSetDefaultType(t *testing.T) {
+	t.Parallel()
+
+	opts := Options{DefaultType: "[]byte", DefaultNullableType: "null.Bytes"}
+	c := Column{Name: "shape", Type: "string", UnknownType: true}
+	setDefaultType(&c, opts)
+	if c.Type != "[]byte" {
+		t.Errorf("want []byte, got %s", c.Type)
+	}
+
+	c = Column{Name: "shape", Type: "null.String", Nullable: true, UnknownType: true}
+	setDefaultType(&c, opts)
+	if c.Type != "null.Bytes" {
+		t.Errorf("want null.Bytes, got %s", c.Type)
+	}
+
+	c = Column{Name: "shape", Type: "null.String", Nullable: true, UnknownType: true}
+	setDefaultType(&c, Options{DefaultType: "[]byte"})
+	if c.Type != "null.String" {
+		t.Errorf("the nullable type should be kept, got %s", c.Type)
+	}
+}
+
+func TestTablesColumnType(t *testing.T) {
+	t.Parallel()
+
+	opts := Options{
+		ColumnType: func(table Table, c Column) (string, bool) {
+			if table.PKey == nil {
+				t.Errorf("%s should have its primary key", table.Name)
+			}
+			if table.Name == "jets" && c.Name == "name" {
+				return "JetName", true
+			}
+			return "", false
+		},
+	}
+
+	tables, err := TablesWithOptions(testMockDriver{}, "public", []string{"jets", "pilots"}, nil, opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, table := range tables {
+		c := table.GetColumn("name")
+		want := "string"
+		if table.Name == "jets" {
+			want = "JetName"
+		}
+		if c.Type != want {
+			t.Errorf("%s.name want %s, got %s", table.Name, want, c.Type)
+		}
+	}
+}
+
+func TestSetGoNames(t *testing.T) {
+	t.Parallel()
+
+	table := Table{Name: "pilot_urls", Columns: []Column{{Name: "pilot_id"}, {Name: "url"}}}
+	setGoNames(&table, nil, nil, nil)
+	if table.GoName != "PilotURL" || table.GoNamePlural != "PilotUrls" || table.Columns[0].GoName != "PilotID" || table.Columns[1].GoName != "URL" {
+		t.Errorf("names were wrong: %#v", table)
+	}
+	if table.VarName != "pilotURL" || table.VarNamePlural != "pilotUrls" {
+		t.Errorf("var names were wrong: %#v", table)
+	}
+
+	setGoNames(&table, nil, strmangle.CamelCase, nil)
+	if table.GoName != "pilotURL" || table.GoNamePlural != "pilotUrls" || table.Columns[0].GoName != "pilotID" {
+		t.Errorf("names weren't mapped: %#v", table)
+	}
+
+	table = Table{Name: "pg_catalog.pg_class"}
+	setGoNames(&table, nil, nil, nil)
+	if table.GoName != "PGCatalogPGClass" || table.VarName != "pgCatalogPGClass" {
+		t.Errorf("want the schema in the name of a qualified table, got: %s", table.GoName)
+	}
+
+	table = Table{Name: "information_schema.columns"}
+	setGoNames(&table, nil, nil, nil)
+	if table.GoName != "InformationSchemaColumn" || table.GoNamePlural != "InformationSchemaColumns" {
+		t.Errorf("want only the table's name made singular and plural, got: %s %s", table.GoName, table.GoNamePlural)
+	}
+}
+
+func TestSetGoNamesFold(t *testing.T) {
+	t.Parallel()
+
+	table := Table{Name: "PILOT_URLS", Columns: []Column{{Name: "PILOT_ID"}}}
+	setGoNames(&table, strings.ToLower, nil, nil)
+	if table.Name != "PILOT_URLS" || table.Columns[0].Name != "PILOT_ID" {
+		t.Errorf("the names should be left as they are: %#v", table)
+	}
+	if table.GoName != "PilotURL" || table.GoNamePlural != "PilotUrls" || table.Columns[0].GoName != "PilotID" {
+		t.Errorf("want Go names from the folded names: %#v", table)
+	}
+}
+
+func TestUnexported(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		In  string
+		Out string
+	}{
+		{"User", "user"},
+		{"PilotURL", "pilotURL"},
+		{"URLPart", "urlPart"},
+		{"ID", "id"},
+		{"pilot", "pilot"},
+	}
+
+	for _, test := range tests {
+		if out := unexported(test.In); out != test.Out {
+			t.Errorf("%s: want %s, got %s", test.In, test.Out, out)
+		}
+	}
+}
+
+func TestSetGoNamesTableAliases(t *testing.T) {
+	t.Parallel()
+
+	aliases := map[string]string{"tbl_usr": "User"}
+
+	table := Table{Name: "usr", DBName: "tbl_usr", Columns: []Column{{Name: "usr_id"}}}
+	setGoNames(&table, nil, nil, aliases)
+	if table.GoName != "User" || table.GoNamePlural != "Users" || table.VarName != "user" || table.Columns[0].GoName != "UsrID" {
+		t.Errorf("names were wrong: %#v", table)
+	}
+
+	table = Table{Name: "tbl_usr", DBName: "TBL_USR"}
+	setGoNames(&table, nil, nil, aliases)
+	if table.GoName != "User" {
+		t.Errorf("want the alias of the lowercased name, got: %s", table.GoName)
+	}
+
+	table = Table{Name: "pilots", DBName: "pilots"}
+	setGoNames(&table, nil, nil, aliases)
+	if table.GoName != "Pilot" || table.GoNamePlural != "Pilots" {
+		t.Errorf("want the mapped name for a table without an alias, got: %s", table.GoName)
+	}
+}
+
+type testFailingDriver struct {
+	testMockDriver
+}
+
+func (m testFailingDriver) Columns(schema, tableName string) ([]Column, error) {
+	if tableName == "pilots" {
+		return nil, errors.New("permission denied for table pilots")
+	}
+	return m.testMockDriver.Columns(schema, tableName)
+}
+
+func TestTablesSkipTableErrors(t *testing.T) {
+	t.Parallel()
+
+	if _, err := Tables(testFailingDriver{}, "public", nil, nil); err == nil {
+		t.Fatal("want an error without SkipTableErrors")
+	}
+
+	tables, err := TablesWithOptions(testFailingDriver{}, "public", nil, nil, Options{SkipTableErrors: true})
+	failed, ok := err.(TableErrors)
+	if !ok || len(failed) != 1 || failed["pilots"] == nil {
+		t.Fatalf("want the error of pilots, got: %v", err)
+	}
+	if !strings.Contains(failed.Error(), "permission denied for table pilots") {
+		t.Errorf("the error should have the cause: %s", failed.Error())
+	}
+
+	if len(tables) != 6 || GetTable(tables, "jets").Name != "jets" {
+		t.Fatalf("the other tables should be read: %#v", tables)
+	}
+	for _, table := range tables {
+		for _, fkey := range table.FKeys {
+			if fkey.ForeignTable == "pilots" {
+				t.Errorf("%s still has a foreign key to pilots", table.Name)
+			}
+		}
+	}
+	if jets := GetTable(tables, "jets"); jets.GetColumn("pilot_id").ForeignKey != nil || jets.GetColumn("airport_id").ForeignKey == nil {
+		t.Errorf("the column foreign keys of jets were wrong: %#v", jets.Columns)
+	}
+	if GetTable(tables, "pilot_languages").IsJoinTable {
+		t.Error("pilot_languages can't be a join table without pilots")
+	}
+}
+
+// testSavepointDriver keeps the results of the functions run in savepoints
+type testSavepointDriver struct {
+	testFailingDriver
+	results *[]error
+}
+
+func (m testSavepointDriver) WithSavepoint(fn func() error) error {
+	err := fn()
+	*m.results = append(*m.results, err)
+	return err
+}
+
+func TestTablesSavepoints(t *testing.T) {
+	t.Parallel()
+
+	var results []error
+	db := testSavepointDriver{results: &results}
+	if _, err := Tables(db, "public", nil, nil); err == nil {
+		t.Fatal("want an error without SkipTableErrors")
+	}
+	if len(results) != 0 {
+		t.Errorf("savepoints are only needed to skip table errors: %v", results)
+	}
+
+	tables, _ := TablesWithOptions(db, "public", nil, nil, Options{SkipTableErrors: true})
+	if len(results) != len(tables)+1 {
+		t.Fatalf("want a savepoint for each of the %d tables, got %d", len(tables)+1, len(results))
+	}
+
+	var failed int
+	for _, err := range results {
+		if err != nil {
+			failed++
+		}
+	}
+	if failed != 1 {
+		t.Errorf("only the savepoint of pilots should fail: %v", results)
+	}
+}
+
+func TestHasNotNullCheck(t *testing.T) {
+	t.Parallel()
+
+	checks := []Check{
+		{Name: "email_not_null", Expression: "(email IS NOT NULL)", Columns: []string{"email"}},
+		{Name: "name_not_null", Expression: "(`name` is not null)", Columns: []string{"name"}},
+		{Name: "age_positive", Expression: "((age IS NOT NULL) AND (age > 0))", Columns: []string{"age"}},
+		{Name: "either", Expression: "((phone IS NOT NULL) OR (fax IS NOT NULL))", Columns: []string{"phone", "fax"}},
+		{Name: "zip_not_null", Expression: "(zip IS NOT NULL)", Columns: []string{"zip"}, NotValid: true},
+	}
+
+	tests := []struct {
+		Column string
+		Want   bool
+	}{
+		{"email", true},
+		{"name", true},
+		{"age", false},
+		{"phone", false},
+		{"zip", false},
+		{"id", false},
+	}
+
+	for _, test := range tests {
+		if got := hasNotNullCheck(checks, test.Column); got != test.Want {
+			t.Errorf("%s want %t, got %t", test.Column, test.Want, got)
+		}
+	}
+}
+
+type testCheckDriver struct {
+	testMockDriver
+}
+
+func (m testCheckDriver) CheckInfo(schema, tableName string) ([]Check, error) {
+	if tableName != "jets" {
+		return nil, nil
+	}
+
+	return []Check{{Name: "jets_color_check", Expression: "(color IS NOT NULL)", Columns: []string{"color"}}}, nil
+}
+
+func TestTablesNotNullChecks(t *testing.T) {
+	t.Parallel()
+
+	tables, err := TablesWithOptions(testCheckDriver{}, "public", []string{"jets"}, nil, Options{NotNullChecks: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if jets := GetTable(tables, "jets"); jets.GetColumn("color").Nullable || !jets.GetColumn("uuid").Nullable {
+		t.Errorf("only color has a not null check: %#v", jets.Columns)
+	}
+}
+
+func TestSetColumnForeignKeys(t *testing.T) {
+	t.Parallel()
+
+	tables, err := Tables(testMockDriver{}, "public", nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	jets := GetTable(tables, "jets")
+	want := map[string]string{"id": "", "pilot_id": "pilots", "airport_id": "airports"}
+	for _, c := range jets.Columns {
+		foreign, ok := want[c.Name]
+		if !ok {
+			continue
+		}
+
+		switch {
+		case len(foreign) == 0 && c.ForeignKey != nil:
+			t.Errorf("%s should not be a foreign key: %#v", c.Name, c.ForeignKey)
+		case len(foreign) != 0 && (c.ForeignKey == nil || c.ForeignKey.ForeignTable != foreign):
+			t.Errorf("%s should reference %s: %#v", c.Name, foreign, c.ForeignKey)
+		}
+	}
+
+	// The constraints are set on FKeys after the columns point at them
+	if fkey := jets.Columns[1].ForeignKey; fkey == nil || !fkey.Nullable {
+		t.Errorf("pilot_id foreign key should be nullable: %#v", fkey)
+	}
+}
+
+func TestCheckUnknownTypes(t *testing.T) {
+	t.Parallel()
+
+	table := Table{
+		Name: "pilots",
+		Columns: []Column{
+			{Name: "id", DBType: "integer"},
+			{Name: "ship", DBType: "starship", UnknownType: true},
+			{Name: "shape", DBType: "tesseract", UnknownType: true},
+		},
+	}
+
+	err := checkUnknownTypes(table)
+	if err == nil {
+		t.Fatal("expected an error for the unknown types")
+	}
+	if want := "unknown column types in table pilots: ship (starship), shape (tesseract)"; err.Error() != want {
+		t.Errorf("want: %s, got: %s", want, err)
+	}
+
+	table.Columns = table.Columns[:1]
+	if err := checkUnknownTypes(table); err != nil {
+		t.Error(err)
+	}
+}
@@ -1,15 +1,34 @@
 package bdb
 
-import "fmt"
+import (
+	"fmt"
+
+	"github.com/volatiletech/sqlboiler/strmangle"
+)
 
 // PrimaryKey represents a primary key constraint in a database
 type PrimaryKey struct {
 	Name    string
 	Columns []string
+	// Comment is the comment on the constraint, for drivers that read it.
+	Comment string
+}
+
+// UniqueKey represents a unique constraint or index in a database
+type UniqueKey struct {
+	Name    string
+	Columns []string
+	// IsAlternateKey is true when the columns are not the same as
+	// the primary key's, ie. it's a natural key beside the surrogate one.
+	IsAlternateKey bool
+	// Predicate is the where clause of the partial index the key comes
+	// from, the columns are only unique for the rows that match it.
+	Predicate string
 }
 
 // ForeignKey represents a foreign key constraint in a database
 type ForeignKey struct {
+	Schema   string
 	Table    string
 	Name     string
 	Column   string
@@ -20,6 +39,157 @@ type ForeignKey struct {
 	ForeignColumn         string
 	ForeignColumnNullable bool
 	ForeignColumnUnique   bool
+	// ForeignIsPrimary is true when the foreign key references the primary
+	// key of the foreign table, and false when it references a unique key.
+	ForeignIsPrimary bool
+	// MatchType is one of the Match constants, it decides whether a
+	// composite foreign key with some NULL columns is checked.
+	MatchType string
+	// Comment is the comment on the constraint, for drivers that read it.
+	Comment string
+	// NotValid is true for a foreign key added NOT VALID in postgres or
+	// WITH NOCHECK in mssql, it's checked for new rows but the existing ones
+	// may not match it.
+	NotValid bool
+}
+
+// Foreign key match types, MatchSimple is the default of every database.
+const (
+	MatchSimple  = "SIMPLE"
+	MatchFull    = "FULL"
+	MatchPartial = "PARTIAL"
+)
+
+// Check represents a CHECK constraint, Columns are the columns used in
+// the expression.
+type Check struct {
+	Name       string
+	Expression string
+	Columns    []string
+	// NotValid is true for a postgres check added NOT VALID, the existing
+	// rows may not pass it.
+	NotValid bool
+}
+
+// Exclusion represents an exclusion constraint, eg: Postgres'
+// exclude using gist (room with =, during with &&)
+type Exclusion struct {
+	Name string
+	// Method is the index access method, eg: gist
+	Method     string
+	Definition string
+	Elements   []ExclusionElement
+}
+
+// ExclusionElement is one part of an exclusion constraint, Column is empty
+// when the element is an expression.
+type ExclusionElement struct {
+	Column   string
+	Operator string
+	OpClass  string
+}
+
+// Index represents an index on a table
+type Index struct {
+	Name    string
+	Unique  bool
+	Columns []IndexColumn
+	// Include holds the non-key columns of a covering index,
+	// eg: create index ... include (a, b)
+	Include []string
+	// Comment is the comment on the index, for drivers that read it.
+	Comment string
+	// Predicate is the where clause of a partial index, eg:
+	// (deleted_at IS NULL), empty when the index covers every row. A
+	// unique index with a predicate is only unique for the rows it covers.
+	Predicate string
+}
+
+// IndexColumn is a key column of an index in index order, Name is empty
+// when the key is an expression.
+type IndexColumn struct {
+	Name string
+	// Expression is the expression of an expression key, eg: lower(email)
+	Expression string
+	Descending bool
+}
+
+// HasExpression returns true if one of the key columns of the index is an
+// expression, the index then can't be used as a key of its columns.
+func (i Index) HasExpression() bool {
+	for _, c := range i.Columns {
+		if len(c.Expression) != 0 {
+			return true
+		}
+	}
+
+	return false
+}
+
+// IndexColumnNames returns the names of the key columns of the index
+func (i Index) IndexColumnNames() []string {
+	names := make([]string, len(i.Columns))
+	for j, c := range i.Columns {
+		names[j] = c.Name
+	}
+
+	return names
+}
+
+// ForeignKeyGroups groups the columns of each foreign key constraint
+// together, keeping the order they were given in. Constraints are told
+// apart by schema, table and name since constraint names are not unique
+// across tables in every database.
+func ForeignKeyGroups(fkeys []ForeignKey) [][]ForeignKey {
+	type key struct{ schema, table, name string }
+
+	var groups [][]ForeignKey
+	indexes := map[key]int{}
+	for _, f := range fkeys {
+		k := key{f.Schema, f.Table, f.Name}
+		i, ok := indexes[k]
+		if !ok {
+			i = len(groups)
+			indexes[k] = i
+			groups = append(groups, nil)
+		}
+
+		groups[i] = append(groups[i], f)
+	}
+
+	return groups
+}
+
+// RelationshipName suggests a name for the relationship made by the foreign
+// key, from its column without the identifier suffix, eg: sender for
+// sender_id. It tells apart keys to the same table, which the foreign
+// table's name can't.
+func (f ForeignKey) RelationshipName() string {
+	return strmangle.TrimIdentifierSuffix(f.Column)
+}
+
+// RelationshipNames suggests a distinct relationship name for each foreign
+// key constraint of a table, by constraint name. It's the RelationshipName
+// of the constraint's first column, or the constraint's name when another
+// constraint would get the same one.
+func RelationshipNames(fkeys []ForeignKey) map[string]string {
+	groups := ForeignKeyGroups(fkeys)
+
+	counts := map[string]int{}
+	for _, g := range groups {
+		counts[g[0].RelationshipName()]++
+	}
+
+	names := make(map[string]string, len(groups))
+	for _, g := range groups {
+		name := g[0].RelationshipName()
+		if counts[name] > 1 {
+			name = g[0].Name
+		}
+		names[g[0].Name] = name
+	}
+
+	return names
 }
 
 // SQLColumnDef formats a column name and type like an SQL column definition.
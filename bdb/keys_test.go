@@ -1,6 +1,9 @@
 package bdb
 
-import "testing"
+import (
+	"reflect"
+	"testing"
+)
 
 func TestSQLColDefinitions(t *testing.T) {
 	t.Parallel()
@@ -64,3 +67,55 @@ func TestNames(t *testing.T) {
 		t.Error("wrong type:", ret[1])
 	}
 }
+
+func TestForeignKeyGroups(t *testing.T) {
+	t.Parallel()
+
+	fkeys := []ForeignKey{
+		{Schema: "public", Table: "jets", Name: "owner_fk", Column: "owner_id"},
+		{Schema: "public", Table: "jets", Name: "hangar_fk", Column: "hangar_id"},
+		{Schema: "public", Table: "jets", Name: "hangar_fk", Column: "hangar_bay"},
+		{Schema: "public", Table: "boats", Name: "owner_fk", Column: "owner_id"},
+		{Schema: "archive", Table: "jets", Name: "owner_fk", Column: "owner_id"},
+	}
+
+	groups := ForeignKeyGroups(fkeys)
+	if len(groups) != 4 {
+		t.Fatalf("want 4 groups, got: %#v", groups)
+	}
+
+	if len(groups[0]) != 1 || groups[0][0].Table != "jets" || groups[0][0].Schema != "public" {
+		t.Errorf("group 0 was wrong: %#v", groups[0])
+	}
+	if len(groups[1]) != 2 || groups[1][0].Column != "hangar_id" || groups[1][1].Column != "hangar_bay" {
+		t.Errorf("group 1 was wrong: %#v", groups[1])
+	}
+	if len(groups[2]) != 1 || groups[2][0].Table != "boats" {
+		t.Errorf("group 2 was wrong: %#v", groups[2])
+	}
+	if len(groups[3]) != 1 || groups[3][0].Schema != "archive" {
+		t.Errorf("group 3 was wrong: %#v", groups[3])
+	}
+}
+
+func TestRelationshipNames(t *testing.T) {
+	t.Parallel()
+
+	fkeys := []ForeignKey{
+		{Name: "messages_sender_fkey", Column: "sender_id", ForeignTable: "users", ForeignColumn: "id"},
+		{Name: "messages_recipient_fkey", Column: "recipient_id", ForeignTable: "users", ForeignColumn: "id"},
+		{Name: "messages_thread_fkey", Column: "thread_id", ForeignTable: "threads", ForeignColumn: "id"},
+		{Name: "messages_thread_fkey", Column: "thread_rev", ForeignTable: "threads", ForeignColumn: "rev"},
+		{Name: "messages_thread_owner_fkey", Column: "thread_id", ForeignTable: "thread_owners", ForeignColumn: "thread_id"},
+	}
+
+	want := map[string]string{
+		"messages_sender_fkey":       "sender",
+		"messages_recipient_fkey":    "recipient",
+		"messages_thread_fkey":       "messages_thread_fkey",
+		"messages_thread_owner_fkey": "messages_thread_owner_fkey",
+	}
+	if got := RelationshipNames(fkeys); !reflect.DeepEqual(got, want) {
+		t.Errorf("want %v, got %v", want, got)
+	}
+}
@@ -145,3 +145,161 @@ func buildToManyRelationship(localTable Table, foreignKey ForeignKey, foreignTab
 
 	return relationship
 }
+
+// Cardinalities of a RelationshipEdge
+const (
+	CardinalityOne  = "one"
+	CardinalityMany = "many"
+)
+
+// RelationshipEdge is a relationship from Table to ForeignTable through the
+// foreign key named ForeignKey. Cardinality is how many rows of ForeignTable
+// a row of Table has, Optional is true when it may have none. Outgoing is
+// true when the foreign key is on Table, and JoinTable is set when the
+// tables are related through a join table, ForeignKey is then the join
+// table's key to ForeignTable.
+type RelationshipEdge struct {
+	Table          string
+	Columns        []string
+	ForeignTable   string
+	ForeignColumns []string
+	ForeignKey     string
+
+	Cardinality string
+	Optional    bool
+	Outgoing    bool
+	JoinTable   string
+}
+
+// RelationshipGraph holds the relationships of each table, by table name.
+// Every table is in it even if it has no relationships, join tables only
+// show up as the JoinTable of the edges between the tables they join.
+type RelationshipGraph map[string][]RelationshipEdge
+
+// BuildRelationshipGraph builds the relationships between the tables from
+// their foreign keys, in both directions. Foreign keys to tables that aren't
+// in tables are left out.
+func BuildRelationshipGraph(tables []Table) RelationshipGraph {
+	graph := RelationshipGraph{}
+	for _, t := range tables {
+		if !t.IsJoinTable {
+			graph[t.Name] = nil
+		}
+	}
+
+	for _, t := range tables {
+		fkeys := groupForeignKeys(t.FKeys)
+
+		if t.IsJoinTable {
+			if len(fkeys) != 2 {
+				continue
+			}
+			a, b := fkeys[0], fkeys[1]
+			graph.addJoin(t.Name, a, b)
+			graph.addJoin(t.Name, b, a)
+			continue
+		}
+
+		for _, f := range fkeys {
+			if _, ok := graph[f.ForeignTable]; !ok {
+				continue
+			}
+
+			graph[t.Name] = append(graph[t.Name], RelationshipEdge{
+				Table:          t.Name,
+				Columns:        f.Columns,
+				ForeignTable:   f.ForeignTable,
+				ForeignColumns: f.ForeignColumns,
+				ForeignKey:     f.Name,
+				Cardinality:    CardinalityOne,
+				Optional:       f.Nullable,
+				Outgoing:       true,
+			})
+
+			cardinality := CardinalityMany
+			if f.Unique {
+				cardinality = CardinalityOne
+			}
+			graph[f.ForeignTable] = append(graph[f.ForeignTable], RelationshipEdge{
+				Table:          f.ForeignTable,
+				Columns:        f.ForeignColumns,
+				ForeignTable:   t.Name,
+				ForeignColumns: f.Columns,
+				ForeignKey:     f.Name,
+				Cardinality:    cardinality,
+				Optional:       true,
+			})
+		}
+	}
+
+	return graph
+}
+
+// Related returns the names of the tables related to table, without
+// duplicates and in the order of their first edge.
+func (g RelationshipGraph) Related(table string) []string {
+	var names []string
+	seen := map[string]bool{}
+	for _, e := range g[table] {
+		if !seen[e.ForeignTable] {
+			seen[e.ForeignTable] = true
+			names = append(names, e.ForeignTable)
+		}
+	}
+
+	return names
+}
+
+// addJoin adds the many to many edge from the table of local to the table
+// of foreign through joinTable.
+func (g RelationshipGraph) addJoin(joinTable string, local, foreign graphForeignKey) {
+	if _, ok := g[local.ForeignTable]; !ok {
+		return
+	}
+	if _, ok := g[foreign.ForeignTable]; !ok {
+		return
+	}
+
+	g[local.ForeignTable] = append(g[local.ForeignTable], RelationshipEdge{
+		Table:          local.ForeignTable,
+		Columns:        local.ForeignColumns,
+		ForeignTable:   foreign.ForeignTable,
+		ForeignColumns: foreign.ForeignColumns,
+		ForeignKey:     foreign.Name,
+		Cardinality:    CardinalityMany,
+		Optional:       true,
+		JoinTable:      joinTable,
+	})
+}
+
+// graphForeignKey is a foreign key constraint with all of its columns
+type graphForeignKey struct {
+	Name           string
+	Columns        []string
+	ForeignTable   string
+	ForeignColumns []string
+	Nullable       bool
+	Unique         bool
+}
+
+// groupForeignKeys puts the columns of each foreign key constraint together.
+// A composite key is never unique since that's only known for single
+// columns.
+func groupForeignKeys(fkeys []ForeignKey) []graphForeignKey {
+	var grouped []graphForeignKey
+	for _, group := range ForeignKeyGroups(fkeys) {
+		g := graphForeignKey{
+			Name:         group[0].Name,
+			ForeignTable: group[0].ForeignTable,
+			Unique:       len(group) == 1 && group[0].Unique,
+		}
+		for _, f := range group {
+			g.Columns = append(g.Columns, f.Column)
+			g.ForeignColumns = append(g.ForeignColumns, f.ForeignColumn)
+			g.Nullable = g.Nullable || f.Nullable
+		}
+		grouped = append(grouped, g)
+	}
+
+	return grouped
+}
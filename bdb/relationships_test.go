@@ -305,3 +305,88 @@ func TestToManyRelationshipsNull(t *testing.T) {
 		}
 	}
 }
+
+func TestBuildRelationshipGraph(t *testing.T) {
+	t.Parallel()
+
+	tables := []Table{
+		{Name: "pilots"},
+		{Name: "languages"},
+		{
+			Name: "jets",
+			FKeys: []ForeignKey{
+				{Name: "jets_pilot_id_fk", Column: "pilot_id", ForeignTable: "pilots", ForeignColumn: "id", Nullable: true},
+				{Name: "jets_hangar_id_fk", Column: "hangar_id", ForeignTable: "hangars", ForeignColumn: "id"},
+			},
+		},
+		{
+			Name: "licenses",
+			FKeys: []ForeignKey{
+				{Name: "licenses_pilot_id_fk", Column: "pilot_id", ForeignTable: "pilots", ForeignColumn: "id", Unique: true},
+			},
+		},
+		{
+			Name:        "pilot_languages",
+			IsJoinTable: true,
+			FKeys: []ForeignKey{
+				{Name: "pilot_id_fk", Column: "pilot_id", ForeignTable: "pilots", ForeignColumn: "id"},
+				{Name: "language_id_fk", Column: "language_id", ForeignTable: "languages", ForeignColumn: "id"},
+			},
+		},
+	}
+
+	graph := BuildRelationshipGraph(tables)
+
+	if _, ok := graph["pilot_languages"]; ok {
+		t.Error("join tables should not be in the graph")
+	}
+
+	want := []RelationshipEdge{
+		{
+			Table: "pilots", Columns: []string{"id"}, ForeignTable: "jets", ForeignColumns: []string{"pilot_id"},
+			ForeignKey: "jets_pilot_id_fk", Cardinality: CardinalityMany, Optional: true,
+		},
+		{
+			Table: "pilots", Columns: []string{"id"}, ForeignTable: "licenses", ForeignColumns: []string{"pilot_id"},
+			ForeignKey: "licenses_pilot_id_fk", Cardinality: CardinalityOne, Optional: true,
+		},
+		{
+			Table: "pilots", Columns: []string{"id"}, ForeignTable: "languages", ForeignColumns: []string{"id"},
+			ForeignKey: "language_id_fk", Cardinality: CardinalityMany, Optional: true, JoinTable: "pilot_languages",
+		},
+	}
+	if !reflect.DeepEqual(graph["pilots"], want) {
+		t.Errorf("pilots edges were wrong:\n\nwant:%#v\n\ngot:%#v", want, graph["pilots"])
+	}
+
+	jets := []RelationshipEdge{
+		{
+			Table: "jets", Columns: []string{"pilot_id"}, ForeignTable: "pilots", ForeignColumns: []string{"id"},
+			ForeignKey: "jets_pilot_id_fk", Cardinality: CardinalityOne, Optional: true, Outgoing: true,
+		},
+	}
+	if !reflect.DeepEqual(graph["jets"], jets) {
+		t.Errorf("jets edges were wrong:\n\nwant:%#v\n\ngot:%#v", jets, graph["jets"])
+	}
+
+	if related := graph.Related("languages"); !reflect.DeepEqual(related, []string{"pilots"}) {
+		t.Errorf("languages should only be related to pilots: %v", related)
+	}
+}
+
+func TestGroupForeignKeys(t *testing.T) {
+	t.Parallel()
+
+	grouped := groupForeignKeys([]ForeignKey{
+		{Name: "lines_order_fk", Column: "order_id", ForeignTable: "orders", ForeignColumn: "id", Unique: true},
+		{Name: "lines_order_fk", Column: "order_rev", ForeignTable: "orders", ForeignColumn: "rev", Nullable: true},
+	})
+
+	want := []graphForeignKey{{
+		Name: "lines_order_fk", Columns: []string{"order_id", "order_rev"},
+		ForeignTable: "orders", ForeignColumns: []string{"id", "rev"}, Nullable: true,
+	}}
+	if !reflect.DeepEqual(grouped, want) {
+		t.Errorf("want %#v, got %#v", want, grouped)
+	}
+}
@@ -0,0 +1,24 @@
+package bdb
+
+// Sequence describes the sequence a column takes its values from
+type Sequence struct {
+	Schema string
+	Name   string
+	// DataType is smallint, integer or bigint. Sequences were all bigint
+	// before Postgres 10.
+	DataType  string
+	Start     int64
+	Increment int64
+	Min       int64
+	Max       int64
+	// Cycle is true when the sequence starts over from Min (or Max when
+	// descending) once it runs out, instead of failing.
+	Cycle bool
+	// CacheSize is how many values a session allocates ahead of time, 0
+	// when the driver can't tell.
+	CacheSize int64
+	// OwnedBy is the table.column the sequence is owned by, it's dropped
+	// with the column, eg: for a serial or identity column. It's empty
+	// when the sequence stands on its own.
+	OwnedBy string
+}
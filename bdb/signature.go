@@ -0,0 +1,79 @@
+package bdb
+
+import (
+	"sort"
+
+	"github.com/pkg/errors"
+)
+
+// ColumnSignature is the part of a column that changes when its type does
+type ColumnSignature struct {
+	DBType   string
+	Nullable bool
+}
+
+// ColumnSignatures reads the database type and nullability of every column,
+// by table.column. Only the table names and columns are read and nothing is
+// translated, so it's cheap to compare with a stored signature to catch
+// column types changing under the generated models.
+func ColumnSignatures(db Interface, schema string, whitelist, blacklist []string) (map[string]ColumnSignature, error) {
+	names, err := db.TableNames(schema, whitelist, blacklist)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to get table names")
+	}
+
+	signatures := map[string]ColumnSignature{}
+	for _, name := range names {
+		columns, err := db.Columns(schema, name)
+		if err != nil {
+			return nil, errors.Wrapf(err, "unable to fetch table column info (%s)", name)
+		}
+
+		for _, c := range columns {
+			signatures[name+"."+c.Name] = columnSignature(c)
+		}
+	}
+
+	return signatures, nil
+}
+
+// columnSignature of the column
+func columnSignature(c Column) ColumnSignature {
+	return ColumnSignature{DBType: signatureDBType(c), Nullable: c.Nullable}
+}
+
+// signatureDBType is the most precise type the driver gave the column,
+// eg: varchar(32) from mysql rather than varchar, or the element type of a
+// postgres array.
+func signatureDBType(c Column) string {
+	switch {
+	case len(c.FullDBType) != 0:
+		return c.FullDBType
+	case c.DBType == "ARRAY" && c.ArrType != nil:
+		return *c.ArrType + "[]"
+	case c.DBType == "USER-DEFINED" && len(c.UDTName) != 0:
+		return c.UDTName
+	}
+
+	return c.DBType
+}
+
+// SignatureChanges returns the sorted table.column names whose signature
+// differs between stored and current, including the ones that were added
+// or removed.
+func SignatureChanges(stored, current map[string]ColumnSignature) []string {
+	var changed []string
+	for name, sig := range current {
+		if old, ok := stored[name]; !ok || old != sig {
+			changed = append(changed, name)
+		}
+	}
+	for name := range stored {
+		if _, ok := current[name]; !ok {
+			changed = append(changed, name)
+		}
+	}
+
+	sort.Strings(changed)
+	return changed
+}
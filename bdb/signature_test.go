@@ -0,0 +1,69 @@
+package bdb
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestColumnSignatures(t *testing.T) {
+	t.Parallel()
+
+	signatures, err := ColumnSignatures(testMockDriver{}, "public", []string{"airports"}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := map[string]ColumnSignature{
+		"airports.id":   {DBType: "integer"},
+		"airports.size": {DBType: "integer", Nullable: true},
+	}
+	if !reflect.DeepEqual(signatures, want) {
+		t.Errorf("want %#v, got %#v", want, signatures)
+	}
+}
+
+func TestSignatureDBType(t *testing.T) {
+	t.Parallel()
+
+	elem := "integer"
+	tests := []struct {
+		Column Column
+		Want   string
+	}{
+		{Column{DBType: "integer"}, "integer"},
+		{Column{DBType: "varchar", FullDBType: "varchar(32)"}, "varchar(32)"},
+		{Column{DBType: "ARRAY", ArrType: &elem}, "integer[]"},
+		{Column{DBType: "USER-DEFINED", UDTName: "hstore"}, "hstore"},
+	}
+
+	for i, test := range tests {
+		if got := signatureDBType(test.Column); got != test.Want {
+			t.Errorf("%d) want %s, got %s", i, test.Want, got)
+		}
+	}
+}
+
+func TestSignatureChanges(t *testing.T) {
+	t.Parallel()
+
+	stored := map[string]ColumnSignature{
+		"jets.id":    {DBType: "integer"},
+		"jets.name":  {DBType: "text"},
+		"jets.color": {DBType: "text", Nullable: true},
+		"jets.gone":  {DBType: "text"},
+	}
+	current := map[string]ColumnSignature{
+		"jets.id":    {DBType: "integer"},
+		"jets.name":  {DBType: "varchar(32)"},
+		"jets.color": {DBType: "text"},
+		"jets.new":   {DBType: "text"},
+	}
+
+	want := []string{"jets.color", "jets.gone", "jets.name", "jets.new"}
+	if got := SignatureChanges(stored, current); !reflect.DeepEqual(got, want) {
+		t.Errorf("want %v, got %v", want, got)
+	}
+	if got := SignatureChanges(stored, stored); got != nil {
+		t.Errorf("want no changes, got %v", got)
+	}
+}
@@ -0,0 +1,29 @@
+package bdb
+
+import (
+	"fmt"
+	"time"
+)
+
+// Stats records how much metadata Tables read and how long each phase took.
+// Pass one in Options.Stats to have it filled in.
+type Stats struct {
+	Tables      int
+	Columns     int
+	ForeignKeys int
+
+	TableNamesTime  time.Duration
+	ColumnsTime     time.Duration
+	PrimaryKeysTime time.Duration
+	ForeignKeysTime time.Duration
+	Total           time.Duration
+}
+
+// String for fmt.Stringer
+func (s Stats) String() string {
+	return fmt.Sprintf(
+		"%d tables, %d columns, %d foreign keys in %s (table names: %s, columns: %s, primary keys: %s, foreign keys: %s)",
+		s.Tables, s.Columns, s.ForeignKeys, s.Total,
+		s.TableNamesTime, s.ColumnsTime, s.PrimaryKeysTime, s.ForeignKeysTime,
+	)
+}
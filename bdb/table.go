@@ -1,20 +1,146 @@
 package bdb
 
-import "fmt"
+import (
+	"fmt"
+
+	"github.com/volatiletech/sqlboiler/strmangle"
+)
+
+// Table persistence values, these mirror Postgres' pg_class.relpersistence.
+const (
+	PersistencePermanent = "permanent"
+	PersistenceUnlogged  = "unlogged"
+	PersistenceTemporary = "temporary"
+)
+
+// Trigger is a trigger on a table. Timing is BEFORE, AFTER or INSTEAD OF
+// and Event is the statements it fires on, eg: INSERT OR UPDATE. Function
+// is what the trigger runs, for postgres a function call and for mysql the
+// trigger's body.
+type Trigger struct {
+	Name     string
+	Timing   string
+	Event    string
+	Function string
+}
+
+// Policy is a row level security policy on a table. Command is the
+// statement it applies to (ALL, SELECT, INSERT, UPDATE or DELETE), Roles
+// are the roles it applies to and Using and WithCheck are its expressions,
+// empty when it has none.
+type Policy struct {
+	Name       string
+	Command    string
+	Permissive bool
+	Roles      []string
+	Using      string
+	WithCheck  string
+}
+
+// Grant is a privilege on a table given to a role (the Grantee), eg:
+// SELECT or INSERT. Grantable is true when the grantee can grant it to
+// others as well.
+type Grant struct {
+	Grantee   string
+	Privilege string
+	Grantable bool
+}
 
 // Table metadata from the database schema.
 type Table struct {
 	Name string
+	// DBName is the name of the table in the database, it's the same as
+	// Name unless the names were normalized.
+	DBName string
+	// GoName is the name of the table's model, its singular Name made by
+	// Options.NameMapper or its alias, and GoNamePlural the name of a
+	// slice of them. VarName and VarNamePlural are the same names
+	// unexported, for the model's unexported variables.
+	GoName        string
+	GoNamePlural  string
+	VarName       string
+	VarNamePlural string
 	// For dbs with real schemas, like Postgres.
 	// Example value: "schema_name"."table_name"
 	SchemaName string
 	Columns    []Column
 
-	PKey  *PrimaryKey
-	FKeys []ForeignKey
+	PKey    *PrimaryKey
+	FKeys   []ForeignKey
+	UKeys   []UniqueKey
+	Indexes []Index
+	// Checks holds every check constraint, the ones on a single column
+	// are also in that column's Checks.
+	Checks []Check
+	// Exclusions are only read by drivers that support them.
+	Exclusions []Exclusion
+	// Triggers are only read when Options.Triggers is set, by drivers
+	// that support it.
+	Triggers []Trigger
+	// Policies are the row level security policies, they are only read
+	// when Options.Policies is set, by drivers that support it.
+	Policies []Policy
 
 	IsJoinTable bool
 
+	// Persistence is one of the Persistence constants, it is only read
+	// by drivers that support it when extended metadata is enabled and
+	// is otherwise always PersistencePermanent.
+	Persistence string
+
+	// IsView is true for views and materialized views, ViewDefinition is
+	// the query they are made from. Both are read by drivers that support
+	// it whether or not extended metadata is enabled.
+	IsView         bool
+	IsMaterialized bool
+	ViewDefinition string
+	// IsUpdatable is false for views that can't be inserted into, updated
+	// or deleted from, tables are always updatable. Like IsView it doesn't
+	// need extended metadata.
+	IsUpdatable bool
+	// IsRemote is true for a view whose rows come from another database,
+	// eg: through postgres' dblink. Its columns are still read like any
+	// other view's but nothing is known about the remote tables, so it has
+	// no keys or relationships. It is set with IsView, so it doesn't need
+	// extended metadata either.
+	IsRemote bool
+
+	// IsPartitioned is true for a partitioned table, PartitionKey are the
+	// columns it's partitioned by. Expressions in the partition key have no
+	// column and are left out. Both are only read by drivers that support
+	// it when extended metadata is enabled.
+	IsPartitioned bool
+	PartitionKey  []string
+
+	// Inherits are the tables a postgres table INHERITS from, a child has
+	// the columns of its parents as well as its own. Partitions don't count.
+	// It's only read when extended metadata is enabled.
+	Inherits []string
+
+	// Grants are the privileges given on the table, they're only read when
+	// extended metadata is enabled.
+	Grants []Grant
+
+	// OID is the oid of the table's pg_class row, for joining against the
+	// postgres catalogs. It's only read when extended metadata is enabled
+	// and is otherwise 0.
+	OID uint32
+
+	// TotalSizeBytes is roughly how much disk the table takes up, with its
+	// indexes and TOAST data, from pg_total_relation_size of its OID. It's
+	// only read when extended metadata is enabled. Views take up none, unlike
+	// materialized views.
+	TotalSizeBytes int64
+
+	// Engine is the mysql storage engine of the table, eg: InnoDB or MyISAM,
+	// empty for views. MyISAM tables have no foreign keys or transactions.
+	// Collation is the table's default collation and Charset its character
+	// set, eg: utf8mb4_general_ci and utf8mb4. They're only read by mysql,
+	// when extended metadata is enabled.
+	Engine    string
+	Collation string
+	Charset   string
+
 	ToOneRelationships  []ToOneRelationship
 	ToManyRelationships []ToManyRelationship
 }
@@ -41,6 +167,29 @@ func (t Table) GetColumn(name string) (col Column) {
 	panic(fmt.Sprintf("could not find column name: %s", name))
 }
 
+// ColumnGoNames returns the GoNames of the columns of the table with these
+// names. Panics if one isn't found (for use in templates mostly).
+func (t Table) ColumnGoNames(names []string) []string {
+	goNames := make([]string, len(names))
+	for i, name := range names {
+		goNames[i] = t.GetColumn(name).GoName
+	}
+
+	return goNames
+}
+
+// SingularName suggests a name for a single row of the table, eg: "person"
+// for the "people" table. Custom inflections can be added with
+// strmangle.AddIrregulars and strmangle.AddUncountables.
+func (t Table) SingularName() string {
+	return strmangle.Singular(t.Name)
+}
+
+// IsPlural checks if the table name is a plural word.
+func (t Table) IsPlural() bool {
+	return strmangle.IsPlural(t.Name)
+}
+
 // CanLastInsertID checks the following:
 // 1. Is there only one primary key?
 // 2. Does the primary key column have a default value?
@@ -0,0 +1,47 @@
+package bdb
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// TableErrors are the errors of the tables that couldn't be read when
+// Options.SkipTableErrors is set, by table name. The other tables are
+// returned along with them.
+type TableErrors map[string]error
+
+// Error lists the error of each table, in table name order.
+func (t TableErrors) Error() string {
+	names := t.Tables()
+
+	msgs := make([]string, len(names))
+	for i, name := range names {
+		msgs[i] = fmt.Sprintf("%s: %v", name, t[name])
+	}
+
+	return fmt.Sprintf("unable to read %d tables: %s", len(names), strings.Join(msgs, "; "))
+}
+
+// Tables returns the sorted names of the tables that couldn't be read.
+func (t TableErrors) Tables() []string {
+	names := make([]string, 0, len(t))
+	for name := range t {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names
+}
+
+// removeFailedTables takes the foreign keys to the tables that couldn't be
+// read out of the tables that were, there are no models to relate them to.
+func removeFailedTables(tables []Table, failed TableErrors, loose bool) {
+	names := failed.Tables()
+	for i := range tables {
+		filterForeignKeys(&tables[i], nil, names)
+		tables[i].IsJoinTable = false
+		setIsJoinTable(&tables[i], loose)
+		setColumnForeignKeys(&tables[i])
+	}
+}
@@ -118,3 +118,27 @@ func TestCanLastInsertID(t *testing.T) {
 		}
 	}
 }
+
+func TestTableSingularName(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		Name     string
+		Singular string
+		IsPlural bool
+	}{
+		{"people", "person", true},
+		{"pilot_languages", "pilot_language", true},
+		{"pilot", "pilot", false},
+	}
+
+	for i, test := range tests {
+		table := Table{Name: test.Name}
+		if got := table.SingularName(); got != test.Singular {
+			t.Errorf("%d) want singular %s, got: %s", i, test.Singular, got)
+		}
+		if got := table.IsPlural(); got != test.IsPlural {
+			t.Errorf("%d) want plural %t, got: %t", i, test.IsPlural, got)
+		}
+	}
+}
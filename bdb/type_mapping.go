@@ -0,0 +1,63 @@
+package bdb
+
+import "sort"
+
+// TypeMapping is a database type and the Go types it's translated to
+type TypeMapping struct {
+	// DBType is the full database type, array types are written as their
+	// element type followed by [], eg: integer[]
+	DBType       string
+	Type         string
+	NullableType string
+}
+
+// TypeMappings translates the types of cols with db, once as a not null
+// and once as a nullable column. Types the driver doesn't know show up
+// with its fallback type, usually string.
+func TypeMappings(db Interface, cols []Column) []TypeMapping {
+	mappings := make([]TypeMapping, len(cols))
+	for i, c := range cols {
+		m := TypeMapping{DBType: c.DBType}
+		if len(c.FullDBType) != 0 {
+			m.DBType = c.FullDBType
+		}
+		if c.ArrType != nil {
+			m.DBType = *c.ArrType + "[]"
+		}
+
+		c.Nullable = false
+		m.Type = db.TranslateColumnType(c).Type
+		c.Nullable = true
+		m.NullableType = db.TranslateColumnType(c).Type
+
+		mappings[i] = m
+	}
+
+	return mappings
+}
+
+// KnownTypes returns the sorted names of the database types of cols, without
+// the sizes of FullDBType. Arrays are their element type followed by [] and
+// user defined types are their UDTName, eg: integer[] or geometry.
+func KnownTypes(cols []Column) []string {
+	seen := map[string]struct{}{}
+	var names []string
+	for _, c := range cols {
+		name := c.DBType
+		switch {
+		case c.ArrType != nil:
+			name = *c.ArrType + "[]"
+		case name == "USER-DEFINED" && len(c.UDTName) != 0:
+			name = c.UDTName
+		}
+
+		if _, ok := seen[name]; ok {
+			continue
+		}
+		seen[name] = struct{}{}
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,71 @@
+package bdb
+
+import "testing"
+
+type testTypeDriver struct {
+	testMockDriver
+}
+
+func (m testTypeDriver) TranslateColumnType(c Column) Column {
+	switch {
+	case c.ArrType != nil:
+		c.Type = "types.StringArray"
+	case c.DBType == "integer" && c.Nullable:
+		c.Type = "null.Int"
+	case c.DBType == "integer":
+		c.Type = "int"
+	case c.Nullable:
+		c.Type = "null.String"
+	default:
+		c.Type = "string"
+	}
+	return c
+}
+
+func TestTypeMappings(t *testing.T) {
+	t.Parallel()
+
+	text := "text"
+	mappings := TypeMappings(testTypeDriver{}, []Column{
+		{DBType: "integer"},
+		{DBType: "varchar", FullDBType: "varchar(255)"},
+		{DBType: "ARRAY", ArrType: &text},
+	})
+
+	want := []TypeMapping{
+		{DBType: "integer", Type: "int", NullableType: "null.Int"},
+		{DBType: "varchar(255)", Type: "string", NullableType: "null.String"},
+		{DBType: "text[]", Type: "types.StringArray", NullableType: "types.StringArray"},
+	}
+	if len(mappings) != len(want) {
+		t.Fatalf("want %d mappings, got: %#v", len(want), mappings)
+	}
+	for i, w := range want {
+		if mappings[i] != w {
+			t.Errorf("%d) want %#v, got %#v", i, w, mappings[i])
+		}
+	}
+}
+
+func TestKnownTypes(t *testing.T) {
+	t.Parallel()
+
+	text := "text"
+	got := KnownTypes([]Column{
+		{DBType: "integer"},
+		{DBType: "varchar", FullDBType: "varchar(255)"},
+		{DBType: "varchar", FullDBType: "varchar(32)"},
+		{DBType: "ARRAY", ArrType: &text},
+		{DBType: "USER-DEFINED", UDTName: "geometry"},
+	})
+
+	want := []string{"geometry", "integer", "text[]", "varchar"}
+	if len(got) != len(want) {
+		t.Fatalf("want %v, got %v", want, got)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("want %v, got %v", want, got)
+		}
+	}
+}
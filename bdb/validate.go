@@ -0,0 +1,87 @@
+package bdb
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/volatiletech/sqlboiler/strmangle"
+)
+
+// Validate checks that the tables can be generated, it returns an error
+// listing the names the templates would write more than once: the columns
+// of a table that would be the same Go field, eg: "Name" and "name" in a
+// database with case sensitive quoted identifiers, and the tables that
+// would be the same model, eg: with an alias or a NameMapper.
+func Validate(tables []Table) error {
+	var problems []string
+	for _, names := range modelNameCollisions(tables) {
+		problems = append(problems, fmt.Sprintf("tables %s are all %s",
+			strings.Join(names[1:], ", "), names[0]))
+	}
+	for _, t := range tables {
+		for _, names := range goNameCollisions(t) {
+			problems = append(problems, fmt.Sprintf("%s: columns %s are all %s",
+				t.Name, strings.Join(names[1:], ", "), names[0]))
+		}
+	}
+
+	if len(problems) != 0 {
+		return errors.Errorf("tables have the same Go names: %s", strings.Join(problems, "; "))
+	}
+
+	return nil
+}
+
+// modelNameCollisions finds the tables that have the same model name
+// (GoName), skipping the ones without one. Each collision is the model name
+// followed by the tables, in table order.
+func modelNameCollisions(tables []Table) [][]string {
+	var order []string
+	models := map[string][]string{}
+	for _, t := range tables {
+		if len(t.GoName) == 0 {
+			continue
+		}
+		if _, ok := models[t.GoName]; !ok {
+			order = append(order, t.GoName)
+		}
+		models[t.GoName] = append(models[t.GoName], t.Name)
+	}
+
+	var collisions [][]string
+	for _, goName := range order {
+		if names := models[goName]; len(names) > 1 {
+			collisions = append(collisions, append([]string{goName}, names...))
+		}
+	}
+
+	return collisions
+}
+
+// goNameCollisions finds the columns of the table that have the same Go
+// name, each collision is the Go name followed by the columns, in column
+// order.
+func goNameCollisions(t Table) [][]string {
+	var order []string
+	columns := map[string][]string{}
+	for _, c := range t.Columns {
+		goName := c.GoName
+		if len(goName) == 0 {
+			goName = strmangle.TitleCase(c.Name)
+		}
+		if _, ok := columns[goName]; !ok {
+			order = append(order, goName)
+		}
+		columns[goName] = append(columns[goName], c.Name)
+	}
+
+	var collisions [][]string
+	for _, goName := range order {
+		if names := columns[goName]; len(names) > 1 {
+			collisions = append(collisions, append([]string{goName}, names...))
+		}
+	}
+
+	return collisions
+}
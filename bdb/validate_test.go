@@ -0,0 +1,54 @@
+package bdb
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/volatiletech/sqlboiler/strmangle"
+)
+
+func TestValidate(t *testing.T) {
+	t.Parallel()
+
+	tables := []Table{
+		{Name: "pilots", Columns: []Column{{Name: "id"}, {Name: "name"}}},
+		{Name: "jets", Columns: []Column{{Name: "id"}, {Name: "Name"}, {Name: "name"}, {Name: "pilot_id"}, {Name: "pilotID"}}},
+	}
+
+	if err := Validate(tables[:1]); err != nil {
+		t.Errorf("pilots should be valid: %v", err)
+	}
+
+	err := Validate(tables)
+	if err == nil {
+		t.Fatal("want an error for jets")
+	}
+	want := "tables have the same Go names: jets: columns Name, name are all Name; jets: columns pilot_id, pilotID are all PilotID"
+	if err.Error() != want {
+		t.Errorf("want %q, got %q", want, err.Error())
+	}
+}
+
+func TestValidateGoNames(t *testing.T) {
+	t.Parallel()
+
+	tables := []Table{
+		{Name: "pilot", DBName: "pilot", Columns: []Column{{Name: "id"}}},
+		{Name: "pilots", DBName: "pilots", Columns: []Column{{Name: "id"}}},
+		{Name: "tbl_usr", DBName: "tbl_usr", Columns: []Column{{Name: "user_name"}, {Name: "username"}}},
+	}
+	// Lowercasing the Go names makes username and user_name the same
+	mapper := func(name string) string { return strings.ToLower(strmangle.TitleCase(name)) }
+	for i := range tables {
+		setGoNames(&tables[i], nil, mapper, map[string]string{"tbl_usr": "Usr"})
+	}
+
+	err := Validate(tables)
+	if err == nil {
+		t.Fatal("want an error for the Go names")
+	}
+	want := "tables have the same Go names: tables pilot, pilots are all pilot; tbl_usr: columns user_name, username are all username"
+	if err.Error() != want {
+		t.Errorf("want %q, got %q", want, err.Error())
+	}
+}
@@ -58,11 +58,24 @@ func New(config *Config) (*State, error) {
 		return nil, err
 	}
 
+	// Before the tables are read, which title cases their names
+	strmangle.AddUppercaseWords(config.UppercaseWords...)
+
 	// Connect to the driver database
 	if err = s.Driver.Open(); err != nil {
+		// Drivers' String hides the password, so it's safe in the error
+		if conn, ok := s.Driver.(fmt.Stringer); ok {
+			return nil, errors.Wrapf(err, "unable to connect to the database (%s)", conn)
+		}
 		return nil, errors.Wrap(err, "unable to connect to the database")
 	}
 
+	if file, ok := s.Driver.(*drivers.SQLFileDriver); ok {
+		for _, stmt := range file.Unsupported {
+			fmt.Fprintf(os.Stderr, "Warning: skipped unsupported statement in %s: %s\n", file, stmt)
+		}
+	}
+
 	err = s.initTables(config.Schema, config.WhitelistTables, config.BlacklistTables)
 	if err != nil {
 		return nil, errors.Wrap(err, "unable to initialize tables")
@@ -92,6 +105,9 @@ func New(config *Config) (*State, error) {
 	}
 
 	s.Importer = newImporter()
+	if len(config.NullPackage) != 0 {
+		s.Importer.BasedOnType.setNullPackage(config.NullPackage)
+	}
 
 	return s, nil
 }
@@ -290,6 +306,10 @@ func (s *State) initDriver(driverName string) error {
 	// Create a driver based off driver flag
 	switch driverName {
 	case "postgres":
+		if len(s.Config.Postgres.SchemaFile) != 0 {
+			s.Driver = drivers.NewSQLFileDriver(s.Config.Postgres.SchemaFile)
+			break
+		}
 		s.Driver = drivers.NewPostgresDriver(
 			s.Config.Postgres.User,
 			s.Config.Postgres.Pass,
@@ -299,6 +319,17 @@ func (s *State) initDriver(driverName string) error {
 			s.Config.Postgres.SSLMode,
 		)
 	case "mysql":
+		if s.Config.MySQL.TiDB {
+			s.Driver = drivers.NewTiDBDriver(
+				s.Config.MySQL.User,
+				s.Config.MySQL.Pass,
+				s.Config.MySQL.DBName,
+				s.Config.MySQL.Host,
+				s.Config.MySQL.Port,
+				s.Config.MySQL.SSLMode,
+			)
+			break
+		}
 		s.Driver = drivers.NewMySQLDriver(
 			s.Config.MySQL.User,
 			s.Config.MySQL.Pass,
@@ -316,6 +347,39 @@ func (s *State) initDriver(driverName string) error {
 			s.Config.MSSQL.Port,
 			s.Config.MSSQL.SSLMode,
 		)
+	case "spanner":
+		s.Driver = drivers.NewSpannerDriver(
+			s.Config.Spanner.Project,
+			s.Config.Spanner.Instance,
+			s.Config.Spanner.DBName,
+		)
+	case "clickhouse":
+		s.Driver = drivers.NewClickHouseDriver(
+			s.Config.ClickHouse.User,
+			s.Config.ClickHouse.Pass,
+			s.Config.ClickHouse.DBName,
+			s.Config.ClickHouse.Host,
+			s.Config.ClickHouse.Port,
+			s.Config.ClickHouse.SSLMode,
+		)
+	case "vertica":
+		s.Driver = drivers.NewVerticaDriver(
+			s.Config.Vertica.User,
+			s.Config.Vertica.Pass,
+			s.Config.Vertica.DBName,
+			s.Config.Vertica.Host,
+			s.Config.Vertica.Port,
+			s.Config.Vertica.SSLMode,
+		)
+	case "snowflake":
+		s.Driver = drivers.NewSnowflakeDriver(
+			s.Config.Snowflake.User,
+			s.Config.Snowflake.Pass,
+			s.Config.Snowflake.Account,
+			s.Config.Snowflake.DBName,
+			s.Config.Snowflake.Warehouse,
+			s.Config.Snowflake.Role,
+		)
 	case "mock":
 		s.Driver = &drivers.MockDriver{}
 	}
@@ -335,11 +399,36 @@ func (s *State) initDriver(driverName string) error {
 // initTables retrieves all "public" schema table names from the database.
 func (s *State) initTables(schema string, whitelist, blacklist []string) error {
 	var err error
-	s.Tables, err = bdb.Tables(s.Driver, schema, whitelist, blacklist)
+	var stats bdb.Stats
+	opts := bdb.Options{
+		ExcludeColumnTypes:  s.Config.ExcludeColumnTypes,
+		LooseJoinTables:     s.Config.LooseJoinTables,
+		TableNamesQuery:     s.Config.TableNamesQuery,
+		StrictTypes:         s.Config.StrictTypes,
+		ForceNullable:       s.Config.ForceNullable,
+		DefaultType:         s.Config.DefaultType,
+		DefaultNullableType: s.Config.DefaultNullableType,
+		SkipTableErrors:     s.Config.SkipTableErrors,
+		NotNullChecks:       s.Config.NotNullChecks,
+		ColumnType:          s.Config.ColumnType,
+		NameMapper:          s.Config.NameMapper,
+		TableAliases:        s.Config.TableAliases,
+		Stats:               &stats,
+	}
+
+	s.Tables, err = bdb.TablesWithOptions(s.Driver, schema, whitelist, blacklist, opts)
+	if failed, ok := err.(bdb.TableErrors); ok {
+		fmt.Fprintf(os.Stderr, "Warning: skipping tables that couldn't be read, %v\n", failed)
+		err = nil
+	}
 	if err != nil {
 		return errors.Wrap(err, "unable to fetch table data")
 	}
 
+	if s.Config.Debug {
+		fmt.Printf("Read %s\n", stats)
+	}
+
 	if len(s.Tables) == 0 {
 		return errors.New("no tables found in database")
 	}
@@ -348,7 +437,11 @@ func (s *State) initTables(schema string, whitelist, blacklist []string) error {
 		return err
 	}
 
-	return nil
+	if cols := textCompositeColumns(s.Tables); len(cols) != 0 {
+		fmt.Fprintf(os.Stderr, "Warning: composite type columns are generated as strings, give them a Go type with a @gotype: comment or --default-type: %s\n", strings.Join(cols, ", "))
+	}
+
+	return bdb.Validate(s.Tables)
 }
 
 // Tags must be in a format like: json, xml, etc.
@@ -378,6 +471,21 @@ func (s *State) initOutFolder() error {
 	return os.MkdirAll(s.Config.OutFolder, os.ModePerm)
 }
 
+// textCompositeColumns lists the postgres composite type columns that no Go
+// type was picked for, they're still read as text, eg: users.address
+func textCompositeColumns(tables []bdb.Table) []string {
+	var cols []string
+	for _, t := range tables {
+		for _, c := range t.Columns {
+			if c.DBType == "composite" && c.UnknownType && (c.Type == "string" || c.Type == "null.String") {
+				cols = append(cols, t.Name+"."+c.Name)
+			}
+		}
+	}
+
+	return cols
+}
+
 // checkPKeys ensures every table has a primary key column
 func checkPKeys(tables []bdb.Table) error {
 	var missingPkey []string
@@ -1,26 +1,52 @@
 package boilingcore
 
+import "github.com/volatiletech/sqlboiler/bdb"
+
 // Config for the running of the commands
 type Config struct {
-	DriverName       string
-	Schema           string
-	PkgName          string
-	OutFolder        string
-	BaseDir          string
-	WhitelistTables  []string
-	BlacklistTables  []string
-	Tags             []string
-	Replacements     []string
-	Debug            bool
-	NoTests          bool
-	NoHooks          bool
-	NoAutoTimestamps bool
-	Wipe             bool
-	StructTagCasing  string
-
-	Postgres PostgresConfig
-	MySQL    MySQLConfig
-	MSSQL    MSSQLConfig
+	DriverName          string
+	Schema              string
+	PkgName             string
+	OutFolder           string
+	BaseDir             string
+	WhitelistTables     []string
+	BlacklistTables     []string
+	ExcludeColumnTypes  []string
+	Tags                []string
+	Replacements        []string
+	Debug               bool
+	NoTests             bool
+	NoHooks             bool
+	NoAutoTimestamps    bool
+	LooseJoinTables     bool
+	TableNamesQuery     string
+	StrictTypes         bool
+	ForceNullable       bool
+	DefaultType         string
+	DefaultNullableType string
+	SkipTableErrors     bool
+	NotNullChecks       bool
+	NullPackage         string
+	Wipe                bool
+	StructTagCasing     string
+	// UppercaseWords are acronyms to write in uppercase in Go names, see
+	// strmangle.AddUppercaseWords.
+	UppercaseWords []string
+	// ColumnType is bdb.Options.ColumnType, it can only be set from code.
+	ColumnType func(bdb.Table, bdb.Column) (string, bool)
+	// NameMapper is bdb.Options.NameMapper, it can only be set from code.
+	NameMapper func(string) string
+	// TableAliases is bdb.Options.TableAliases, read from the [aliases]
+	// table of the config file.
+	TableAliases map[string]string
+
+	Postgres   PostgresConfig
+	MySQL      MySQLConfig
+	MSSQL      MSSQLConfig
+	Spanner    SpannerConfig
+	ClickHouse ClickHouseConfig
+	Vertica    VerticaConfig
+	Snowflake  SnowflakeConfig
 }
 
 // PostgresConfig configures a postgres database
@@ -31,6 +57,8 @@ type PostgresConfig struct {
 	Port    int
 	DBName  string
 	SSLMode string
+	// SchemaFile reads the schema from a file of DDL instead of connecting
+	SchemaFile string
 }
 
 // MySQLConfig configures a mysql database
@@ -41,6 +69,8 @@ type MySQLConfig struct {
 	Port    int
 	DBName  string
 	SSLMode string
+	// TiDB reads the database as TiDB, which speaks the mysql protocol
+	TiDB bool
 }
 
 // MSSQLConfig configures a mysql database
@@ -52,3 +82,40 @@ type MSSQLConfig struct {
 	DBName  string
 	SSLMode string
 }
+
+// SpannerConfig configures a spanner database
+type SpannerConfig struct {
+	Project  string
+	Instance string
+	DBName   string
+}
+
+// ClickHouseConfig configures a clickhouse database
+type ClickHouseConfig struct {
+	User    string
+	Pass    string
+	Host    string
+	Port    int
+	DBName  string
+	SSLMode string
+}
+
+// VerticaConfig configures a vertica database
+type VerticaConfig struct {
+	User    string
+	Pass    string
+	Host    string
+	Port    int
+	DBName  string
+	SSLMode string
+}
+
+// SnowflakeConfig configures a snowflake database
+type SnowflakeConfig struct {
+	User      string
+	Pass      string
+	Account   string
+	DBName    string
+	Warehouse string
+	Role      string
+}
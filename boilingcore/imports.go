@@ -64,6 +64,17 @@ func combineTypeImports(a imports, b map[string]imports, columns []bdb.Column) i
 				tmpImp.thirdParty = append(tmpImp.thirdParty, imp.thirdParty...)
 			}
 		}
+
+		// The package of a type given with a @gotype: hint, the standard
+		// library's paths have no dot in their first element
+		if len(col.TypeImport) != 0 {
+			imp := fmt.Sprintf("%q", col.TypeImport)
+			if strings.Contains(strings.SplitN(col.TypeImport, "/", 2)[0], ".") {
+				tmpImp.thirdParty = append(tmpImp.thirdParty, imp)
+			} else {
+				tmpImp.standard = append(tmpImp.standard, imp)
+			}
+		}
 	}
 
 	tmpImp.standard = removeDuplicates(tmpImp.standard)
@@ -155,6 +166,10 @@ type importer struct {
 	BasedOnType mapImports
 }
 
+// nullImport is the import of the null package the drivers' null.* types
+// are from by default.
+const nullImport = `"gopkg.in/volatiletech/null.v6"`
+
 // newImporter returns an importer struct with default import values
 func newImporter() importer {
 	var imp importer
@@ -309,59 +324,62 @@ func newImporter() importer {
 	// TranslateColumnType to see the type assignments.
 	imp.BasedOnType = mapImports{
 		"null.Float32": {
-			thirdParty: importList{`"gopkg.in/volatiletech/null.v6"`},
+			thirdParty: importList{nullImport},
 		},
 		"null.Float64": {
-			thirdParty: importList{`"gopkg.in/volatiletech/null.v6"`},
+			thirdParty: importList{nullImport},
 		},
 		"null.Int": {
-			thirdParty: importList{`"gopkg.in/volatiletech/null.v6"`},
+			thirdParty: importList{nullImport},
 		},
 		"null.Int8": {
-			thirdParty: importList{`"gopkg.in/volatiletech/null.v6"`},
+			thirdParty: importList{nullImport},
 		},
 		"null.Int16": {
-			thirdParty: importList{`"gopkg.in/volatiletech/null.v6"`},
+			thirdParty: importList{nullImport},
 		},
 		"null.Int32": {
-			thirdParty: importList{`"gopkg.in/volatiletech/null.v6"`},
+			thirdParty: importList{nullImport},
 		},
 		"null.Int64": {
-			thirdParty: importList{`"gopkg.in/volatiletech/null.v6"`},
+			thirdParty: importList{nullImport},
 		},
 		"null.Uint": {
-			thirdParty: importList{`"gopkg.in/volatiletech/null.v6"`},
+			thirdParty: importList{nullImport},
 		},
 		"null.Uint8": {
-			thirdParty: importList{`"gopkg.in/volatiletech/null.v6"`},
+			thirdParty: importList{nullImport},
 		},
 		"null.Uint16": {
-			thirdParty: importList{`"gopkg.in/volatiletech/null.v6"`},
+			thirdParty: importList{nullImport},
 		},
 		"null.Uint32": {
-			thirdParty: importList{`"gopkg.in/volatiletech/null.v6"`},
+			thirdParty: importList{nullImport},
 		},
 		"null.Uint64": {
-			thirdParty: importList{`"gopkg.in/volatiletech/null.v6"`},
+			thirdParty: importList{nullImport},
 		},
 		"null.String": {
-			thirdParty: importList{`"gopkg.in/volatiletech/null.v6"`},
+			thirdParty: importList{nullImport},
 		},
 		"null.Bool": {
-			thirdParty: importList{`"gopkg.in/volatiletech/null.v6"`},
+			thirdParty: importList{nullImport},
 		},
 		"null.Time": {
-			thirdParty: importList{`"gopkg.in/volatiletech/null.v6"`},
+			thirdParty: importList{nullImport},
 		},
 		"null.JSON": {
-			thirdParty: importList{`"gopkg.in/volatiletech/null.v6"`},
+			thirdParty: importList{nullImport},
 		},
 		"null.Bytes": {
-			thirdParty: importList{`"gopkg.in/volatiletech/null.v6"`},
+			thirdParty: importList{nullImport},
 		},
 		"time.Time": {
 			standard: importList{`"time"`},
 		},
+		"json.RawMessage": {
+			standard: importList{`"encoding/json"`},
+		},
 		"types.JSON": {
 			thirdParty: importList{`"github.com/volatiletech/sqlboiler/types"`},
 		},
@@ -383,11 +401,57 @@ func newImporter() importer {
 		"types.Hstore": {
 			thirdParty: importList{`"github.com/volatiletech/sqlboiler/types"`},
 		},
+		"types.Decimal": {
+			thirdParty: importList{`"github.com/volatiletech/sqlboiler/types"`},
+		},
+		"types.NullDecimal": {
+			thirdParty: importList{`"github.com/volatiletech/sqlboiler/types"`},
+		},
+		"types.Set": {
+			thirdParty: importList{`"github.com/volatiletech/sqlboiler/types"`},
+		},
+		"[]spanner.NullInt64": {
+			thirdParty: importList{`"cloud.google.com/go/spanner"`},
+		},
+		"[]spanner.NullFloat64": {
+			thirdParty: importList{`"cloud.google.com/go/spanner"`},
+		},
+		"[]spanner.NullBool": {
+			thirdParty: importList{`"cloud.google.com/go/spanner"`},
+		},
+		"[]spanner.NullString": {
+			thirdParty: importList{`"cloud.google.com/go/spanner"`},
+		},
+		"[]spanner.NullNumeric": {
+			thirdParty: importList{`"cloud.google.com/go/spanner"`},
+		},
+		"[]spanner.NullJSON": {
+			thirdParty: importList{`"cloud.google.com/go/spanner"`},
+		},
+		"[]spanner.NullDate": {
+			thirdParty: importList{`"cloud.google.com/go/spanner"`},
+		},
+		"[]spanner.NullTime": {
+			thirdParty: importList{`"cloud.google.com/go/spanner"`},
+		},
 	}
 
 	return imp
 }
 
+// setNullPackage imports the null.* types from the package at path instead,
+// it's imported as null so the types keep their names.
+func (m mapImports) setNullPackage(path string) {
+	for key := range m {
+		if !strings.HasPrefix(key, "null.") {
+			continue
+		}
+
+		m.Remove(key, nullImport)
+		m.Add(key, fmt.Sprintf("null %q", path), true)
+	}
+}
+
 // Remove an import matching the match string under the specified key.
 // Remove will search both standard and thirdParty import lists for a match.
 func (m mapImports) Remove(key string, match string) {
@@ -292,6 +292,21 @@ func TestCombineTypeImports(t *testing.T) {
 	}
 }
 
+func TestSetNullPackage(t *testing.T) {
+	t.Parallel()
+
+	imps := newImporter()
+	imps.BasedOnType.setNullPackage("github.com/guregu/null")
+
+	cols := []bdb.Column{{Type: "null.String"}, {Type: "types.JSON"}}
+	res := combineTypeImports(imports{}, imps.BasedOnType, cols)
+
+	want := importList{`"github.com/volatiletech/sqlboiler/types"`, `null "github.com/guregu/null"`}
+	if !reflect.DeepEqual(res.thirdParty, want) {
+		t.Errorf("want %v, got %v", want, res.thirdParty)
+	}
+}
+
 func TestCombineImports(t *testing.T) {
 	t.Parallel()
 
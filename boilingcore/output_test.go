@@ -7,6 +7,11 @@ import (
 	"os"
 	"strings"
 	"testing"
+
+	"github.com/volatiletech/sqlboiler/bdb"
+	"github.com/volatiletech/sqlboiler/bdb/drivers"
+	"github.com/volatiletech/sqlboiler/queries"
+	"github.com/volatiletech/sqlboiler/strmangle"
 )
 
 type NopWriteCloser struct {
@@ -50,6 +55,71 @@ func TestWriteFile(t *testing.T) {
 	}
 }
 
+// hintedDriver is the mock driver with a @gotype: hint on pilots.name
+type hintedDriver struct {
+	*drivers.MockDriver
+}
+
+func (h hintedDriver) Columns(schema, tableName string) ([]bdb.Column, error) {
+	columns, err := h.MockDriver.Columns(schema, tableName)
+	for i := range columns {
+		if tableName == "pilots" && columns[i].Name == "name" {
+			columns[i].Comment = "@gotype:github.com/acme/geo.Address"
+		}
+	}
+
+	return columns, err
+}
+
+func TestGenerateOutputTypeHint(t *testing.T) {
+	// t.Parallel() cannot be used
+
+	saveTestHarnessWriteFile := testHarnessWriteFile
+	defer func() {
+		testHarnessWriteFile = saveTestHarnessWriteFile
+	}()
+
+	var output []byte
+	testHarnessWriteFile = func(_ string, in []byte, _ os.FileMode) error {
+		output = in
+		return nil
+	}
+
+	tables, err := bdb.Tables(hintedDriver{&drivers.MockDriver{}}, "public", nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tpls, err := loadTemplates("../templates")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	state := &State{
+		Config:    &Config{PkgName: "models"},
+		Templates: tpls,
+		Importer:  newImporter(),
+	}
+	data := &templateData{
+		Tables:      tables,
+		Table:       bdb.GetTable(tables, "pilots"),
+		PkgName:     "models",
+		DriverName:  "postgres",
+		Dialect:     queries.Dialect{LQ: '"', RQ: '"', IndexPlaceholders: true},
+		LQ:          strmangle.QuoteCharacter('"'),
+		RQ:          strmangle.QuoteCharacter('"'),
+		StringFuncs: templateStringMappers,
+	}
+
+	if err = generateOutput(state, data); err != nil {
+		t.Fatal(err)
+	}
+
+	out := string(output)
+	if !strings.Contains(out, `"github.com/acme/geo"`) || !strings.Contains(out, "geo.Address") {
+		t.Errorf("want the hinted type and its import in:\n%s", out)
+	}
+}
+
 func TestFormatBuffer(t *testing.T) {
 	t.Parallel()
 
@@ -1,9 +1,16 @@
 package boilingcore
 
 import (
+	"bytes"
 	"sort"
+	"strings"
 	"testing"
 	"text/template"
+
+	"github.com/volatiletech/sqlboiler/bdb"
+	"github.com/volatiletech/sqlboiler/bdb/drivers"
+	"github.com/volatiletech/sqlboiler/queries"
+	"github.com/volatiletech/sqlboiler/strmangle"
 )
 
 func TestTemplateNameListSort(t *testing.T) {
@@ -66,3 +73,69 @@ func TestTemplateList_Templates(t *testing.T) {
 		t.Error("don't want not")
 	}
 }
+
+func TestTemplatesRenamedModel(t *testing.T) {
+	t.Parallel()
+
+	tables, err := bdb.TablesWithOptions(&drivers.MockDriver{}, "public", nil, nil, bdb.Options{
+		TableAliases: map[string]string{"pilots": "Aviator"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, dir := range []string{"../templates", "../templates_test"} {
+		tpls, err := loadTemplates(dir)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		for _, table := range []string{"pilots", "jets"} {
+			data := &templateData{
+				Tables:      tables,
+				Table:       bdb.GetTable(tables, table),
+				PkgName:     "models",
+				DriverName:  "postgres",
+				Dialect:     queries.Dialect{LQ: '"', RQ: '"', IndexPlaceholders: true},
+				LQ:          strmangle.QuoteCharacter('"'),
+				RQ:          strmangle.QuoteCharacter('"'),
+				StringFuncs: templateStringMappers,
+			}
+
+			buf := &bytes.Buffer{}
+			writePackageName(buf, data.PkgName)
+			for _, name := range tpls.Templates() {
+				if err := executeTemplate(buf, tpls.Template, name, data); err != nil {
+					t.Fatal(err)
+				}
+			}
+			if _, err := formatBuffer(buf); err != nil {
+				t.Fatalf("%s %s: %v", dir, table, err)
+			}
+
+			out := buf.String()
+			if !strings.Contains(out, "Aviator") || strings.Contains(out, "*Pilot") || strings.Contains(out, "PilotSlice") || strings.Contains(out, "pilotQuery") || strings.Contains(out, "pilotR") {
+				t.Errorf("%s %s: pilots should be named Aviator everywhere", dir, table)
+			}
+		}
+	}
+
+	tpls, err := loadTemplates("../templates")
+	if err != nil {
+		t.Fatal(err)
+	}
+	buf := &bytes.Buffer{}
+	data := &templateData{
+		Tables:      tables,
+		Table:       bdb.GetTable(tables, "pilots"),
+		StringFuncs: templateStringMappers,
+	}
+	if err = executeTemplate(buf, tpls.Template, "00_struct.tpl", data); err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{"type Aviator struct", "R *aviatorR", "var AviatorColumns", "type aviatorL struct"} {
+		if !strings.Contains(buf.String(), want) {
+			t.Errorf("want %q in:\n%s", want, buf.String())
+		}
+	}
+}
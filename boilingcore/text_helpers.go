@@ -14,15 +14,17 @@ type TxtToOne struct {
 	ForeignKey bdb.ForeignKey
 
 	LocalTable struct {
-		NameGo       string
-		ColumnNameGo string
+		NameGo         string
+		ColumnNameGo   string
+		ColumnNullType bool
 	}
 
 	ForeignTable struct {
-		NameGo       string
-		NamePluralGo string
-		ColumnNameGo string
-		ColumnName   string
+		NameGo         string
+		NamePluralGo   string
+		ColumnNameGo   string
+		ColumnName     string
+		ColumnNullType bool
 	}
 
 	Function struct {
@@ -41,30 +43,32 @@ func txtsFromFKey(tables []bdb.Table, table bdb.Table, fkey bdb.ForeignKey) TxtT
 
 	r.ForeignKey = fkey
 
-	r.LocalTable.NameGo = strmangle.TitleCase(strmangle.Singular(table.Name))
-	r.LocalTable.ColumnNameGo = strmangle.TitleCase(strmangle.Singular(fkey.Column))
+	col := table.GetColumn(fkey.Column)
+	foreignTable := bdb.GetTable(tables, fkey.ForeignTable)
+	foreignColumn := foreignTable.GetColumn(fkey.ForeignColumn)
+
+	r.LocalTable.NameGo = table.GoName
+	r.LocalTable.ColumnNameGo = col.GoName
 
-	r.ForeignTable.NameGo = strmangle.TitleCase(strmangle.Singular(fkey.ForeignTable))
-	r.ForeignTable.NamePluralGo = strmangle.TitleCase(strmangle.Plural(fkey.ForeignTable))
+	r.ForeignTable.NameGo = foreignTable.GoName
+	r.ForeignTable.NamePluralGo = foreignTable.GoNamePlural
 	r.ForeignTable.ColumnName = fkey.ForeignColumn
-	r.ForeignTable.ColumnNameGo = strmangle.TitleCase(strmangle.Singular(fkey.ForeignColumn))
+	r.ForeignTable.ColumnNameGo = foreignColumn.GoName
 
 	r.Function.Name, r.Function.ForeignName = txtNameToOne(fkey)
 
-	if fkey.Nullable {
-		col := table.GetColumn(fkey.Column)
-		r.Function.LocalAssignment = fmt.Sprintf("%s.%s", strmangle.TitleCase(fkey.Column), strings.TrimPrefix(col.Type, "null."))
+	r.LocalTable.ColumnNullType = col.NullType()
+	if r.LocalTable.ColumnNullType {
+		r.Function.LocalAssignment = fmt.Sprintf("%s.%s", col.GoName, strings.TrimPrefix(col.Type, "null."))
 	} else {
-		r.Function.LocalAssignment = strmangle.TitleCase(fkey.Column)
+		r.Function.LocalAssignment = col.GoName
 	}
 
-	foreignTable := bdb.GetTable(tables, fkey.ForeignTable)
-	foreignColumn := foreignTable.GetColumn(fkey.ForeignColumn)
-
-	if fkey.ForeignColumnNullable {
-		r.Function.ForeignAssignment = fmt.Sprintf("%s.%s", strmangle.TitleCase(fkey.ForeignColumn), strings.TrimPrefix(foreignColumn.Type, "null."))
+	r.ForeignTable.ColumnNullType = foreignColumn.NullType()
+	if r.ForeignTable.ColumnNullType {
+		r.Function.ForeignAssignment = fmt.Sprintf("%s.%s", foreignColumn.GoName, strings.TrimPrefix(foreignColumn.Type, "null."))
 	} else {
-		r.Function.ForeignAssignment = strmangle.TitleCase(fkey.ForeignColumn)
+		r.Function.ForeignAssignment = foreignColumn.GoName
 	}
 
 	r.Function.UsesBytes = foreignColumn.Type == "[]byte"
@@ -108,8 +112,9 @@ func txtsFromOneToOne(tables []bdb.Table, table bdb.Table, oneToOne bdb.ToOneRel
 // TxtToMany contains text that will be used by many-to-one relationships.
 type TxtToMany struct {
 	LocalTable struct {
-		NameGo       string
-		ColumnNameGo string
+		NameGo         string
+		ColumnNameGo   string
+		ColumnNullType bool
 	}
 
 	ForeignTable struct {
@@ -117,6 +122,7 @@ type TxtToMany struct {
 		NamePluralGo      string
 		NameHumanReadable string
 		ColumnNameGo      string
+		ColumnNullType    bool
 		Slice             string
 	}
 
@@ -135,31 +141,34 @@ type TxtToMany struct {
 // transformation in advance for a given relationship.
 func txtsFromToMany(tables []bdb.Table, table bdb.Table, rel bdb.ToManyRelationship) TxtToMany {
 	r := TxtToMany{}
-	r.LocalTable.NameGo = strmangle.TitleCase(strmangle.Singular(table.Name))
-	r.LocalTable.ColumnNameGo = strmangle.TitleCase(rel.Column)
-
-	foreignNameSingular := strmangle.Singular(rel.ForeignTable)
-	r.ForeignTable.NamePluralGo = strmangle.TitleCase(strmangle.Plural(rel.ForeignTable))
-	r.ForeignTable.NameGo = strmangle.TitleCase(foreignNameSingular)
-	r.ForeignTable.ColumnNameGo = strmangle.TitleCase(rel.ForeignColumn)
-	r.ForeignTable.Slice = fmt.Sprintf("%sSlice", strmangle.TitleCase(foreignNameSingular))
+
+	col := table.GetColumn(rel.Column)
+	foreignTable := bdb.GetTable(tables, rel.ForeignTable)
+	foreignColumn := foreignTable.GetColumn(rel.ForeignColumn)
+
+	r.LocalTable.NameGo = table.GoName
+	r.LocalTable.ColumnNameGo = col.GoName
+
+	r.ForeignTable.NamePluralGo = foreignTable.GoNamePlural
+	r.ForeignTable.NameGo = foreignTable.GoName
+	r.ForeignTable.ColumnNameGo = foreignColumn.GoName
+	r.ForeignTable.Slice = fmt.Sprintf("%sSlice", foreignTable.GoName)
 	r.ForeignTable.NameHumanReadable = strings.Replace(rel.ForeignTable, "_", " ", -1)
 
 	r.Function.Name, r.Function.ForeignName = txtNameToMany(rel)
 
-	col := table.GetColumn(rel.Column)
-	if rel.Nullable {
-		r.Function.LocalAssignment = fmt.Sprintf("%s.%s", strmangle.TitleCase(rel.Column), strings.TrimPrefix(col.Type, "null."))
+	r.LocalTable.ColumnNullType = col.NullType()
+	if r.LocalTable.ColumnNullType {
+		r.Function.LocalAssignment = fmt.Sprintf("%s.%s", col.GoName, strings.TrimPrefix(col.Type, "null."))
 	} else {
-		r.Function.LocalAssignment = strmangle.TitleCase(rel.Column)
+		r.Function.LocalAssignment = col.GoName
 	}
 
-	if rel.ForeignColumnNullable {
-		foreignTable := bdb.GetTable(tables, rel.ForeignTable)
-		foreignColumn := foreignTable.GetColumn(rel.ForeignColumn)
-		r.Function.ForeignAssignment = fmt.Sprintf("%s.%s", strmangle.TitleCase(rel.ForeignColumn), strings.TrimPrefix(foreignColumn.Type, "null."))
+	r.ForeignTable.ColumnNullType = foreignColumn.NullType()
+	if r.ForeignTable.ColumnNullType {
+		r.Function.ForeignAssignment = fmt.Sprintf("%s.%s", foreignColumn.GoName, strings.TrimPrefix(foreignColumn.Type, "null."))
 	} else {
-		r.Function.ForeignAssignment = strmangle.TitleCase(rel.ForeignColumn)
+		r.Function.ForeignAssignment = foreignColumn.GoName
 	}
 
 	r.Function.UsesBytes = col.Type == "[]byte"
@@ -196,7 +205,7 @@ func txtsFromToMany(tables []bdb.Table, table bdb.Table, rel bdb.ToManyRelations
 // fk == table = industry.Industry | industry.Industry
 // fk != table = industry.ParentIndustry | industry.Industry
 func txtNameToOne(fk bdb.ForeignKey) (localFn, foreignFn string) {
-	localFn = strmangle.Singular(trimSuffixes(fk.Column))
+	localFn = strmangle.Singular(strmangle.TrimIdentifierSuffix(fk.Column))
 	fkeyIsTableName := localFn != strmangle.Singular(fk.ForeignTable)
 	localFn = strmangle.TitleCase(localFn)
 
@@ -235,8 +244,8 @@ func txtNameToOne(fk bdb.ForeignKey) (localFn, foreignFn string) {
 // fk != table = industry.MappedIndustryIndustry
 func txtNameToMany(toMany bdb.ToManyRelationship) (localFn, foreignFn string) {
 	if toMany.ToJoinTable {
-		localFkey := strmangle.Singular(trimSuffixes(toMany.JoinLocalColumn))
-		foreignFkey := strmangle.Singular(trimSuffixes(toMany.JoinForeignColumn))
+		localFkey := strmangle.Singular(strmangle.TrimIdentifierSuffix(toMany.JoinLocalColumn))
+		foreignFkey := strmangle.Singular(strmangle.TrimIdentifierSuffix(toMany.JoinForeignColumn))
 
 		if localFkey != strmangle.Singular(toMany.Table) {
 			foreignFn = strmangle.TitleCase(localFkey)
@@ -251,7 +260,7 @@ func txtNameToMany(toMany bdb.ToManyRelationship) (localFn, foreignFn string) {
 		return localFn, foreignFn
 	}
 
-	fkeyName := strmangle.Singular(trimSuffixes(toMany.ForeignColumn))
+	fkeyName := strmangle.Singular(strmangle.TrimIdentifierSuffix(toMany.ForeignColumn))
 	if fkeyName != strmangle.Singular(toMany.Table) {
 		localFn = strmangle.TitleCase(fkeyName)
 	}
@@ -264,25 +273,10 @@ func txtNameToMany(toMany bdb.ToManyRelationship) (localFn, foreignFn string) {
 // Simple case: yes - we can name the function the same as the plural table name
 // Not simple case: We have to name the function based off the foreign key and the foreign table name
 func mkFunctionName(fkeyTableSingular, foreignTablePluralGo, fkeyColumn string, toJoinTable bool) string {
-	colName := trimSuffixes(fkeyColumn)
+	colName := strmangle.TrimIdentifierSuffix(fkeyColumn)
 	if toJoinTable || fkeyTableSingular == colName {
 		return foreignTablePluralGo
 	}
 
 	return strmangle.TitleCase(colName) + foreignTablePluralGo
 }
-
-var identifierSuffixes = []string{"_id", "_uuid", "_guid", "_oid"}
-
-// trimSuffixes from the identifier
-func trimSuffixes(str string) string {
-	ln := len(str)
-	for _, s := range identifierSuffixes {
-		str = strings.TrimSuffix(str, s)
-		if len(str) != ln {
-			break
-		}
-	}
-
-	return str
-}
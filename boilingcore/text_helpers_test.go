@@ -18,13 +18,14 @@ func TestTxtsFromOne(t *testing.T) {
 	}
 
 	jets := bdb.GetTable(tables, "jets")
-	texts := txtsFromFKey(tables, jets, jets.FKeys[0])
+	texts := txtsFromFKey(tables, jets, jets.FKeys[1])
 	expect := TxtToOne{}
 
-	expect.ForeignKey = jets.FKeys[0]
+	expect.ForeignKey = jets.FKeys[1]
 
 	expect.LocalTable.NameGo = "Jet"
 	expect.LocalTable.ColumnNameGo = "PilotID"
+	expect.LocalTable.ColumnNullType = true
 
 	expect.ForeignTable.NameGo = "Pilot"
 	expect.ForeignTable.NamePluralGo = "Pilots"
@@ -41,9 +42,9 @@ func TestTxtsFromOne(t *testing.T) {
 		t.Errorf("Want:\n%s\nGot:\n%s\n", spew.Sdump(expect), spew.Sdump(texts))
 	}
 
-	texts = txtsFromFKey(tables, jets, jets.FKeys[1])
+	texts = txtsFromFKey(tables, jets, jets.FKeys[0])
 	expect = TxtToOne{}
-	expect.ForeignKey = jets.FKeys[1]
+	expect.ForeignKey = jets.FKeys[0]
 
 	expect.LocalTable.NameGo = "Jet"
 	expect.LocalTable.ColumnNameGo = "AirportID"
@@ -101,6 +102,7 @@ func TestTxtsFromOneToOne(t *testing.T) {
 	expect.ForeignTable.NamePluralGo = "Jets"
 	expect.ForeignTable.ColumnName = "pilot_id"
 	expect.ForeignTable.ColumnNameGo = "PilotID"
+	expect.ForeignTable.ColumnNullType = true
 
 	expect.Function.Name = "Jet"
 	expect.Function.ForeignName = "Pilot"
@@ -260,15 +262,3 @@ func TestTxtNameToMany(t *testing.T) {
 		}
 	}
 }
-
-func TestTrimSuffixes(t *testing.T) {
-	t.Parallel()
-
-	for _, s := range identifierSuffixes {
-		a := "hello" + s
-
-		if z := trimSuffixes(a); z != "hello" {
-			t.Errorf("got %s", z)
-		}
-	}
-}
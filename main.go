@@ -78,14 +78,32 @@ func main() {
 	rootCmd.PersistentFlags().StringP("basedir", "", "", "The base directory has the templates and templates_test folders")
 	rootCmd.PersistentFlags().StringSliceP("blacklist", "b", nil, "Do not include these tables in your generated package")
 	rootCmd.PersistentFlags().StringSliceP("whitelist", "w", nil, "Only include these tables in your generated package")
+	rootCmd.PersistentFlags().StringSliceP("exclude-column-types", "", nil, "Do not include columns of these database types, eg: bytea (key columns are always included)")
 	rootCmd.PersistentFlags().StringSliceP("tag", "t", nil, "Struct tags to be included on your models in addition to json, yaml, toml")
+	rootCmd.PersistentFlags().StringSliceP("uppercase-words", "", nil, "Acronyms to write in uppercase in Go names, eg: sku (id, url and others already are)")
 	rootCmd.PersistentFlags().StringSliceP("replace", "", nil, "Replace templates by directory: relpath/to_file.tpl:relpath/to_replacement.tpl")
 	rootCmd.PersistentFlags().BoolP("debug", "d", false, "Debug mode prints stack traces on error")
 	rootCmd.PersistentFlags().BoolP("no-tests", "", false, "Disable generated go test files")
 	rootCmd.PersistentFlags().BoolP("no-hooks", "", false, "Disable hooks feature for your models")
 	rootCmd.PersistentFlags().BoolP("no-auto-timestamps", "", false, "Disable automatic timestamps for created_at/updated_at")
+	rootCmd.PersistentFlags().BoolP("loose-join-tables", "", false, "Treat tables with extra columns besides the two keys as join tables")
+	rootCmd.PersistentFlags().StringP("table-names-query", "", "", "Find the tables with this query, it must return a single column of table names")
+	rootCmd.PersistentFlags().BoolP("strict-types", "", false, "Fail on columns whose type the driver doesn't know instead of using a string")
+	rootCmd.PersistentFlags().StringP("default-type", "", "", "The Go type of columns whose type the driver doesn't know, string when empty")
+	rootCmd.PersistentFlags().StringP("default-nullable-type", "", "", "The Go type of nullable columns whose type the driver doesn't know, null.String when empty")
+	rootCmd.PersistentFlags().BoolP("skip-table-errors", "", false, "Generate the tables that could be read when others fail, with a warning")
+	rootCmd.PersistentFlags().BoolP("not-null-checks", "", false, "Treat nullable columns with a CHECK (col IS NOT NULL) as NOT NULL")
+	rootCmd.PersistentFlags().BoolP("force-nullable", "", false, "Generate every column that isn't part of a primary key with a null type")
+	rootCmd.PersistentFlags().StringP("null-package", "", "", "Import the null types from this package instead of gopkg.in/volatiletech/null.v6")
 	rootCmd.PersistentFlags().BoolP("version", "", false, "Print the version")
 	rootCmd.PersistentFlags().BoolP("tinyint-as-bool", "", false, "Map MySQL tinyint(1) in Go to bool instead of int8")
+	rootCmd.PersistentFlags().BoolP("set-as-slice", "", false, "Map MySQL SET columns in Go to types.Set instead of string")
+	rootCmd.PersistentFlags().BoolP("extended-metadata", "", false, "Read additional table metadata, eg. table persistence (postgres and mysql only)")
+	rootCmd.PersistentFlags().BoolP("consistent-snapshot", "", false, "Read the schema inside a single read only transaction (postgres and mysql only)")
+	rootCmd.PersistentFlags().DurationP("statement-timeout", "", 0, "Fail when a query reading the schema takes longer than this, eg: 30s")
+	rootCmd.PersistentFlags().BoolP("use-pgx", "", false, "Connect with the pgx driver instead of lib/pq (postgres only)")
+	rootCmd.PersistentFlags().BoolP("owned-tables-only", "", false, "Only generate the tables owned by the connecting user (postgres only)")
+	rootCmd.PersistentFlags().StringP("sql-driver-name", "", "", "Connect with the database/sql driver registered under this name (postgres only)")
 	rootCmd.PersistentFlags().BoolP("wipe", "", false, "Delete the output folder (rm -rf) before generation to ensure sanity")
 	rootCmd.PersistentFlags().StringP("struct-tag-casing", "", "snake", "Decides the casing for go structure tag names. camel or snake (default snake)")
 
@@ -98,6 +116,10 @@ func main() {
 	viper.SetDefault("mysql.port", "3306")
 	viper.SetDefault("mssql.sslmode", "true")
 	viper.SetDefault("mssql.port", "1433")
+	viper.SetDefault("clickhouse.sslmode", "false")
+	viper.SetDefault("clickhouse.port", "9000")
+	viper.SetDefault("vertica.sslmode", "none")
+	viper.SetDefault("vertica.port", "5433")
 
 	viper.BindPFlags(rootCmd.PersistentFlags())
 	viper.AutomaticEnv()
@@ -132,17 +154,26 @@ func preRun(cmd *cobra.Command, args []string) error {
 	driverName := args[0]
 
 	cmdConfig = &boilingcore.Config{
-		DriverName:       driverName,
-		OutFolder:        viper.GetString("output"),
-		Schema:           viper.GetString("schema"),
-		PkgName:          viper.GetString("pkgname"),
-		BaseDir:          viper.GetString("basedir"),
-		Debug:            viper.GetBool("debug"),
-		NoTests:          viper.GetBool("no-tests"),
-		NoHooks:          viper.GetBool("no-hooks"),
-		NoAutoTimestamps: viper.GetBool("no-auto-timestamps"),
-		Wipe:             viper.GetBool("wipe"),
-		StructTagCasing:  strings.ToLower(viper.GetString("struct-tag-casing")), // camel | snake
+		DriverName:          driverName,
+		OutFolder:           viper.GetString("output"),
+		Schema:              viper.GetString("schema"),
+		PkgName:             viper.GetString("pkgname"),
+		BaseDir:             viper.GetString("basedir"),
+		Debug:               viper.GetBool("debug"),
+		NoTests:             viper.GetBool("no-tests"),
+		NoHooks:             viper.GetBool("no-hooks"),
+		NoAutoTimestamps:    viper.GetBool("no-auto-timestamps"),
+		LooseJoinTables:     viper.GetBool("loose-join-tables"),
+		TableNamesQuery:     viper.GetString("table-names-query"),
+		StrictTypes:         viper.GetBool("strict-types"),
+		ForceNullable:       viper.GetBool("force-nullable"),
+		DefaultType:         viper.GetString("default-type"),
+		DefaultNullableType: viper.GetString("default-nullable-type"),
+		SkipTableErrors:     viper.GetBool("skip-table-errors"),
+		NotNullChecks:       viper.GetBool("not-null-checks"),
+		NullPackage:         viper.GetString("null-package"),
+		Wipe:                viper.GetBool("wipe"),
+		StructTagCasing:     strings.ToLower(viper.GetString("struct-tag-casing")), // camel | snake
 	}
 
 	// BUG: https://github.com/spf13/viper/issues/200
@@ -165,6 +196,14 @@ func preRun(cmd *cobra.Command, args []string) error {
 		}
 	}
 
+	cmdConfig.ExcludeColumnTypes = viper.GetStringSlice("exclude-column-types")
+	if len(cmdConfig.ExcludeColumnTypes) == 1 && strings.ContainsRune(cmdConfig.ExcludeColumnTypes[0], ',') {
+		cmdConfig.ExcludeColumnTypes, err = cmd.PersistentFlags().GetStringSlice("exclude-column-types")
+		if err != nil {
+			return err
+		}
+	}
+
 	cmdConfig.Tags = viper.GetStringSlice("tag")
 	if len(cmdConfig.Tags) == 1 && strings.ContainsRune(cmdConfig.Tags[0], ',') {
 		cmdConfig.Tags, err = cmd.PersistentFlags().GetStringSlice("tag")
@@ -173,6 +212,17 @@ func preRun(cmd *cobra.Command, args []string) error {
 		}
 	}
 
+	cmdConfig.UppercaseWords = viper.GetStringSlice("uppercase-words")
+	if len(cmdConfig.UppercaseWords) == 1 && strings.ContainsRune(cmdConfig.UppercaseWords[0], ',') {
+		cmdConfig.UppercaseWords, err = cmd.PersistentFlags().GetStringSlice("uppercase-words")
+		if err != nil {
+			return err
+		}
+	}
+
+	// Model names for tables, from the [aliases] table of the config file
+	cmdConfig.TableAliases = viper.GetStringMapString("aliases")
+
 	cmdConfig.Replacements = viper.GetStringSlice("replace")
 	if len(cmdConfig.Replacements) == 1 && strings.ContainsRune(cmdConfig.Replacements[0], ',') {
 		cmdConfig.Replacements, err = cmd.PersistentFlags().GetStringSlice("replace")
@@ -181,6 +231,15 @@ func preRun(cmd *cobra.Command, args []string) error {
 		}
 	}
 
+	// Set ExtendedMetadata global var. This flag only applies to Postgres and MySQL.
+	drivers.ExtendedMetadata = viper.GetBool("extended-metadata")
+
+	// Set ConsistentSnapshot global var. This flag only applies to Postgres and MySQL.
+	drivers.ConsistentSnapshot = viper.GetBool("consistent-snapshot")
+
+	// Set StatementTimeout global var. This flag applies to every driver.
+	drivers.StatementTimeout = viper.GetDuration("statement-timeout")
+
 	if driverName == "postgres" {
 		cmdConfig.Postgres = boilingcore.PostgresConfig{
 			User:    viper.GetString("postgres.user"),
@@ -189,6 +248,35 @@ func preRun(cmd *cobra.Command, args []string) error {
 			Port:    viper.GetInt("postgres.port"),
 			DBName:  viper.GetString("postgres.dbname"),
 			SSLMode: viper.GetString("postgres.sslmode"),
+
+			SchemaFile: viper.GetString("postgres.schemafile"),
+		}
+
+		// Set UsePgx global var. This flag only applies to Postgres.
+		drivers.UsePgx = viper.GetBool("use-pgx")
+
+		// Set PostgresSQLDriverName global var. This flag only applies to Postgres.
+		drivers.PostgresSQLDriverName = viper.GetString("sql-driver-name")
+
+		// Set PostgresOwnedTablesOnly global var. This flag only applies to Postgres.
+		drivers.PostgresOwnedTablesOnly = viper.GetBool("owned-tables-only")
+
+		// Set PostgresSSHTunnel global var. These settings only apply to Postgres.
+		drivers.PostgresSSHTunnel = drivers.SSHTunnel{
+			Host:           viper.GetString("postgres.ssh_host"),
+			User:           viper.GetString("postgres.ssh_user"),
+			KeyFile:        viper.GetString("postgres.ssh_key"),
+			KnownHostsFile: viper.GetString("postgres.ssh_known_hosts"),
+		}
+
+		// Set PostgresIntTypes global var. These settings only apply to Postgres.
+		drivers.PostgresIntTypes = drivers.IntTypes{
+			Smallint: viper.GetString("postgres.smallint_type"),
+			Integer:  viper.GetString("postgres.integer_type"),
+			Bigint:   viper.GetString("postgres.bigint_type"),
+		}
+		if err := drivers.PostgresIntTypes.Validate(); err != nil {
+			return err
 		}
 
 		// BUG: https://github.com/spf13/viper/issues/71
@@ -208,16 +296,19 @@ func preRun(cmd *cobra.Command, args []string) error {
 			cmdConfig.Schema = "public"
 		}
 
-		err = vala.BeginValidation().Validate(
-			vala.StringNotEmpty(cmdConfig.Postgres.User, "postgres.user"),
-			vala.StringNotEmpty(cmdConfig.Postgres.Host, "postgres.host"),
-			vala.Not(vala.Equals(cmdConfig.Postgres.Port, 0, "postgres.port")),
-			vala.StringNotEmpty(cmdConfig.Postgres.DBName, "postgres.dbname"),
-			vala.StringNotEmpty(cmdConfig.Postgres.SSLMode, "postgres.sslmode"),
-		).Check()
-
-		if err != nil {
-			return commandFailure(err.Error())
+		// Nothing is needed to connect when the schema is read from a file
+		if len(cmdConfig.Postgres.SchemaFile) == 0 {
+			err = vala.BeginValidation().Validate(
+				vala.StringNotEmpty(cmdConfig.Postgres.User, "postgres.user"),
+				vala.StringNotEmpty(cmdConfig.Postgres.Host, "postgres.host"),
+				vala.Not(vala.Equals(cmdConfig.Postgres.Port, 0, "postgres.port")),
+				vala.StringNotEmpty(cmdConfig.Postgres.DBName, "postgres.dbname"),
+				vala.StringNotEmpty(cmdConfig.Postgres.SSLMode, "postgres.sslmode"),
+			).Check()
+
+			if err != nil {
+				return commandFailure(err.Error())
+			}
 		}
 	}
 
@@ -229,11 +320,25 @@ func preRun(cmd *cobra.Command, args []string) error {
 			Port:    viper.GetInt("mysql.port"),
 			DBName:  viper.GetString("mysql.dbname"),
 			SSLMode: viper.GetString("mysql.sslmode"),
+			TiDB:    viper.GetBool("mysql.tidb"),
+		}
+
+		// Set MySQLIntTypes global var. These settings only apply to MySQL.
+		drivers.MySQLIntTypes = drivers.IntTypes{
+			Smallint: viper.GetString("mysql.smallint_type"),
+			Integer:  viper.GetString("mysql.integer_type"),
+			Bigint:   viper.GetString("mysql.bigint_type"),
+		}
+		if err := drivers.MySQLIntTypes.Validate(); err != nil {
+			return err
 		}
 
 		// Set MySQL TinyintAsBool global var. This flag only applies to MySQL.
 		drivers.TinyintAsBool = viper.GetBool("tinyint-as-bool")
 
+		// Set MySQL SetAsSlice global var. This flag only applies to MySQL.
+		drivers.SetAsSlice = viper.GetBool("set-as-slice")
+
 		// MySQL doesn't have schemas, just databases
 		cmdConfig.Schema = cmdConfig.MySQL.DBName
 
@@ -303,6 +408,141 @@ func preRun(cmd *cobra.Command, args []string) error {
 		}
 	}
 
+	if driverName == "spanner" {
+		cmdConfig.Spanner = boilingcore.SpannerConfig{
+			Project:  viper.GetString("spanner.project"),
+			Instance: viper.GetString("spanner.instance"),
+			DBName:   viper.GetString("spanner.dbname"),
+		}
+
+		// There is no test main template for spanner, the generated tests
+		// would not be able to set up a database to run against.
+		cmdConfig.NoTests = true
+
+		err = vala.BeginValidation().Validate(
+			vala.StringNotEmpty(cmdConfig.Spanner.Project, "spanner.project"),
+			vala.StringNotEmpty(cmdConfig.Spanner.Instance, "spanner.instance"),
+			vala.StringNotEmpty(cmdConfig.Spanner.DBName, "spanner.dbname"),
+		).Check()
+
+		if err != nil {
+			return commandFailure(err.Error())
+		}
+	}
+
+	if driverName == "clickhouse" {
+		cmdConfig.ClickHouse = boilingcore.ClickHouseConfig{
+			User:    viper.GetString("clickhouse.user"),
+			Pass:    viper.GetString("clickhouse.pass"),
+			Host:    viper.GetString("clickhouse.host"),
+			Port:    viper.GetInt("clickhouse.port"),
+			DBName:  viper.GetString("clickhouse.dbname"),
+			SSLMode: viper.GetString("clickhouse.sslmode"),
+		}
+
+		// ClickHouse doesn't have schemas, just databases
+		cmdConfig.Schema = cmdConfig.ClickHouse.DBName
+
+		// There is no test main template for clickhouse, the generated tests
+		// would not be able to set up a database to run against.
+		cmdConfig.NoTests = true
+
+		// BUG: https://github.com/spf13/viper/issues/71
+		// Despite setting defaults, nested values don't get defaults
+		// Set them manually
+		if cmdConfig.ClickHouse.SSLMode == "" {
+			cmdConfig.ClickHouse.SSLMode = "false"
+			viper.Set("clickhouse.sslmode", cmdConfig.ClickHouse.SSLMode)
+		}
+
+		if cmdConfig.ClickHouse.Port == 0 {
+			cmdConfig.ClickHouse.Port = 9000
+			viper.Set("clickhouse.port", cmdConfig.ClickHouse.Port)
+		}
+
+		err = vala.BeginValidation().Validate(
+			vala.StringNotEmpty(cmdConfig.ClickHouse.Host, "clickhouse.host"),
+			vala.Not(vala.Equals(cmdConfig.ClickHouse.Port, 0, "clickhouse.port")),
+			vala.StringNotEmpty(cmdConfig.ClickHouse.DBName, "clickhouse.dbname"),
+		).Check()
+
+		if err != nil {
+			return commandFailure(err.Error())
+		}
+	}
+
+	if driverName == "vertica" {
+		cmdConfig.Vertica = boilingcore.VerticaConfig{
+			User:    viper.GetString("vertica.user"),
+			Pass:    viper.GetString("vertica.pass"),
+			Host:    viper.GetString("vertica.host"),
+			Port:    viper.GetInt("vertica.port"),
+			DBName:  viper.GetString("vertica.dbname"),
+			SSLMode: viper.GetString("vertica.sslmode"),
+		}
+
+		// There is no test main template for vertica, the generated tests
+		// would not be able to set up a database to run against.
+		cmdConfig.NoTests = true
+
+		// BUG: https://github.com/spf13/viper/issues/71
+		// Despite setting defaults, nested values don't get defaults
+		// Set them manually
+		if cmdConfig.Vertica.SSLMode == "" {
+			cmdConfig.Vertica.SSLMode = "none"
+			viper.Set("vertica.sslmode", cmdConfig.Vertica.SSLMode)
+		}
+
+		if cmdConfig.Vertica.Port == 0 {
+			cmdConfig.Vertica.Port = 5433
+			viper.Set("vertica.port", cmdConfig.Vertica.Port)
+		}
+
+		if len(cmdConfig.Schema) == 0 {
+			cmdConfig.Schema = "public"
+		}
+
+		err = vala.BeginValidation().Validate(
+			vala.StringNotEmpty(cmdConfig.Vertica.User, "vertica.user"),
+			vala.StringNotEmpty(cmdConfig.Vertica.Host, "vertica.host"),
+			vala.Not(vala.Equals(cmdConfig.Vertica.Port, 0, "vertica.port")),
+			vala.StringNotEmpty(cmdConfig.Vertica.DBName, "vertica.dbname"),
+		).Check()
+
+		if err != nil {
+			return commandFailure(err.Error())
+		}
+	}
+
+	if driverName == "snowflake" {
+		cmdConfig.Snowflake = boilingcore.SnowflakeConfig{
+			User:      viper.GetString("snowflake.user"),
+			Pass:      viper.GetString("snowflake.pass"),
+			Account:   viper.GetString("snowflake.account"),
+			DBName:    viper.GetString("snowflake.dbname"),
+			Warehouse: viper.GetString("snowflake.warehouse"),
+			Role:      viper.GetString("snowflake.role"),
+		}
+
+		// There is no test main template for snowflake, the generated tests
+		// would not be able to set up a database to run against.
+		cmdConfig.NoTests = true
+
+		if len(cmdConfig.Schema) == 0 {
+			cmdConfig.Schema = "public"
+		}
+
+		err = vala.BeginValidation().Validate(
+			vala.StringNotEmpty(cmdConfig.Snowflake.User, "snowflake.user"),
+			vala.StringNotEmpty(cmdConfig.Snowflake.Account, "snowflake.account"),
+			vala.StringNotEmpty(cmdConfig.Snowflake.DBName, "snowflake.dbname"),
+		).Check()
+
+		if err != nil {
+			return commandFailure(err.Error())
+		}
+	}
+
 	cmdState, err = boilingcore.New(cmdConfig)
 	return err
 }
@@ -1,9 +1,14 @@
 package randomize
 
 import (
+	"bytes"
 	"crypto/md5"
+	"encoding/binary"
+	"encoding/hex"
 	"fmt"
 	"math/rand"
+	"strconv"
+	"strings"
 )
 
 const alphabetAll = "0123456789abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ"
@@ -33,6 +38,83 @@ func randPoint() string {
 	return fmt.Sprintf("(%d,%d)", a, b)
 }
 
+// wkbTypes are the well known binary codes of the geometry types
+var wkbTypes = map[string]uint32{
+	"point": 1, "linestring": 2, "polygon": 3, "multipoint": 4,
+	"multilinestring": 5, "multipolygon": 6, "geometrycollection": 7,
+}
+
+// randWKB is the little endian well known binary of a geometry of the
+// named type, a point when the type is geometry or unknown. When srid isn't
+// 0 it's postgis' extended WKB with the SRID.
+func randWKB(s *Seed, geomType string, srid uint32) []byte {
+	geomType = strings.ToLower(geomType)
+	if geomType == "geomcollection" {
+		geomType = "geometrycollection"
+	}
+	code, ok := wkbTypes[geomType]
+	if !ok {
+		geomType, code = "point", wkbTypes["point"]
+	}
+
+	// Coordinates are kept small so they're valid as longitude and latitude
+	x, y := float64(s.nextInt()%89), float64(s.nextInt()%89)
+
+	buf := new(bytes.Buffer)
+	buf.WriteByte(1)
+	if srid != 0 {
+		binary.Write(buf, binary.LittleEndian, code|0x20000000)
+		binary.Write(buf, binary.LittleEndian, srid)
+	} else {
+		binary.Write(buf, binary.LittleEndian, code)
+	}
+
+	switch geomType {
+	case "point":
+		binary.Write(buf, binary.LittleEndian, [2]float64{x, y})
+	case "linestring":
+		binary.Write(buf, binary.LittleEndian, uint32(2))
+		binary.Write(buf, binary.LittleEndian, [4]float64{x, y, x + 1, y + 1})
+	case "polygon":
+		// One closed ring around a triangle
+		binary.Write(buf, binary.LittleEndian, [2]uint32{1, 4})
+		binary.Write(buf, binary.LittleEndian, [8]float64{x, y, x + 1, y, x + 1, y + 1, x, y})
+	default:
+		// Collections of one geometry of the element type
+		binary.Write(buf, binary.LittleEndian, uint32(1))
+		elem := "point"
+		if strings.HasPrefix(geomType, "multi") {
+			elem = strings.TrimPrefix(geomType, "multi")
+		}
+		buf.Write(randWKB(s, elem, 0))
+	}
+
+	return buf.Bytes()
+}
+
+// randPostGIS is the hex extended WKB, which is how postgis outputs geometry
+// and geography, of a geometry for a column of fieldType, eg:
+// geometry(POINT,4326). The geometry type and SRID are from ColumnDBTypes.
+func randPostGIS(s *Seed, fieldType string) []byte {
+	var geomType string
+	var srid uint64
+	if start, end := strings.IndexByte(fieldType, '('), strings.IndexByte(fieldType, ')'); start >= 0 && end > start {
+		args := strings.Split(fieldType[start+1:end], ",")
+		geomType = args[0]
+		if len(args) > 1 {
+			srid, _ = strconv.ParseUint(args[1], 10, 32)
+		}
+	}
+
+	return []byte(strings.ToUpper(hex.EncodeToString(randWKB(s, geomType, uint32(srid)))))
+}
+
+// randMySQLGeometry is a geometry of the mysql type in mysql's internal
+// format, which is the 4 byte SRID (0 here) followed by the WKB.
+func randMySQLGeometry(s *Seed, fieldType string) []byte {
+	return append(make([]byte, 4), randWKB(s, fieldType, 0)...)
+}
+
 func randBox() string {
 	a := rand.Intn(100)
 	b := a + 1
@@ -5,6 +5,7 @@ import (
 	"database/sql"
 	"fmt"
 	"math"
+	"math/big"
 	"math/rand"
 	"reflect"
 	"regexp"
@@ -41,6 +42,7 @@ var (
 	typeNullTime     = reflect.TypeOf(null.Time{})
 	typeNullBytes    = reflect.TypeOf(null.Bytes{})
 	typeNullJSON     = reflect.TypeOf(null.JSON{})
+	typeByteSlice    = reflect.TypeOf([]byte{})
 	typeTime         = reflect.TypeOf(time.Time{})
 	typeJSON         = reflect.TypeOf(types.JSON{})
 	typeInt64Array   = reflect.TypeOf(types.Int64Array{})
@@ -49,6 +51,9 @@ var (
 	typeFloat64Array = reflect.TypeOf(types.Float64Array{})
 	typeStringArray  = reflect.TypeOf(types.StringArray{})
 	typeHStore       = reflect.TypeOf(types.HStore{})
+	typeDecimal      = reflect.TypeOf(types.Decimal{})
+	typeNullDecimal  = reflect.TypeOf(types.NullDecimal{})
+	typeSet          = reflect.TypeOf(types.Set{})
 	rgxValidTime     = regexp.MustCompile(`[2-9]+`)
 
 	validatedTypes = []string{
@@ -57,6 +62,13 @@ var (
 		"lseg", "macaddr", "path", "pg_lsn", "point",
 		"polygon", "txid_snapshot", "money", "hstore",
 	}
+
+	// mysqlSpatialTypes are the mysql types that are randomized as geometry
+	// when they're []byte or null.Bytes
+	mysqlSpatialTypes = []string{
+		"geometry", "point", "linestring", "polygon", "multipoint",
+		"multilinestring", "multipolygon", "geometrycollection", "geomcollection",
+	}
 )
 
 // Seed is an atomic counter for pseudo-randomization structs. Using full
@@ -178,6 +190,33 @@ func randomizeField(s *Seed, field reflect.Value, fieldType string, canBeNull bo
 		return nil
 	}
 
+	if typ == typeSet {
+		field.Set(reflect.ValueOf(randSetValue(s, fieldType)))
+		return nil
+	}
+
+	// PostGIS columns have to be valid geometry of their geometry type and SRID
+	if strings.HasPrefix(fieldType, "geometry(") || strings.HasPrefix(fieldType, "geography(") {
+		switch typ {
+		case typeByteSlice:
+			field.Set(reflect.ValueOf(randPostGIS(s, fieldType)))
+			return nil
+		case typeNullBytes:
+			field.Set(reflect.ValueOf(null.NewBytes(randPostGIS(s, fieldType), true)))
+			return nil
+		}
+	}
+
+	// mysql's spatial columns have to be its internal format of their type
+	if (typ == typeByteSlice || typ == typeNullBytes) && strmangle.SetInclude(fieldType, mysqlSpatialTypes) {
+		if typ == typeByteSlice {
+			field.Set(reflect.ValueOf(randMySQLGeometry(s, fieldType)))
+		} else {
+			field.Set(reflect.ValueOf(null.NewBytes(randMySQLGeometry(s, fieldType), true)))
+		}
+		return nil
+	}
+
 	var value interface{}
 	var isNull bool
 
@@ -385,6 +424,11 @@ func getArrayRandValue(s *Seed, typ reflect.Type, fieldType string) interface{}
 	case typeBoolArray:
 		return types.BoolArray{s.nextInt()%2 == 0, s.nextInt()%2 == 0, s.nextInt()%2 == 0}
 	case typeStringArray:
+		if strings.HasPrefix(fieldType, "enum") {
+			if value, err := randEnumValue(s, fieldType); err == nil {
+				return types.StringArray{value}
+			}
+		}
 		if fieldType == "interval" {
 			value := strconv.Itoa((s.nextInt()%26)+2) + " days"
 			return types.StringArray{value, value}
@@ -478,6 +522,10 @@ func getStructNullValue(s *Seed, typ reflect.Type) interface{} {
 		return null.NewBytes(nil, false)
 	case typeNullByte:
 		return null.NewByte(byte(0), false)
+	case typeDecimal:
+		return types.NewDecimal(new(big.Rat))
+	case typeNullDecimal:
+		return types.NewNullDecimal(nil, false)
 	}
 
 	return nil
@@ -524,6 +572,10 @@ func getStructRandValue(s *Seed, typ reflect.Type) interface{} {
 		return null.NewBytes(randByteSlice(s, 1), true)
 	case typeNullByte:
 		return null.NewByte(byte(rand.Intn(125-65)+65), true)
+	case typeDecimal:
+		return types.NewDecimal(big.NewRat(int64(s.nextInt()), 100))
+	case typeNullDecimal:
+		return types.NewNullDecimal(big.NewRat(int64(s.nextInt()), 100), true)
 	}
 
 	return nil
@@ -580,6 +632,9 @@ func getVariableRandValue(s *Seed, kind reflect.Kind, typ reflect.Type) interfac
 	switch typ.String() {
 	case "types.Byte":
 		return types.Byte(rand.Intn(125-65) + 65)
+	case "types.Set":
+		// Without the members the empty set is the only valid value
+		return types.Set{}
 	}
 
 	switch kind {
@@ -622,6 +677,24 @@ func getVariableRandValue(s *Seed, kind reflect.Kind, typ reflect.Type) interfac
 	return nil
 }
 
+// randSetValue picks some of the members of a set, eg: set('a','b'), the
+// empty set when there are none to pick from.
+func randSetValue(s *Seed, set string) types.Set {
+	value := types.Set{}
+	start := strings.IndexByte(set, '(')
+	if start < 0 || len(set) < start+4 {
+		return value
+	}
+
+	for _, member := range strings.Split(set[start+2:len(set)-2], "','") {
+		if s.nextInt()%2 == 0 {
+			value = append(value, member)
+		}
+	}
+
+	return value
+}
+
 func randEnumValue(s *Seed, enum string) (string, error) {
 	vals := strmangle.ParseEnumVals(enum)
 	if vals == nil || len(vals) == 0 {
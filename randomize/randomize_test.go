@@ -1,10 +1,13 @@
 package randomize
 
 import (
+	"encoding/hex"
 	"reflect"
+	"strings"
 	"testing"
 	"time"
 
+	"github.com/volatiletech/sqlboiler/strmangle"
 	null "gopkg.in/volatiletech/null.v6"
 )
 
@@ -181,3 +184,115 @@ func TestRandEnumValue(t *testing.T) {
 		t.Errorf("Expected monday got: %q", r3)
 	}
 }
+
+func TestRandSetValue(t *testing.T) {
+	t.Parallel()
+
+	s := NewSeed()
+	members := []string{"red", "green", "blue"}
+
+	for i := 0; i < 10; i++ {
+		for _, m := range randSetValue(s, "set('red','green','blue')") {
+			if !strmangle.SetInclude(m, members) {
+				t.Errorf("%q is not a member of the set", m)
+			}
+		}
+	}
+
+	if set := randSetValue(s, "set"); set == nil || len(set) != 0 {
+		t.Errorf("want the empty set without members, got: %#v", set)
+	}
+}
+
+func TestRandPostGIS(t *testing.T) {
+	t.Parallel()
+
+	s := NewSeed()
+
+	tests := []struct {
+		FieldType string
+		Prefix    string
+		Len       int
+	}{
+		{"geography(POINT,4326)", "0101000020E6100000", 25},
+		{"geometry(GEOMETRY,0)", "0101000000", 21},
+		{"geometry(POLYGON,3857)", "0103000020110F0000", 81},
+		{"geometry(MULTIPOINT,0)", "0104000000010000000101000000", 30},
+	}
+
+	for i, test := range tests {
+		b, err := hex.DecodeString(string(randPostGIS(s, test.FieldType)))
+		if err != nil {
+			t.Fatalf("%d) %v", i, err)
+		}
+		if got := strings.ToUpper(hex.EncodeToString(b)); !strings.HasPrefix(got, test.Prefix) {
+			t.Errorf("%d) want a geometry starting with %s, got %s", i, test.Prefix, got)
+		}
+		if len(b) != test.Len {
+			t.Errorf("%d) want %d bytes, got %d", i, test.Len, len(b))
+		}
+	}
+
+	var value struct {
+		Shape    []byte
+		NewShape null.Bytes
+	}
+	colTypes := map[string]string{"Shape": "geometry(POINT,0)", "NewShape": "geography(POINT,4326)"}
+	if err := Struct(s, &value, colTypes, true); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(string(value.Shape), "0101000000") {
+		t.Errorf("want a point, got %s", value.Shape)
+	}
+	if !value.NewShape.Valid || !strings.HasPrefix(string(value.NewShape.Bytes), "0101000020E6100000") {
+		t.Errorf("want a point with an srid, got %#v", value.NewShape)
+	}
+}
+
+func TestRandMySQLGeometry(t *testing.T) {
+	t.Parallel()
+
+	s := NewSeed()
+
+	tests := []struct {
+		FieldType string
+		Prefix    string
+		Len       int
+	}{
+		{"point", "000000000101000000", 25},
+		{"geometry", "000000000101000000", 25},
+		{"linestring", "00000000010200000002000000", 45},
+		{"polygon", "0000000001030000000100000004000000", 81},
+		{"multipolygon", "00000000010600000001000000010300000001000000", 90},
+		{"geomcollection", "000000000107000000010000000101000000", 34},
+	}
+
+	for i, test := range tests {
+		b := randMySQLGeometry(s, test.FieldType)
+		if got := strings.ToUpper(hex.EncodeToString(b)); !strings.HasPrefix(got, test.Prefix) {
+			t.Errorf("%d) want a geometry starting with %s, got %s", i, test.Prefix, got)
+		}
+		if len(b) != test.Len {
+			t.Errorf("%d) want %d bytes, got %d", i, test.Len, len(b))
+		}
+	}
+
+	var value struct {
+		Area    []byte
+		NewArea null.Bytes
+		Spot    string
+	}
+	colTypes := map[string]string{"Area": "polygon", "NewArea": "point", "Spot": "point"}
+	if err := Struct(s, &value, colTypes, true); err != nil {
+		t.Fatal(err)
+	}
+	if len(value.Area) != 81 {
+		t.Errorf("want a polygon, got %x", value.Area)
+	}
+	if !value.NewArea.Valid || len(value.NewArea.Bytes) != 25 {
+		t.Errorf("want a point, got %#v", value.NewArea)
+	}
+	if !strings.HasPrefix(value.Spot, "(") {
+		t.Errorf("want a postgres point, got %q", value.Spot)
+	}
+}
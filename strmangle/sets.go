@@ -69,6 +69,26 @@ func SetComplement(a []string, b []string) []string {
 	return c
 }
 
+// SetEqual checks that a and b hold the same elements, ignoring order
+func SetEqual(a []string, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	for _, aVal := range a {
+		if !SetInclude(aVal, b) {
+			return false
+		}
+	}
+	for _, bVal := range b {
+		if !SetInclude(bVal, a) {
+			return false
+		}
+	}
+
+	return true
+}
+
 // SetMerge will return a merged slice without duplicates
 func SetMerge(a []string, b []string) []string {
 	var x, merged []string
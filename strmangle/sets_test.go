@@ -135,6 +135,28 @@ func TestSetComplement(t *testing.T) {
 	}
 }
 
+func TestSetEqual(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		A     []string
+		B     []string
+		Equal bool
+	}{
+		{[]string{}, []string{}, true},
+		{[]string{"thing1", "thing2"}, []string{"thing2", "thing1"}, true},
+		{[]string{"thing1", "thing2"}, []string{"thing1"}, false},
+		{[]string{"thing1", "thing1"}, []string{"thing1", "thing2"}, false},
+		{[]string{"thing1"}, []string{"thing2"}, false},
+	}
+
+	for i, test := range tests {
+		if eq := SetEqual(test.A, test.B); eq != test.Equal {
+			t.Errorf("[%d] want %t, got %t", i, test.Equal, eq)
+		}
+	}
+}
+
 func TestSetMerge(t *testing.T) {
 	t.Parallel()
 
@@ -82,11 +82,11 @@ func init() {
 
 // SchemaTable returns a table name with a schema prefixed if
 // using a database that supports real schemas, for example,
-// for Postgres: "schema_name"."table_name",
+// for Postgres and Vertica: "schema_name"."table_name",
 // for MS SQL: [schema_name].[table_name], versus
 // simply "table_name" for MySQL (because it does not support real schemas)
 func SchemaTable(lq, rq string, driver string, schema string, table string) string {
-	if (driver == "postgres" && schema != "public") || driver == "mssql" {
+	if ((driver == "postgres" || driver == "vertica") && schema != "public") || driver == "mssql" {
 		return fmt.Sprintf(`%s%s%s.%s%s%s`, lq, schema, rq, lq, table, rq)
 	}
 
@@ -198,6 +198,22 @@ func Plural(name string) string {
 	return buf.String()
 }
 
+var identifierSuffixes = []string{"_id", "_uuid", "_guid", "_oid"}
+
+// TrimIdentifierSuffix removes the suffix of a column referencing another
+// table's identifier, eg: sender_id becomes sender. Only one is removed.
+func TrimIdentifierSuffix(str string) string {
+	ln := len(str)
+	for _, s := range identifierSuffixes {
+		str = strings.TrimSuffix(str, s)
+		if len(str) != ln {
+			break
+		}
+	}
+
+	return str
+}
+
 // Singular converts plural words to singular words (eg: people to person)
 func Singular(name string) string {
 	buf := GetBuffer()
@@ -221,6 +237,46 @@ func Singular(name string) string {
 	return buf.String()
 }
 
+// IsPlural checks if the last word of name is plural, for example
+// "users" and "people" are but "user" isn't. Words added with
+// AddUncountables are the same in both forms so they are plural as well.
+func IsPlural(name string) bool {
+	return Plural(Singular(name)) == name
+}
+
+// AddIrregulars adds singular to plural mappings for words the inflection
+// rules get wrong (eg: "cactus": "cacti"), they are used by Plural and
+// Singular from then on. It is not safe to call while generating.
+func AddIrregulars(irregulars map[string]string) {
+	for singular, plural := range irregulars {
+		boilRuleset.AddIrregular(singular, plural)
+	}
+}
+
+// AddUncountables adds words that don't change between their singular and
+// plural form (eg: "equipment"). See init for why the default ruleset has
+// none, a model and its plural function will have the same name.
+// It is not safe to call while generating.
+func AddUncountables(words ...string) {
+	for _, w := range words {
+		boilRuleset.AddUncountable(w)
+	}
+}
+
+// AddUppercaseWords adds acronyms that TitleCase and CamelCase write in
+// uppercase (eg: "sku" for "ProductSKU"), besides the ones they already
+// know like id and url. It is not safe to call while generating.
+func AddUppercaseWords(words ...string) {
+	mut.Lock()
+	defer mut.Unlock()
+
+	for _, w := range words {
+		uppercaseWords[strings.ToLower(w)] = struct{}{}
+	}
+	// Names cased before the words were added may be wrong now
+	titleCaseCache = map[string]string{}
+}
+
 // titleCaseCache holds the mapping of title cases.
 // Example: map["MyWord"] == "my_word"
 var (
@@ -354,6 +410,38 @@ func CamelCase(name string) string {
 	return buf.String()
 }
 
+// SnakeCase converts a mixed case name like "UserAccountID" or
+// "User Account" into "user_account_id". Runs of uppercase letters are
+// kept together as one word, so "HTTPServer" becomes "http_server".
+func SnakeCase(name string) string {
+	buf := GetBuffer()
+	defer PutBuffer(buf)
+
+	isUpper := func(c byte) bool { return c >= 'A' && c <= 'Z' }
+	isLower := func(c byte) bool { return (c >= 'a' && c <= 'z') || (c >= '0' && c <= '9') }
+
+	ln := len(name)
+	for i := 0; i < ln; i++ {
+		c := name[i]
+		switch {
+		case c == ' ' || c == '-' || c == '_':
+			if buf.Len() != 0 && buf.Bytes()[buf.Len()-1] != '_' {
+				buf.WriteByte('_')
+			}
+		case isUpper(c):
+			if i > 0 && buf.Len() != 0 && buf.Bytes()[buf.Len()-1] != '_' &&
+				(isLower(name[i-1]) || (isUpper(name[i-1]) && i+1 < ln && isLower(name[i+1]))) {
+				buf.WriteByte('_')
+			}
+			buf.WriteByte(c + 32)
+		default:
+			buf.WriteByte(c)
+		}
+	}
+
+	return strings.TrimSuffix(buf.String(), "_")
+}
+
 // TitleCaseIdentifier splits on dots and then titlecases each fragment.
 // map titleCase (split c ".")
 func TitleCaseIdentifier(id string) string {
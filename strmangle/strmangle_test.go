@@ -3,6 +3,8 @@ package strmangle
 import (
 	"strings"
 	"testing"
+
+	"github.com/volatiletech/inflect"
 )
 
 func TestIdentQuote(t *testing.T) {
@@ -171,6 +173,41 @@ func TestPlural(t *testing.T) {
 	}
 }
 
+func TestIsPlural(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		In  string
+		Out bool
+	}{
+		{"friends", true},
+		{"friend", false},
+		{"hello_people", true},
+		{"hello_person", false},
+	}
+
+	for i, test := range tests {
+		if out := IsPlural(test.In); out != test.Out {
+			t.Errorf("[%d] (%s) Out was wrong: %t, want: %t", i, test.In, out, test.Out)
+		}
+	}
+}
+
+func TestAddIrregulars(t *testing.T) {
+	// The ruleset is global, so this can't run alongside the other tests
+	defer func(r *inflect.Ruleset) { boilRuleset = r }(boilRuleset)
+	boilRuleset = newBoilRuleset()
+
+	AddIrregulars(map[string]string{"cactus": "cacti"})
+
+	if out := Plural("big_cactus"); out != "big_cacti" {
+		t.Errorf("plural was wrong: %q", out)
+	}
+	if out := Singular("big_cacti"); out != "big_cactus" {
+		t.Errorf("singular was wrong: %q", out)
+	}
+}
+
 func TestTitleCase(t *testing.T) {
 	t.Parallel()
 
@@ -215,6 +252,27 @@ func TestTitleCase(t *testing.T) {
 	}
 }
 
+func TestAddUppercaseWords(t *testing.T) {
+	if got := TitleCase("product_sku"); got != "ProductSku" {
+		t.Fatalf("want ProductSku before adding sku, got %s", got)
+	}
+
+	AddUppercaseWords("SKU")
+	defer func() {
+		mut.Lock()
+		delete(uppercaseWords, "sku")
+		titleCaseCache = map[string]string{}
+		mut.Unlock()
+	}()
+
+	if got := TitleCase("product_sku"); got != "ProductSKU" {
+		t.Errorf("want ProductSKU, got %s", got)
+	}
+	if got := CamelCase("product_sku_id"); got != "productSKUID" {
+		t.Errorf("want productSKUID, got %s", got)
+	}
+}
+
 func TestCamelCase(t *testing.T) {
 	t.Parallel()
 
@@ -257,6 +315,32 @@ func TestCamelCase(t *testing.T) {
 	}
 }
 
+func TestSnakeCase(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		In  string
+		Out string
+	}{
+		{"", ""},
+		{"already_snake", "already_snake"},
+		{"UserAccounts", "user_accounts"},
+		{"userAccountID", "user_account_id"},
+		{"HTTPServer", "http_server"},
+		{"User Account", "user_account"},
+		{"order-items", "order_items"},
+		{"Address2Line", "address2_line"},
+		{"ID", "id"},
+		{"Trailing_", "trailing"},
+	}
+
+	for i, test := range tests {
+		if out := SnakeCase(test.In); out != test.Out {
+			t.Errorf("[%d] (%s) Out was wrong: %q, want: %q", i, test.In, out, test.Out)
+		}
+	}
+}
+
 func TestTitleCaseIdentifier(t *testing.T) {
 	t.Parallel()
 
@@ -602,3 +686,15 @@ func TestReplaceReservedWords(t *testing.T) {
 		}
 	}
 }
+
+func TestTrimIdentifierSuffix(t *testing.T) {
+	t.Parallel()
+
+	for _, s := range identifierSuffixes {
+		a := "hello" + s
+
+		if z := TrimIdentifierSuffix(a); z != "hello" {
+			t.Errorf("got %s", z)
+		}
+	}
+}
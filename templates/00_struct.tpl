@@ -2,17 +2,16 @@
 {{- end -}}
 
 {{- $dot := . -}}
-{{- $tableNameSingular := .Table.Name | singular -}}
-{{- $modelName := $tableNameSingular | titleCase -}}
-{{- $modelNameCamel := $tableNameSingular | camelCase -}}
+{{- $modelName := .Table.GoName -}}
+{{- $modelNameCamel := .Table.VarName -}}
 
 // {{$modelName}} is an object representing the database table.
 type {{$modelName}} struct {
 	{{range $column := .Table.Columns }}
 	{{- if eq $dot.StructTagCasing "camel"}}
-	{{titleCase $column.Name}} {{$column.Type}} `{{generateTags $dot.Tags $column.Name}}boil:"{{$column.Name}}" json:"{{$column.Name | camelCase}}{{if $column.Nullable}},omitempty{{end}}" toml:"{{$column.Name | camelCase}}" yaml:"{{$column.Name | camelCase}}{{if $column.Nullable}},omitempty{{end}}"`
+	{{$column.GoName}} {{$column.Type}} `{{generateTags $dot.Tags $column.Name}}boil:"{{$column.Name}}" json:"{{$column.Name | camelCase}}{{if $column.Nullable}},omitempty{{end}}" toml:"{{$column.Name | camelCase}}" yaml:"{{$column.Name | camelCase}}{{if $column.Nullable}},omitempty{{end}}"`
 	{{- else -}}
-	{{titleCase $column.Name}} {{$column.Type}} `{{generateTags $dot.Tags $column.Name}}boil:"{{$column.Name}}" json:"{{$column.Name}}{{if $column.Nullable}},omitempty{{end}}" toml:"{{$column.Name}}" yaml:"{{$column.Name}}{{if $column.Nullable}},omitempty{{end}}"`
+	{{$column.GoName}} {{$column.Type}} `{{generateTags $dot.Tags $column.Name}}boil:"{{$column.Name}}" json:"{{$column.Name}}{{if $column.Nullable}},omitempty{{end}}" toml:"{{$column.Name}}" yaml:"{{$column.Name}}{{if $column.Nullable}},omitempty{{end}}"`
 	{{end -}}
 	{{end -}}
 	{{- if .Table.IsJoinTable -}}
@@ -24,11 +23,11 @@ type {{$modelName}} struct {
 
 var {{$modelName}}Columns = struct {
 	{{range $column := .Table.Columns -}}
-	{{titleCase $column.Name}} string
+	{{$column.GoName}} string
 	{{end -}}
 }{
 	{{range $column := .Table.Columns -}}
-	{{titleCase $column.Name}}: "{{$column.Name}}",
+	{{$column.GoName}}: "{{$column.Name}}",
 	{{end -}}
 }
 
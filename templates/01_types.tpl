@@ -1,7 +1,7 @@
 {{if .Table.IsJoinTable -}}
 {{else -}}
-{{- $varNameSingular := .Table.Name | singular | camelCase -}}
-{{- $tableNameSingular := .Table.Name | singular | titleCase -}}
+{{- $varNameSingular := .Table.VarName -}}
+{{- $tableNameSingular := .Table.GoName -}}
 var (
 	{{$varNameSingular}}Columns               = []string{{"{"}}{{.Table.Columns | columnNames | stringMap .StringFuncs.quoteWrap | join ", "}}{{"}"}}
 	{{if eq .DriverName "mssql" -}}
@@ -3,7 +3,7 @@
 	{{- $dot := . -}}
 	{{- range .Table.FKeys -}}
 		{{- $txt := txtsFromFKey $dot.Tables $dot.Table . -}}
-		{{- $varNameSingular := .ForeignTable | singular | camelCase}}
+		{{- $varNameSingular := (getTable $dot.Tables .ForeignTable).VarName}}
 // {{$txt.Function.Name}}G pointed to by the foreign key.
 func (o *{{$txt.LocalTable.NameGo}}) {{$txt.Function.Name}}G(mods ...qm.QueryMod) {{$varNameSingular}}Query {
 	return o.{{$txt.Function.Name}}(boil.GetDB(), mods...)
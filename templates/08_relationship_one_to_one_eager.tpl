@@ -3,7 +3,7 @@
 	{{- $dot := . -}}
 	{{- range .Table.ToOneRelationships -}}
 		{{- $txt := txtsFromOneToOne $dot.Tables $dot.Table . -}}
-		{{- $varNameSingular := $dot.Table.Name | singular | camelCase -}}
+		{{- $varNameSingular := $dot.Table.VarName -}}
 		{{- $arg := printf "maybe%s" $txt.LocalTable.NameGo -}}
 // Load{{$txt.Function.Name}} allows an eager lookup of values, cached into the
 // loaded structs of the objects.
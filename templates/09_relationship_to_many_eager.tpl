@@ -2,7 +2,7 @@
 {{- else -}}
 	{{- $dot := . -}}
 	{{- range .Table.ToManyRelationships -}}
-		{{- $varNameSingular := $dot.Table.Name | singular | camelCase -}}
+		{{- $varNameSingular := $dot.Table.VarName -}}
 		{{- $txt := txtsFromToMany $dot.Tables $dot.Table . -}}
 		{{- $arg := printf "maybe%s" $txt.LocalTable.NameGo -}}
 		{{- $schemaForeignTable := .ForeignTable | $dot.SchemaTable}}
@@ -25,13 +25,13 @@ func ({{$varNameSingular}}L) Load{{$txt.Function.Name}}(e boil.Executor, singula
 		if object.R == nil {
 			object.R = &{{$varNameSingular}}R{}
 		}
-		args[0] = object.{{.Column | titleCase}}
+		args[0] = object.{{$txt.LocalTable.ColumnNameGo}}
 	} else {
 		for i, obj := range slice {
 			if obj.R == nil {
 				obj.R = &{{$varNameSingular}}R{}
 			}
-			args[i] = obj.{{.Column | titleCase}}
+			args[i] = obj.{{$txt.LocalTable.ColumnNameGo}}
 		}
 	}
 
@@ -68,7 +68,7 @@ func ({{$varNameSingular}}L) Load{{$txt.Function.Name}}(e boil.Executor, singula
 		one := new({{$txt.ForeignTable.NameGo}})
 		var localJoinCol {{$localCol.Type}}
 
-		err = results.Scan({{$foreignTable.Columns | columnNames | stringMap $dot.StringFuncs.titleCase | prefixStringSlice "&one." | join ", "}}, &localJoinCol)
+		err = results.Scan({{$foreignTable.Columns | columnNames | $foreignTable.ColumnGoNames | prefixStringSlice "&one." | join ", "}}, &localJoinCol)
 		if err = results.Err(); err != nil {
 			return errors.Wrap(err, "failed to plebian-bind eager loaded slice {{.ForeignTable}}")
 		}
@@ -87,7 +87,7 @@ func ({{$varNameSingular}}L) Load{{$txt.Function.Name}}(e boil.Executor, singula
 	{{end}}
 
 	{{if not $dot.NoHooks -}}
-	if len({{.ForeignTable | singular | camelCase}}AfterSelectHooks) != 0 {
+	if len({{(getTable $dot.Tables .ForeignTable).VarName}}AfterSelectHooks) != 0 {
 		for _, obj := range resultSlice {
 			if err := obj.doAfterSelectHooks(e); err != nil {
 				return err
@@ -3,8 +3,8 @@
 	{{- $dot := . -}}
 	{{- range .Table.FKeys -}}
 		{{- $txt := txtsFromFKey $dot.Tables $dot.Table . -}}
-		{{- $foreignNameSingular := .ForeignTable | singular | camelCase -}}
-		{{- $varNameSingular := .Table | singular | camelCase}}
+		{{- $foreignNameSingular := (getTable $dot.Tables .ForeignTable).VarName -}}
+		{{- $varNameSingular := (getTable $dot.Tables .Table).VarName}}
 		{{- $schemaTable := .Table | $dot.SchemaTable}}
 // Set{{$txt.Function.Name}}G of the {{.Table | singular}} to the related item.
 // Sets o.R.{{$txt.Function.Name}} to related.
@@ -50,7 +50,7 @@ func (o *{{$txt.LocalTable.NameGo}}) Set{{$txt.Function.Name}}(exec boil.Executo
 		strmangle.SetParamNames("{{$dot.LQ}}", "{{$dot.RQ}}", {{if $dot.Dialect.IndexPlaceholders}}1{{else}}0{{end}}, []string{{"{"}}"{{.Column}}"{{"}"}}),
 		strmangle.WhereClause("{{$dot.LQ}}", "{{$dot.RQ}}", {{if $dot.Dialect.IndexPlaceholders}}2{{else}}0{{end}}, {{$varNameSingular}}PrimaryKeyColumns),
 	)
-	values := []interface{}{related.{{$txt.ForeignTable.ColumnNameGo}}, o.{{$dot.Table.PKey.Columns | stringMap $dot.StringFuncs.titleCase | join ", o."}}{{"}"}}
+	values := []interface{}{related.{{$txt.ForeignTable.ColumnNameGo}}, o.{{$dot.Table.PKey.Columns | $dot.Table.ColumnGoNames | join ", o."}}{{"}"}}
 
 	if boil.DebugMode {
 		fmt.Fprintln(boil.DebugWriter, updateQuery)
@@ -62,7 +62,7 @@ func (o *{{$txt.LocalTable.NameGo}}) Set{{$txt.Function.Name}}(exec boil.Executo
 	}
 
 	o.{{$txt.Function.LocalAssignment}} = related.{{$txt.Function.ForeignAssignment}}
-	{{if .Nullable -}}
+	{{if $txt.LocalTable.ColumnNullType -}}
 	o.{{$txt.LocalTable.ColumnNameGo}}.Valid = true
 	{{- end}}
 
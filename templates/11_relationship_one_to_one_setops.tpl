@@ -3,8 +3,8 @@
 	{{- $dot := . -}}
 	{{- range .Table.ToOneRelationships -}}
 		{{- $txt := txtsFromOneToOne $dot.Tables $dot.Table . -}}
-		{{- $varNameSingular := .Table | singular | camelCase -}}
-		{{- $foreignVarNameSingular := .ForeignTable | singular | camelCase -}}
+		{{- $varNameSingular := (getTable $dot.Tables .Table).VarName -}}
+		{{- $foreignVarNameSingular := (getTable $dot.Tables .ForeignTable).VarName -}}
 		{{- $foreignPKeyCols := (getTable $dot.Tables .ForeignTable).PKey.Columns -}}
 		{{- $foreignSchemaTable := .ForeignTable | $dot.SchemaTable}}
 // Set{{$txt.Function.Name}}G of the {{.Table | singular}} to the related item.
@@ -43,7 +43,7 @@ func (o *{{$txt.LocalTable.NameGo}}) Set{{$txt.Function.Name}}(exec boil.Executo
 
 	if insert {
 		related.{{$txt.Function.ForeignAssignment}} = o.{{$txt.Function.LocalAssignment}}
-		{{if .ForeignColumnNullable -}}
+		{{if $txt.ForeignTable.ColumnNullType -}}
 		related.{{$txt.ForeignTable.ColumnNameGo}}.Valid = true
 		{{- end}}
 
@@ -56,7 +56,7 @@ func (o *{{$txt.LocalTable.NameGo}}) Set{{$txt.Function.Name}}(exec boil.Executo
 			strmangle.SetParamNames("{{$dot.LQ}}", "{{$dot.RQ}}", {{if $dot.Dialect.IndexPlaceholders}}1{{else}}0{{end}}, []string{{"{"}}"{{.ForeignColumn}}"{{"}"}}),
 			strmangle.WhereClause("{{$dot.LQ}}", "{{$dot.RQ}}", {{if $dot.Dialect.IndexPlaceholders}}2{{else}}0{{end}}, {{$foreignVarNameSingular}}PrimaryKeyColumns),
 		)
-		values := []interface{}{o.{{$txt.LocalTable.ColumnNameGo}}, related.{{$foreignPKeyCols | stringMap $dot.StringFuncs.titleCase | join ", related."}}{{"}"}}
+		values := []interface{}{o.{{$txt.LocalTable.ColumnNameGo}}, related.{{$foreignPKeyCols | (getTable $dot.Tables .ForeignTable).ColumnGoNames | join ", related."}}{{"}"}}
 
 		if boil.DebugMode {
 			fmt.Fprintln(boil.DebugWriter, updateQuery)
@@ -68,7 +68,7 @@ func (o *{{$txt.LocalTable.NameGo}}) Set{{$txt.Function.Name}}(exec boil.Executo
 		}
 
 		related.{{$txt.Function.ForeignAssignment}} = o.{{$txt.Function.LocalAssignment}}
-		{{if .ForeignColumnNullable -}}
+		{{if $txt.ForeignTable.ColumnNullType -}}
 		related.{{$txt.ForeignTable.ColumnNameGo}}.Valid = true
 		{{- end}}
 	}
@@ -4,8 +4,8 @@
 	{{- $table := .Table -}}
 	{{- range .Table.ToManyRelationships -}}
 		{{- $txt := txtsFromToMany $dot.Tables $table . -}}
-		{{- $varNameSingular := .Table | singular | camelCase -}}
-		{{- $foreignVarNameSingular := .ForeignTable | singular | camelCase}}
+		{{- $varNameSingular := (getTable $dot.Tables .Table).VarName -}}
+		{{- $foreignVarNameSingular := (getTable $dot.Tables .ForeignTable).VarName}}
 		{{- $foreignPKeyCols := (getTable $dot.Tables .ForeignTable).PKey.Columns -}}
 		{{- $foreignSchemaTable := .ForeignTable | $dot.SchemaTable}}
 // Add{{$txt.Function.Name}}G adds the given related objects to the existing relationships
@@ -49,7 +49,7 @@ func (o *{{$txt.LocalTable.NameGo}}) Add{{$txt.Function.Name}}(exec boil.Executo
 		if insert {
 			{{if not .ToJoinTable -}}
 			rel.{{$txt.Function.ForeignAssignment}} = o.{{$txt.Function.LocalAssignment}}
-				{{if .ForeignColumnNullable -}}
+				{{if $txt.ForeignTable.ColumnNullType -}}
 			rel.{{$txt.ForeignTable.ColumnNameGo}}.Valid = true
 				{{end -}}
 			{{end -}}
@@ -63,7 +63,7 @@ func (o *{{$txt.LocalTable.NameGo}}) Add{{$txt.Function.Name}}(exec boil.Executo
 				strmangle.SetParamNames("{{$dot.LQ}}", "{{$dot.RQ}}", {{if $dot.Dialect.IndexPlaceholders}}1{{else}}0{{end}}, []string{{"{"}}"{{.ForeignColumn}}"{{"}"}}),
 				strmangle.WhereClause("{{$dot.LQ}}", "{{$dot.RQ}}", {{if $dot.Dialect.IndexPlaceholders}}2{{else}}0{{end}}, {{$foreignVarNameSingular}}PrimaryKeyColumns),
 			)
-			values := []interface{}{o.{{$txt.LocalTable.ColumnNameGo}}, rel.{{$foreignPKeyCols | stringMap $dot.StringFuncs.titleCase | join ", rel."}}{{"}"}}
+			values := []interface{}{o.{{$txt.LocalTable.ColumnNameGo}}, rel.{{$foreignPKeyCols | (getTable $dot.Tables .ForeignTable).ColumnGoNames | join ", rel."}}{{"}"}}
 
 			if boil.DebugMode {
 				fmt.Fprintln(boil.DebugWriter, updateQuery)
@@ -75,7 +75,7 @@ func (o *{{$txt.LocalTable.NameGo}}) Add{{$txt.Function.Name}}(exec boil.Executo
 			}
 
 			rel.{{$txt.Function.ForeignAssignment}} = o.{{$txt.Function.LocalAssignment}}
-			{{if .ForeignColumnNullable -}}
+			{{if $txt.ForeignTable.ColumnNullType -}}
 			rel.{{$txt.ForeignTable.ColumnNameGo}}.Valid = true
 			{{end -}}
 		}{{end -}}
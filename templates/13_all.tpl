@@ -1,5 +1,5 @@
-{{- $tableNamePlural := .Table.Name | plural | titleCase -}}
-{{- $varNameSingular := .Table.Name | singular | camelCase}}
+{{- $tableNamePlural := .Table.GoNamePlural -}}
+{{- $varNameSingular := .Table.VarName}}
 // {{$tableNamePlural}}G retrieves all records.
 func {{$tableNamePlural}}G(mods ...qm.QueryMod) {{$varNameSingular}}Query {
 	return {{$tableNamePlural}}(boil.GetDB(), mods...)
@@ -1,5 +1,5 @@
-{{- $tableNameSingular := .Table.Name | singular | titleCase -}}
-{{- $varNameSingular := .Table.Name | singular | camelCase -}}
+{{- $tableNameSingular := .Table.GoName -}}
+{{- $varNameSingular := .Table.VarName -}}
 {{- $schemaTable := .Table.Name | .SchemaTable}}
 // InsertG a single record. See Insert for whitelist behavior description.
 func (o *{{$tableNameSingular}}) InsertG(whitelist ... string) error {
@@ -130,7 +130,7 @@ func (o *{{$tableNameSingular}}) Insert(exec boil.Executor, whitelist ... string
 
 	{{$colName := index .Table.PKey.Columns 0 -}}
 	{{- $col := .Table.GetColumn $colName -}}
-	{{- $colTitled := $colName | titleCase}}
+	{{- $colTitled := $col.GoName}}
 	o.{{$colTitled}} = {{$col.Type}}(lastID)
 	if lastID != 0 && len(cache.retMapping) == 1 && cache.retMapping[0] == {{$varNameSingular}}Mapping["{{$colTitled}}"] {
 		goto CacheNoHooks
@@ -138,8 +138,8 @@ func (o *{{$tableNameSingular}}) Insert(exec boil.Executor, whitelist ... string
 	{{- end}}
 
 	identifierCols = []interface{}{
-		{{range .Table.PKey.Columns -}}
-		o.{{. | titleCase}},
+		{{range .Table.ColumnGoNames .Table.PKey.Columns -}}
+		o.{{.}},
 		{{end -}}
 	}
 
@@ -1,5 +1,5 @@
-{{- $tableNameSingular := .Table.Name | singular | titleCase -}}
-{{- $varNameSingular := .Table.Name | singular | camelCase -}}
+{{- $tableNameSingular := .Table.GoName -}}
+{{- $varNameSingular := .Table.VarName -}}
 {{- $schemaTable := .Table.Name | .SchemaTable}}
 // UpdateG a single {{$tableNameSingular}} record. See Update for
 // whitelist behavior description.
@@ -1,6 +1,6 @@
-{{- $tableNameSingular := .Table.Name | singular | titleCase -}}
-{{- $varNameSingular := .Table.Name | singular | camelCase -}}
-{{- $varNamePlural := .Table.Name | plural | camelCase -}}
+{{- $tableNameSingular := .Table.GoName -}}
+{{- $varNameSingular := .Table.VarName -}}
+{{- $varNamePlural := .Table.VarNamePlural -}}
 {{- $schemaTable := .Table.Name | .SchemaTable}}
 // ReloadGP refetches the object from the database and panics on error.
 func (o *{{$tableNameSingular}}) ReloadGP() {
@@ -28,7 +28,7 @@ func (o *{{$tableNameSingular}}) ReloadG() error {
 // Reload refetches the object from the database
 // using the primary keys with an executor.
 func (o *{{$tableNameSingular}}) Reload(exec boil.Executor) error {
-	ret, err := Find{{$tableNameSingular}}(exec, {{.Table.PKey.Columns | stringMap .StringFuncs.titleCase | prefixStringSlice "o." | join ", "}})
+	ret, err := Find{{$tableNameSingular}}(exec, {{.Table.PKey.Columns | .Table.ColumnGoNames | prefixStringSlice "o." | join ", "}})
 	if err != nil {
 		return err
 	}
@@ -1,4 +1,4 @@
-{{- $tableNameSingular := .Table.Name | singular | titleCase -}}
+{{- $tableNameSingular := .Table.GoName -}}
 {{- $colDefs := sqlColDefinitions .Table.Columns .Table.PKey.Columns -}}
 {{- $pkNames := $colDefs.Names | stringMap .StringFuncs.camelCase | stringMap .StringFuncs.replaceReserved -}}
 {{- $pkArgs := joinSlices " " $pkNames $colDefs.Types | join ", " -}}
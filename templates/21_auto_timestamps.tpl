@@ -5,7 +5,7 @@
 	currTime := time.Now().In(boil.GetLocation())
 		{{range $ind, $col := .Table.Columns}}
 			{{- if eq $col.Name "created_at" -}}
-				{{- if $col.Nullable}}
+				{{- if $col.NullType}}
 	if o.CreatedAt.Time.IsZero() {
 		o.CreatedAt.Time = currTime
 		o.CreatedAt.Valid = true
@@ -17,7 +17,7 @@
 				{{- end -}}
 			{{- end -}}
 			{{- if eq $col.Name "updated_at" -}}
-				{{- if $col.Nullable}}
+				{{- if $col.NullType}}
 	if o.UpdatedAt.Time.IsZero() {
 		o.UpdatedAt.Time = currTime
 		o.UpdatedAt.Valid = true
@@ -39,7 +39,7 @@
 	currTime := time.Now().In(boil.GetLocation())
 		{{range $ind, $col := .Table.Columns}}
 			{{- if eq $col.Name "updated_at" -}}
-				{{- if $col.Nullable}}
+				{{- if $col.NullType}}
 	o.UpdatedAt.Time = currTime
 	o.UpdatedAt.Valid = true
 				{{- else}}
@@ -57,7 +57,7 @@
 	currTime := time.Now().In(boil.GetLocation())
 		{{range $ind, $col := .Table.Columns}}
 			{{- if eq $col.Name "created_at" -}}
-				{{- if $col.Nullable}}
+				{{- if $col.NullType}}
 	if o.CreatedAt.Time.IsZero() {
 		o.CreatedAt.Time = currTime
 		o.CreatedAt.Valid = true
@@ -69,7 +69,7 @@
 				{{- end -}}
 			{{- end -}}
 			{{- if eq $col.Name "updated_at" -}}
-				{{- if $col.Nullable}}
+				{{- if $col.NullType}}
 	o.UpdatedAt.Time = currTime
 	o.UpdatedAt.Valid = true
 				{{- else}}
@@ -1,9 +1,9 @@
 var TableNames = struct {
 	{{range $table := .Tables -}}
-	{{titleCase $table.Name}} string
+	{{$table.GoNamePlural}} string
 	{{end -}}
 }{
 	{{range $table := .Tables -}}
-	{{titleCase $table.Name}}: "{{$table.Name}}",
+	{{$table.GoNamePlural}}: "{{$table.Name}}",
 	{{end -}}
 }
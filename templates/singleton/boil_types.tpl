@@ -62,8 +62,8 @@ It only titlecases the EnumValue portion if it's snake-cased.
 {{$once := onceNew}}
 {{- range $table := .Tables -}}
 	{{- range $col := $table.Columns | filterColumnsByEnum -}}
-		{{- $name := parseEnumName $col.DBType -}}
-		{{- $vals := parseEnumVals $col.DBType -}}
+		{{- $name := parseEnumName $col.EnumDBType -}}
+		{{- $vals := parseEnumVals $col.EnumDBType -}}
 		{{- $isNamed := ne (len $name) 0}}
 		{{- if and $isNamed (onceHas $once $name) -}}
 		{{- else -}}
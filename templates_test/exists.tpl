@@ -1,7 +1,7 @@
-{{- $tableNameSingular := .Table.Name | singular | titleCase -}}
-{{- $tableNamePlural := .Table.Name | plural | titleCase -}}
-{{- $varNamePlural := .Table.Name | plural | camelCase -}}
-{{- $varNameSingular := .Table.Name | singular | camelCase -}}
+{{- $tableNameSingular := .Table.GoName -}}
+{{- $tableNamePlural := .Table.GoNamePlural -}}
+{{- $varNamePlural := .Table.VarNamePlural -}}
+{{- $varNameSingular := .Table.VarName -}}
 func test{{$tableNamePlural}}Exists(t *testing.T) {
 	t.Parallel()
 
@@ -18,7 +18,7 @@ func test{{$tableNamePlural}}Exists(t *testing.T) {
 		t.Error(err)
 	}
 
-	{{$pkeyArgs := .Table.PKey.Columns | stringMap .StringFuncs.titleCase | prefixStringSlice (printf "%s." $varNameSingular) | join ", " -}}
+	{{$pkeyArgs := .Table.PKey.Columns | .Table.ColumnGoNames | prefixStringSlice (printf "%s." $varNameSingular) | join ", " -}}
 	e, err := {{$tableNameSingular}}Exists(tx, {{$pkeyArgs}})
 	if err != nil {
 		t.Errorf("Unable to check if {{$tableNameSingular}} exists: %s", err)
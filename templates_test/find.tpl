@@ -1,7 +1,7 @@
-{{- $tableNameSingular := .Table.Name | singular | titleCase -}}
-{{- $tableNamePlural := .Table.Name | plural | titleCase -}}
-{{- $varNamePlural := .Table.Name | plural | camelCase -}}
-{{- $varNameSingular := .Table.Name | singular | camelCase -}}
+{{- $tableNameSingular := .Table.GoName -}}
+{{- $tableNamePlural := .Table.GoNamePlural -}}
+{{- $varNamePlural := .Table.VarNamePlural -}}
+{{- $varNameSingular := .Table.VarName -}}
 func test{{$tableNamePlural}}Find(t *testing.T) {
 	t.Parallel()
 
@@ -18,7 +18,7 @@ func test{{$tableNamePlural}}Find(t *testing.T) {
 		t.Error(err)
 	}
 
-	{{$varNameSingular}}Found, err := Find{{$tableNameSingular}}(tx, {{.Table.PKey.Columns | stringMap .StringFuncs.titleCase | prefixStringSlice (printf "%s." $varNameSingular) | join ", "}})
+	{{$varNameSingular}}Found, err := Find{{$tableNameSingular}}(tx, {{.Table.PKey.Columns | .Table.ColumnGoNames | prefixStringSlice (printf "%s." $varNameSingular) | join ", "}})
 	if err != nil {
 		t.Error(err)
 	}
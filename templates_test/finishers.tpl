@@ -1,7 +1,7 @@
-{{- $tableNameSingular := .Table.Name | singular | titleCase -}}
-{{- $tableNamePlural := .Table.Name | plural | titleCase -}}
-{{- $varNamePlural := .Table.Name | plural | camelCase -}}
-{{- $varNameSingular := .Table.Name | singular | camelCase -}}
+{{- $tableNameSingular := .Table.GoName -}}
+{{- $tableNamePlural := .Table.GoNamePlural -}}
+{{- $varNamePlural := .Table.VarNamePlural -}}
+{{- $varNameSingular := .Table.VarName -}}
 func test{{$tableNamePlural}}Bind(t *testing.T) {
 	t.Parallel()
 
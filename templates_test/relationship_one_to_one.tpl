@@ -3,8 +3,8 @@
 	{{- $dot := . -}}
 	{{- range .Table.ToOneRelationships -}}
 		{{- $txt := txtsFromOneToOne $dot.Tables $dot.Table . -}}
-		{{- $varNameSingular := .Table | singular | camelCase -}}
-		{{- $foreignVarNameSingular := .ForeignTable | singular | camelCase}}
+		{{- $varNameSingular := (getTable $dot.Tables .Table).VarName -}}
+		{{- $foreignVarNameSingular := (getTable $dot.Tables .ForeignTable).VarName}}
 func test{{$txt.LocalTable.NameGo}}OneToOne{{$txt.ForeignTable.NameGo}}Using{{$txt.Function.Name}}(t *testing.T) {
 	tx := MustTx(boil.Begin())
 	defer tx.Rollback()
@@ -20,10 +20,10 @@ func test{{$txt.LocalTable.NameGo}}OneToOne{{$txt.ForeignTable.NameGo}}Using{{$t
 		t.Errorf("Unable to randomize {{$txt.LocalTable.NameGo}} struct: %s", err)
 	}
 
-	{{if .ForeignColumnNullable -}}
+	{{if $txt.ForeignTable.ColumnNullType -}}
 	foreign.{{$txt.ForeignTable.ColumnNameGo}}.Valid = true
 	{{- end}}
-	{{if .Nullable -}}
+	{{if $txt.LocalTable.ColumnNullType -}}
 	local.{{$txt.LocalTable.ColumnNameGo}}.Valid = true
 	{{- end}}
 
@@ -3,8 +3,8 @@
 	{{- $dot := . -}}
 	{{- range .Table.ToOneRelationships -}}
 		{{- $txt := txtsFromOneToOne $dot.Tables $dot.Table .}}
-{{- $varNameSingular := .Table | singular | camelCase -}}
-{{- $foreignVarNameSingular := .ForeignTable | singular | camelCase -}}
+{{- $varNameSingular := (getTable $dot.Tables .Table).VarName -}}
+{{- $foreignVarNameSingular := (getTable $dot.Tables .ForeignTable).VarName -}}
 {{- $foreignPKeyCols := (getTable $dot.Tables .ForeignTable).PKey.Columns}}
 func test{{$txt.LocalTable.NameGo}}OneToOneSetOp{{$txt.ForeignTable.NameGo}}Using{{$txt.Function.Name}}(t *testing.T) {
 	var err error
@@ -55,7 +55,7 @@ func test{{$txt.LocalTable.NameGo}}OneToOneSetOp{{$txt.ForeignTable.NameGo}}Usin
 		}
 
 		{{if setInclude .ForeignColumn $foreignPKeyCols -}}
-		if exists, err := {{$txt.ForeignTable.NameGo}}Exists(tx, x.{{$foreignPKeyCols | stringMap $dot.StringFuncs.titleCase | join ", x."}}); err != nil {
+		if exists, err := {{$txt.ForeignTable.NameGo}}Exists(tx, x.{{$foreignPKeyCols | (getTable $dot.Tables .ForeignTable).ColumnGoNames | join ", x."}}); err != nil {
 			t.Fatal(err)
 		} else if !exists {
 			t.Error("want 'x' to exist")
@@ -4,8 +4,8 @@
 	{{- $table := .Table }}
 	{{- range .Table.ToManyRelationships -}}
 	{{- $txt := txtsFromToMany $dot.Tables $table .}}
-	{{- $varNameSingular := .Table | singular | camelCase -}}
-	{{- $foreignVarNameSingular := .ForeignTable | singular | camelCase -}}
+	{{- $varNameSingular := (getTable $dot.Tables .Table).VarName -}}
+	{{- $foreignVarNameSingular := (getTable $dot.Tables .ForeignTable).VarName -}}
 func test{{$txt.LocalTable.NameGo}}ToMany{{$txt.Function.Name}}(t *testing.T) {
 	var err error
 	tx := MustTx(boil.Begin())
@@ -25,12 +25,12 @@ func test{{$txt.LocalTable.NameGo}}ToMany{{$txt.Function.Name}}(t *testing.T) {
 
 	randomize.Struct(seed, &b, {{$foreignVarNameSingular}}DBTypes, false, {{$foreignVarNameSingular}}ColumnsWithDefault...)
 	randomize.Struct(seed, &c, {{$foreignVarNameSingular}}DBTypes, false, {{$foreignVarNameSingular}}ColumnsWithDefault...)
-	{{if .Nullable -}}
-	a.{{.Column | titleCase}}.Valid = true
+	{{if $txt.LocalTable.ColumnNullType -}}
+	a.{{$txt.LocalTable.ColumnNameGo}}.Valid = true
 	{{- end}}
-	{{- if .ForeignColumnNullable}}
-	b.{{.ForeignColumn | titleCase}}.Valid = true
-	c.{{.ForeignColumn | titleCase}}.Valid = true
+	{{- if $txt.ForeignTable.ColumnNullType}}
+	b.{{$txt.ForeignTable.ColumnNameGo}}.Valid = true
+	c.{{$txt.ForeignTable.ColumnNameGo}}.Valid = true
 	{{- end}}
 	{{if not .ToJoinTable -}}
 	b.{{$txt.Function.ForeignAssignment}} = a.{{$txt.Function.LocalAssignment}}
@@ -54,7 +54,7 @@ func test{{$txt.LocalTable.NameGo}}ToMany{{$txt.Function.Name}}(t *testing.T) {
 	}
 	{{end}}
 
-	{{$varname := .ForeignTable | singular | camelCase -}}
+	{{$varname := (getTable $dot.Tables .ForeignTable).VarName -}}
 	{{$varname}}, err := a.{{$txt.Function.Name}}(tx).All()
 	if err != nil {
 		t.Fatal(err)
@@ -3,8 +3,8 @@
 	{{- $dot := . -}}
 	{{- $table := .Table -}}
 	{{- range .Table.ToManyRelationships -}}
-	{{- $varNameSingular := .Table | singular | camelCase -}}
-	{{- $foreignVarNameSingular := .ForeignTable | singular | camelCase -}}
+	{{- $varNameSingular := (getTable $dot.Tables .Table).VarName -}}
+	{{- $foreignVarNameSingular := (getTable $dot.Tables .ForeignTable).VarName -}}
 	{{- $txt := txtsFromToMany $dot.Tables $table .}}
 func test{{$txt.LocalTable.NameGo}}ToManyAddOp{{$txt.Function.Name}}(t *testing.T) {
 	var err error
@@ -3,8 +3,8 @@
 	{{- $dot := . -}}
 	{{- range .Table.FKeys -}}
 		{{- $txt := txtsFromFKey $dot.Tables $dot.Table .}}
-{{- $varNameSingular := .Table | singular | camelCase -}}
-{{- $foreignVarNameSingular := .ForeignTable | singular | camelCase}}
+{{- $varNameSingular := (getTable $dot.Tables .Table).VarName -}}
+{{- $foreignVarNameSingular := (getTable $dot.Tables .ForeignTable).VarName}}
 func test{{$txt.LocalTable.NameGo}}ToOneSetOp{{$txt.ForeignTable.NameGo}}Using{{$txt.Function.Name}}(t *testing.T) {
 	var err error
 
@@ -61,7 +61,7 @@ func test{{$txt.LocalTable.NameGo}}ToOneSetOp{{$txt.ForeignTable.NameGo}}Using{{
 		}
 
 		{{if setInclude .Column $dot.Table.PKey.Columns -}}
-		if exists, err := {{$txt.LocalTable.NameGo}}Exists(tx, a.{{$dot.Table.PKey.Columns | stringMap $dot.StringFuncs.titleCase | join ", a."}}); err != nil {
+		if exists, err := {{$txt.LocalTable.NameGo}}Exists(tx, a.{{$dot.Table.PKey.Columns | $dot.Table.ColumnGoNames | join ", a."}}); err != nil {
 			t.Fatal(err)
 		} else if !exists {
 			t.Error("want 'a' to exist")
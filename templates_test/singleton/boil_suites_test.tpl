@@ -9,7 +9,7 @@ func TestParent(t *testing.T) {
   {{- range $index, $table := .Tables}}
   {{- if $table.IsJoinTable -}}
   {{- else -}}
-  {{- $tableName := $table.Name | plural | titleCase -}}
+  {{- $tableName := $table.GoNamePlural -}}
   t.Run("{{$tableName}}", test{{$tableName}})
   {{end -}}
   {{- end -}}
@@ -19,7 +19,7 @@ func TestDelete(t *testing.T) {
   {{- range $index, $table := .Tables}}
   {{- if $table.IsJoinTable -}}
   {{- else -}}
-  {{- $tableName := $table.Name | plural | titleCase -}}
+  {{- $tableName := $table.GoNamePlural -}}
   t.Run("{{$tableName}}", test{{$tableName}}Delete)
   {{end -}}
   {{- end -}}
@@ -29,7 +29,7 @@ func TestQueryDeleteAll(t *testing.T) {
   {{- range $index, $table := .Tables}}
   {{- if $table.IsJoinTable -}}
   {{- else -}}
-  {{- $tableName := $table.Name | plural | titleCase -}}
+  {{- $tableName := $table.GoNamePlural -}}
   t.Run("{{$tableName}}", test{{$tableName}}QueryDeleteAll)
   {{end -}}
   {{- end -}}
@@ -39,7 +39,7 @@ func TestSliceDeleteAll(t *testing.T) {
   {{- range $index, $table := .Tables}}
   {{- if $table.IsJoinTable -}}
   {{- else -}}
-  {{- $tableName := $table.Name | plural | titleCase -}}
+  {{- $tableName := $table.GoNamePlural -}}
   t.Run("{{$tableName}}", test{{$tableName}}SliceDeleteAll)
   {{end -}}
   {{- end -}}
@@ -49,7 +49,7 @@ func TestExists(t *testing.T) {
   {{- range $index, $table := .Tables}}
   {{- if $table.IsJoinTable -}}
   {{- else -}}
-  {{- $tableName := $table.Name | plural | titleCase -}}
+  {{- $tableName := $table.GoNamePlural -}}
   t.Run("{{$tableName}}", test{{$tableName}}Exists)
   {{end -}}
   {{- end -}}
@@ -59,7 +59,7 @@ func TestFind(t *testing.T) {
   {{- range $index, $table := .Tables}}
   {{- if $table.IsJoinTable -}}
   {{- else -}}
-  {{- $tableName := $table.Name | plural | titleCase -}}
+  {{- $tableName := $table.GoNamePlural -}}
   t.Run("{{$tableName}}", test{{$tableName}}Find)
   {{end -}}
   {{- end -}}
@@ -69,7 +69,7 @@ func TestBind(t *testing.T) {
   {{- range $index, $table := .Tables}}
   {{- if $table.IsJoinTable -}}
   {{- else -}}
-  {{- $tableName := $table.Name | plural | titleCase -}}
+  {{- $tableName := $table.GoNamePlural -}}
   t.Run("{{$tableName}}", test{{$tableName}}Bind)
   {{end -}}
   {{- end -}}
@@ -79,7 +79,7 @@ func TestOne(t *testing.T) {
   {{- range $index, $table := .Tables}}
   {{- if $table.IsJoinTable -}}
   {{- else -}}
-  {{- $tableName := $table.Name | plural | titleCase -}}
+  {{- $tableName := $table.GoNamePlural -}}
   t.Run("{{$tableName}}", test{{$tableName}}One)
   {{end -}}
   {{- end -}}
@@ -89,7 +89,7 @@ func TestAll(t *testing.T) {
   {{- range $index, $table := .Tables}}
   {{- if $table.IsJoinTable -}}
   {{- else -}}
-  {{- $tableName := $table.Name | plural | titleCase -}}
+  {{- $tableName := $table.GoNamePlural -}}
   t.Run("{{$tableName}}", test{{$tableName}}All)
   {{end -}}
   {{- end -}}
@@ -99,7 +99,7 @@ func TestCount(t *testing.T) {
   {{- range $index, $table := .Tables}}
   {{- if $table.IsJoinTable -}}
   {{- else -}}
-  {{- $tableName := $table.Name | plural | titleCase -}}
+  {{- $tableName := $table.GoNamePlural -}}
   t.Run("{{$tableName}}", test{{$tableName}}Count)
   {{end -}}
   {{- end -}}
@@ -110,7 +110,7 @@ func TestHooks(t *testing.T) {
   {{- range $index, $table := .Tables}}
   {{- if $table.IsJoinTable -}}
   {{- else -}}
-  {{- $tableName := $table.Name | plural | titleCase -}}
+  {{- $tableName := $table.GoNamePlural -}}
   t.Run("{{$tableName}}", test{{$tableName}}Hooks)
   {{end -}}
   {{- end -}}
@@ -121,7 +121,7 @@ func TestInsert(t *testing.T) {
   {{- range $index, $table := .Tables}}
   {{- if $table.IsJoinTable -}}
   {{- else -}}
-  {{- $tableName := $table.Name | plural | titleCase -}}
+  {{- $tableName := $table.GoNamePlural -}}
   t.Run("{{$tableName}}", test{{$tableName}}Insert)
   t.Run("{{$tableName}}", test{{$tableName}}InsertWhitelist)
   {{end -}}
@@ -282,7 +282,7 @@ func TestReload(t *testing.T) {
   {{- range $index, $table := .Tables}}
   {{- if $table.IsJoinTable -}}
   {{- else -}}
-  {{- $tableName := $table.Name | plural | titleCase -}}
+  {{- $tableName := $table.GoNamePlural -}}
   t.Run("{{$tableName}}", test{{$tableName}}Reload)
   {{end -}}
   {{- end -}}
@@ -292,7 +292,7 @@ func TestReloadAll(t *testing.T) {
   {{- range $index, $table := .Tables}}
   {{- if $table.IsJoinTable -}}
   {{- else -}}
-  {{- $tableName := $table.Name | plural | titleCase -}}
+  {{- $tableName := $table.GoNamePlural -}}
   t.Run("{{$tableName}}", test{{$tableName}}ReloadAll)
   {{end -}}
   {{- end -}}
@@ -302,7 +302,7 @@ func TestSelect(t *testing.T) {
   {{- range $index, $table := .Tables}}
   {{- if $table.IsJoinTable -}}
   {{- else -}}
-  {{- $tableName := $table.Name | plural | titleCase -}}
+  {{- $tableName := $table.GoNamePlural -}}
   t.Run("{{$tableName}}", test{{$tableName}}Select)
   {{end -}}
   {{- end -}}
@@ -312,7 +312,7 @@ func TestUpdate(t *testing.T) {
   {{- range $index, $table := .Tables}}
   {{- if $table.IsJoinTable -}}
   {{- else -}}
-  {{- $tableName := $table.Name | plural | titleCase -}}
+  {{- $tableName := $table.GoNamePlural -}}
   t.Run("{{$tableName}}", test{{$tableName}}Update)
   {{end -}}
   {{- end -}}
@@ -322,7 +322,7 @@ func TestSliceUpdateAll(t *testing.T) {
   {{- range $index, $table := .Tables}}
   {{- if $table.IsJoinTable -}}
   {{- else -}}
-  {{- $tableName := $table.Name | plural | titleCase -}}
+  {{- $tableName := $table.GoNamePlural -}}
   t.Run("{{$tableName}}", test{{$tableName}}SliceUpdateAll)
   {{end -}}
   {{- end -}}
@@ -332,7 +332,7 @@ func TestUpsert(t *testing.T) {
   {{- range $index, $table := .Tables}}
   {{- if $table.IsJoinTable -}}
   {{- else -}}
-  {{- $tableName := $table.Name | plural | titleCase -}}
+  {{- $tableName := $table.GoNamePlural -}}
   t.Run("{{$tableName}}", test{{$tableName}}Upsert)
   {{end -}}
   {{- end -}}
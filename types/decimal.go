@@ -0,0 +1,189 @@
+package types
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"math/big"
+)
+
+// decimalMaxScale is the most digits after the point postgres keeps for a
+// numeric, it's used for decimals that don't end, eg: 1/3
+const decimalMaxScale = 16383
+
+// Decimal is an arbitrary precision decimal, it's used for the postgres
+// numeric type without a precision, which a float64 can't hold exactly.
+// The zero value is 0.
+type Decimal struct {
+	*big.Rat
+}
+
+// NewDecimal creates a Decimal from d.
+func NewDecimal(d *big.Rat) Decimal {
+	return Decimal{Rat: d}
+}
+
+// String outputs the decimal without an exponent, eg: 12.5
+func (d Decimal) String() string {
+	if d.Rat == nil {
+		return "0"
+	}
+	if d.IsInt() {
+		return d.Num().String()
+	}
+
+	return d.FloatString(decimalScale(d.Denom()))
+}
+
+// decimalScale finds the digits after the point needed to write a fraction
+// with the denominator den exactly.
+func decimalScale(den *big.Int) int {
+	rest := new(big.Int).Set(den)
+	two, five, mod := big.NewInt(2), big.NewInt(5), new(big.Int)
+
+	twos, fives := 0, 0
+	for rest.Sign() != 0 {
+		if mod.Mod(rest, two).Sign() != 0 {
+			break
+		}
+		rest.Quo(rest, two)
+		twos++
+	}
+	for rest.Sign() != 0 {
+		if mod.Mod(rest, five).Sign() != 0 {
+			break
+		}
+		rest.Quo(rest, five)
+		fives++
+	}
+
+	if rest.Cmp(big.NewInt(1)) != 0 {
+		return decimalMaxScale
+	}
+	if twos > fives {
+		return twos
+	}
+	return fives
+}
+
+// Value returns the decimal as a string for the database.
+func (d Decimal) Value() (driver.Value, error) {
+	return d.String(), nil
+}
+
+// Scan stores the numeric src in d.
+func (d *Decimal) Scan(src interface{}) error {
+	r, err := scanDecimal(src)
+	if err != nil {
+		return err
+	}
+	if r == nil {
+		return errors.New("cannot scan null into decimal")
+	}
+
+	d.Rat = r
+	return nil
+}
+
+// MarshalJSON outputs the decimal as a JSON number.
+func (d Decimal) MarshalJSON() ([]byte, error) {
+	return []byte(d.String()), nil
+}
+
+// UnmarshalJSON reads a JSON number or string into d.
+func (d *Decimal) UnmarshalJSON(data []byte) error {
+	r, ok := new(big.Rat).SetString(string(bytes.Trim(data, `"`)))
+	if !ok {
+		return fmt.Errorf("invalid decimal: %s", data)
+	}
+
+	d.Rat = r
+	return nil
+}
+
+// NullDecimal is a Decimal that can be NULL.
+type NullDecimal struct {
+	Decimal Decimal
+	Valid   bool
+}
+
+// NewNullDecimal creates a NullDecimal from d.
+func NewNullDecimal(d *big.Rat, valid bool) NullDecimal {
+	return NullDecimal{Decimal: NewDecimal(d), Valid: valid}
+}
+
+// Value returns the decimal as a string, or nil when it isn't valid.
+func (n NullDecimal) Value() (driver.Value, error) {
+	if !n.Valid {
+		return nil, nil
+	}
+
+	return n.Decimal.Value()
+}
+
+// Scan stores the numeric src in n, NULL makes it invalid.
+func (n *NullDecimal) Scan(src interface{}) error {
+	r, err := scanDecimal(src)
+	if err != nil {
+		return err
+	}
+
+	n.Decimal.Rat, n.Valid = r, r != nil
+	return nil
+}
+
+// MarshalJSON outputs the decimal as a JSON number, or null.
+func (n NullDecimal) MarshalJSON() ([]byte, error) {
+	if !n.Valid {
+		return []byte("null"), nil
+	}
+
+	return n.Decimal.MarshalJSON()
+}
+
+// UnmarshalJSON reads a JSON number, string or null into n.
+func (n *NullDecimal) UnmarshalJSON(data []byte) error {
+	if bytes.Equal(data, []byte("null")) {
+		n.Decimal.Rat, n.Valid = nil, false
+		return nil
+	}
+
+	if err := n.Decimal.UnmarshalJSON(data); err != nil {
+		return err
+	}
+
+	n.Valid = true
+	return nil
+}
+
+// scanDecimal converts a database value to a big.Rat, nil stays nil
+func scanDecimal(src interface{}) (*big.Rat, error) {
+	var str string
+
+	switch src := src.(type) {
+	case nil:
+		return nil, nil
+	case string:
+		str = src
+	case []byte:
+		str = string(src)
+	case int64:
+		return new(big.Rat).SetInt64(src), nil
+	case float64:
+		r := new(big.Rat).SetFloat64(src)
+		if r == nil {
+			return nil, fmt.Errorf("invalid decimal: %v", src)
+		}
+		return r, nil
+	default:
+		return nil, errors.New("incompatible type for decimal")
+	}
+
+	r, ok := new(big.Rat).SetString(str)
+	if !ok {
+		return nil, fmt.Errorf("invalid decimal: %s", str)
+	}
+
+	return r, nil
+}
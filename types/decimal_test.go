@@ -0,0 +1,100 @@
+package types
+
+import (
+	"encoding/json"
+	"math/big"
+	"testing"
+)
+
+func TestDecimalString(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		In   string
+		Want string
+	}{
+		{"0", "0"},
+		{"12.50", "12.5"},
+		{"-0.001", "-0.001"},
+		{"123456789012345678901234567890.000000000000000000001", "123456789012345678901234567890.000000000000000000001"},
+	}
+
+	for i, test := range tests {
+		var d Decimal
+		if err := d.Scan([]byte(test.In)); err != nil {
+			t.Fatal(err)
+		}
+		if got := d.String(); got != test.Want {
+			t.Errorf("%d) want %s, got %s", i, test.Want, got)
+		}
+	}
+
+	if got := (Decimal{}).String(); got != "0" {
+		t.Errorf("zero value should be 0, got %s", got)
+	}
+}
+
+func TestDecimalScan(t *testing.T) {
+	t.Parallel()
+
+	var d Decimal
+	if err := d.Scan(nil); err == nil {
+		t.Error("expected an error scanning null")
+	}
+	if err := d.Scan("abc"); err == nil {
+		t.Error("expected an error scanning a bad decimal")
+	}
+	if err := d.Scan(int64(5)); err != nil || d.String() != "5" {
+		t.Errorf("scan int64 was wrong: %v %s", err, d)
+	}
+
+	v, err := NewDecimal(big.NewRat(1, 4)).Value()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v != "0.25" {
+		t.Errorf("value was wrong: %v", v)
+	}
+}
+
+func TestNullDecimal(t *testing.T) {
+	t.Parallel()
+
+	var n NullDecimal
+	if err := n.Scan(nil); err != nil {
+		t.Fatal(err)
+	}
+	if v, _ := n.Value(); n.Valid || v != nil {
+		t.Errorf("should be null: %#v", n)
+	}
+
+	if err := n.Scan("1.5"); err != nil {
+		t.Fatal(err)
+	}
+	if v, _ := n.Value(); !n.Valid || v != "1.5" {
+		t.Errorf("should be 1.5: %v", v)
+	}
+}
+
+func TestDecimalJSON(t *testing.T) {
+	t.Parallel()
+
+	var out struct {
+		D Decimal
+		N NullDecimal
+	}
+	if err := json.Unmarshal([]byte(`{"D":"10.01","N":null}`), &out); err != nil {
+		t.Fatal(err)
+	}
+	if out.D.String() != "10.01" || out.N.Valid {
+		t.Errorf("unmarshal was wrong: %#v", out)
+	}
+
+	b, err := json.Marshal(out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(b) != `{"D":10.01,"N":null}` {
+		t.Errorf("marshal was wrong: %s", b)
+	}
+}
@@ -0,0 +1,62 @@
+package types
+
+import (
+	"database/sql/driver"
+	"errors"
+	"strings"
+)
+
+// Set is the value of a MySQL SET column, the members it holds. MySQL
+// stores a set as its members separated by commas, which is how it's
+// scanned and written. A nil Set is NULL and an empty one is the empty set.
+type Set []string
+
+// String output your set as MySQL writes it, eg: a,b
+func (s Set) String() string {
+	return strings.Join(s, ",")
+}
+
+// Contains checks if member is in the set.
+func (s Set) Contains(member string) bool {
+	for _, m := range s {
+		if m == member {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Value returns s as a driver.Value.
+func (s Set) Value() (driver.Value, error) {
+	if s == nil {
+		return nil, nil
+	}
+
+	return s.String(), nil
+}
+
+// Scan stores the src in *s.
+func (s *Set) Scan(src interface{}) error {
+	var source string
+
+	switch src.(type) {
+	case nil:
+		*s = nil
+		return nil
+	case string:
+		source = src.(string)
+	case []byte:
+		source = string(src.([]byte))
+	default:
+		return errors.New("incompatible type for set")
+	}
+
+	if len(source) == 0 {
+		*s = Set{}
+		return nil
+	}
+
+	*s = strings.Split(source, ",")
+	return nil
+}
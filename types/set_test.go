@@ -0,0 +1,58 @@
+package types
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSetScan(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		Src  interface{}
+		Want Set
+	}{
+		{nil, nil},
+		{"", Set{}},
+		{"read", Set{"read"}},
+		{[]byte("read,write"), Set{"read", "write"}},
+	}
+
+	for i, test := range tests {
+		var s Set
+		if err := s.Scan(test.Src); err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(s, test.Want) {
+			t.Errorf("%d) want %#v, got %#v", i, test.Want, s)
+		}
+	}
+
+	var s Set
+	if err := s.Scan(1); err == nil {
+		t.Error("expected an error scanning an int")
+	}
+}
+
+func TestSetValue(t *testing.T) {
+	t.Parallel()
+
+	if v, err := Set(nil).Value(); err != nil || v != nil {
+		t.Errorf("a nil set should be NULL, got %#v: %v", v, err)
+	}
+	if v, err := (Set{}).Value(); err != nil || v != "" {
+		t.Errorf("an empty set should be empty, got %#v: %v", v, err)
+	}
+	if v, err := (Set{"read", "write"}).Value(); err != nil || v != "read,write" {
+		t.Errorf("want read,write, got %#v: %v", v, err)
+	}
+}
+
+func TestSetContains(t *testing.T) {
+	t.Parallel()
+
+	s := Set{"read", "write"}
+	if !s.Contains("write") || s.Contains("admin") {
+		t.Errorf("contains was wrong for %v", s)
+	}
+}